@@ -5,6 +5,7 @@ type RegisterRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=8"`
 	FullName string `json:"full_name" validate:"required,min=2,max=100"`
+	Role     string `json:"role,omitempty" validate:"omitempty,oneof=freelancer client"` // optional; defaults to user
 }
 
 // LoginRequest represents the request body for user login
@@ -25,4 +26,3 @@ type AuthResponse struct {
 	TokenType    string `json:"token_type"`
 	ExpiresIn    int    `json:"expires_in"`
 }
-
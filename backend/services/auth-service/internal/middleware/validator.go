@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -54,7 +55,7 @@ func (v *Validator) formatValidationError(err error) error {
 		return err
 	}
 
-	return fmt.Errorf(strings.Join(messages, "; "))
+	return errors.New(strings.Join(messages, "; "))
 }
 
 // getValidationMessage returns a human-readable validation message
@@ -68,8 +69,9 @@ func getValidationMessage(fieldError validator.FieldError) string {
 		return fmt.Sprintf("must be at least %s characters", fieldError.Param())
 	case "max":
 		return fmt.Sprintf("must be at most %s characters", fieldError.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", fieldError.Param())
 	default:
 		return fmt.Sprintf("failed validation rule: %s", fieldError.Tag())
 	}
 }
-
@@ -45,12 +45,16 @@ func (s *AuthService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, err
 		return nil, err
 	}
 
-	// Create user
+	// Create user (clients register with role client to get the client dashboard in contract-service)
+	role := domain.RoleUser
+	if req.Role != "" {
+		role = req.Role
+	}
 	user := &domain.User{
 		Email:    req.Email,
 		Password: string(hashedPassword),
 		FullName: req.FullName,
-		Role:     domain.RoleUser,
+		Role:     role,
 		IsActive: true,
 	}
 
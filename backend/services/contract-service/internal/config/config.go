@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 // Config holds all configuration for the application
@@ -11,6 +12,7 @@ type Config struct {
 	App      AppConfig
 	Database DatabaseConfig
 	JWT      JWTConfig
+	Storage  StorageConfig
 }
 
 // ServerConfig holds server-related configuration
@@ -24,11 +26,65 @@ type ServerConfig struct {
 
 // AppConfig holds application-level configuration
 type AppConfig struct {
-	Environment               string
-	LogLevel                  string
-	ShareableLinkBaseURL      string // Base for contract links, e.g. https://app.ourdomain.com/contract
-	DraftExpiryDays           int    // Delete drafts older than this (default 14)
-	DraftCleanupIntervalMins  int    // Run draft-cleanup job every N minutes (default 360 = 6h)
+	Environment              string
+	LogLevel                 string
+	ShareableLinkBaseURL     string // Base for contract links, e.g. https://app.ourdomain.com/contract
+	DraftExpiryDays          int    // Delete drafts older than this (default 14)
+	DraftCleanupIntervalMins int    // Run draft-cleanup job every N minutes (default 360 = 6h)
+	ImportMaxRows            int    // Max data rows per CSV import (default 500)
+	SignOTPRequired          bool   // Client must confirm an emailed one-time code before signing
+	SignOTPTTLMins           int    // Sign code validity in minutes (default 10)
+	SignOTPMaxAttempts       int    // Wrong codes allowed per issued code (default 5)
+	SignOTPCooldownSecs      int    // Minimum seconds between codes for one contract (default 60)
+	SignatureRequired        bool   // Client sign must carry a typed or drawn signature (default false)
+	SignatureMaxBytes        int    // Max decoded size of a drawn signature image (default 262144)
+
+	// Events to user-service (completed contracts -> verified profile projects, testimonials, reputation)
+	UserServiceURL                string // e.g. http://localhost:8081; empty = events are not published
+	InternalAPIToken              string // shared secret sent as X-Internal-Token; same value as user-service
+	CompletionPublishIntervalMins int    // Retry undelivered completion and testimonial events every N minutes (default 15)
+
+	// Disputes
+	DisputeResponseHours        int // Time the other party has to answer a new dispute (default 72)
+	DisputeSettleDays           int // Time to settle once both parties engaged, before escalation (default 14)
+	DisputeDeadlineIntervalMins int // Check dispute deadlines every N minutes (default 15)
+
+	// Reputation
+	ReputationIntervalMins int // Score unscored contracts and re-send aggregates every N minutes (default 30)
+
+	// Idempotency-Key on create, send and sign
+	IdempotencyTTLHours            int // Stored responses are replayed for this long (default 24)
+	IdempotencyCleanupIntervalMins int // Delete expired keys every N minutes (default 60)
+
+	// Public link routes (/api/v1/public/...): per-minute limits, 0 = off
+	PublicRateIPPerMin          int // Requests per client IP (default 120)
+	PublicRateTokenPerMin       int // Requests per link token (default 60)
+	PublicRateTokenWritesPerMin int // POST/PUT/DELETE per link token (default 10)
+	PublicMaxFailedLookups      int // Unknown tokens per IP within the failure window before it is blocked (default 10)
+	PublicFailureWindowMins     int // Failure window (default 10)
+	PublicBlockMins             int // How long a blocked IP gets 429 (default 15)
+
+	// Signed client links
+	ClientLinkKeys         string // "kid:secret,kid:secret"; first signs, all verify. Empty = bare client_view_token links
+	ClientLinkTTLDays      int    // Lifetime of links sent by email (default 180)
+	ClientLinkAcceptLegacy bool   // Keep accepting bare-token links sent before signing was enabled (default true)
+
+	// Client link opens
+	ContractViewNotify bool // Tell the freelancer when the client first opens the contract link (default true)
+
+	// Optimistic concurrency
+	RequireIfMatch bool // PUT /contracts/:id without If-Match → 428 (default false: unconditional write for older clients)
+
+	// Calendar subscription feeds
+	CalendarFeedBaseURL string // Public base URL of this API for feed URLs, e.g. https://api.ourdomain.com (empty = URL is a path)
+
+	// Live contract events (SSE)
+	ContractEventRetentionDays       int // Stored events a reconnecting stream can resume from are kept this long (default 7)
+	ContractEventCleanupIntervalMins int // Delete old events every N minutes (default 60)
+	ContractEventHeartbeatSecs       int // Heartbeat comment interval on open streams (default 25)
+
+	// Retainer contracts
+	RetainerCycleIntervalMins int // Generate started retainer periods every N minutes (default 60)
 }
 
 // DatabaseConfig holds PostgreSQL configuration
@@ -46,6 +102,20 @@ type JWTConfig struct {
 	Secret string
 }
 
+// StorageConfig holds attachment blob storage configuration
+type StorageConfig struct {
+	Backend                string // local | s3
+	LocalDir               string // for local backend
+	S3Endpoint             string // S3-compatible endpoint (AWS, MinIO, R2)
+	S3Region               string
+	S3Bucket               string
+	S3Prefix               string
+	S3AccessKeyID          string
+	S3SecretAccessKey      string
+	AttachmentMaxBytes     int64    // per-file limit (default 20 MB)
+	AttachmentAllowedTypes []string // detected MIME types allowed; empty = service defaults
+}
+
 // Load reads configuration from environment variables with defaults
 func Load() *Config {
 	return &Config{
@@ -60,8 +130,51 @@ func Load() *Config {
 			Environment:              getEnv("APP_ENV", "development"),
 			LogLevel:                 getEnv("LOG_LEVEL", "info"),
 			ShareableLinkBaseURL:     getEnv("SHAREABLE_LINK_BASE_URL", ""),
-			DraftExpiryDays:           getEnvAsInt("DRAFT_EXPIRY_DAYS", 14),
+			DraftExpiryDays:          getEnvAsInt("DRAFT_EXPIRY_DAYS", 14),
 			DraftCleanupIntervalMins: getEnvAsInt("DRAFT_CLEANUP_INTERVAL_MINS", 360),
+			ImportMaxRows:            getEnvAsInt("IMPORT_MAX_ROWS", 500),
+			SignOTPRequired:          getEnvAsBool("SIGN_OTP_REQUIRED", false),
+			SignOTPTTLMins:           getEnvAsInt("SIGN_OTP_TTL_MINS", 10),
+			SignOTPMaxAttempts:       getEnvAsInt("SIGN_OTP_MAX_ATTEMPTS", 5),
+			SignOTPCooldownSecs:      getEnvAsInt("SIGN_OTP_COOLDOWN_SECS", 60),
+			SignatureRequired:        getEnvAsBool("SIGNATURE_REQUIRED", false),
+			SignatureMaxBytes:        getEnvAsInt("SIGNATURE_MAX_BYTES", 256<<10),
+
+			UserServiceURL:                getEnv("USER_SERVICE_URL", ""),
+			InternalAPIToken:              getEnv("INTERNAL_API_TOKEN", ""),
+			CompletionPublishIntervalMins: getEnvAsInt("COMPLETION_PUBLISH_INTERVAL_MINS", 15),
+
+			DisputeResponseHours:        getEnvAsInt("DISPUTE_RESPONSE_HOURS", 72),
+			DisputeSettleDays:           getEnvAsInt("DISPUTE_SETTLE_DAYS", 14),
+			DisputeDeadlineIntervalMins: getEnvAsInt("DISPUTE_DEADLINE_INTERVAL_MINS", 15),
+
+			ReputationIntervalMins: getEnvAsInt("REPUTATION_INTERVAL_MINS", 30),
+
+			IdempotencyTTLHours:            getEnvAsInt("IDEMPOTENCY_TTL_HOURS", 24),
+			IdempotencyCleanupIntervalMins: getEnvAsInt("IDEMPOTENCY_CLEANUP_INTERVAL_MINS", 60),
+
+			PublicRateIPPerMin:          getEnvAsInt("PUBLIC_RATE_IP_PER_MIN", 120),
+			PublicRateTokenPerMin:       getEnvAsInt("PUBLIC_RATE_TOKEN_PER_MIN", 60),
+			PublicRateTokenWritesPerMin: getEnvAsInt("PUBLIC_RATE_TOKEN_WRITES_PER_MIN", 10),
+			PublicMaxFailedLookups:      getEnvAsInt("PUBLIC_MAX_FAILED_LOOKUPS", 10),
+			PublicFailureWindowMins:     getEnvAsInt("PUBLIC_FAILURE_WINDOW_MINS", 10),
+			PublicBlockMins:             getEnvAsInt("PUBLIC_BLOCK_MINS", 15),
+
+			ClientLinkKeys:         getEnv("CLIENT_LINK_KEYS", ""),
+			ClientLinkTTLDays:      getEnvAsInt("CLIENT_LINK_TTL_DAYS", 180),
+			ClientLinkAcceptLegacy: getEnvAsBool("CLIENT_LINK_ACCEPT_LEGACY", true),
+
+			ContractViewNotify: getEnvAsBool("CONTRACT_VIEW_NOTIFY", true),
+
+			RequireIfMatch: getEnvAsBool("REQUIRE_IF_MATCH", false),
+
+			CalendarFeedBaseURL: getEnv("CALENDAR_FEED_BASE_URL", ""),
+
+			ContractEventRetentionDays:       getEnvAsInt("CONTRACT_EVENT_RETENTION_DAYS", 7),
+			ContractEventCleanupIntervalMins: getEnvAsInt("CONTRACT_EVENT_CLEANUP_INTERVAL_MINS", 60),
+			ContractEventHeartbeatSecs:       getEnvAsInt("CONTRACT_EVENT_HEARTBEAT_SECS", 25),
+
+			RetainerCycleIntervalMins: getEnvAsInt("RETAINER_CYCLE_INTERVAL_MINS", 60),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -74,6 +187,18 @@ func Load() *Config {
 		JWT: JWTConfig{
 			Secret: getEnv("JWT_SECRET", ""),
 		},
+		Storage: StorageConfig{
+			Backend:                getEnv("STORAGE_BACKEND", "local"),
+			LocalDir:               getEnv("STORAGE_LOCAL_DIR", "./data/attachments"),
+			S3Endpoint:             getEnv("S3_ENDPOINT", ""),
+			S3Region:               getEnv("S3_REGION", "us-east-1"),
+			S3Bucket:               getEnv("S3_BUCKET", ""),
+			S3Prefix:               getEnv("S3_PREFIX", "attachments/"),
+			S3AccessKeyID:          getEnv("S3_ACCESS_KEY_ID", ""),
+			S3SecretAccessKey:      getEnv("S3_SECRET_ACCESS_KEY", ""),
+			AttachmentMaxBytes:     int64(getEnvAsInt("ATTACHMENT_MAX_BYTES", 20<<20)),
+			AttachmentAllowedTypes: getEnvAsList("ATTACHMENT_ALLOWED_TYPES"),
+		},
 	}
 }
 
@@ -92,3 +217,23 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsList splits a comma-separated variable, dropping empty entries
+func getEnvAsList(key string) []string {
+	var out []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// Amendment (change order) statuses
+const (
+	AmendmentStatusProposed  = "proposed"
+	AmendmentStatusAccepted  = "accepted"
+	AmendmentStatusRejected  = "rejected"
+	AmendmentStatusWithdrawn = "withdrawn"
+)
+
+// ContractAmendment is a change order on a signed/active contract. The freelancer proposes changes; the client
+// accepts (becoming terms version Version) or rejects them via the contract token. The terms in force before
+// the change are kept in PreviousTerms, so every earlier version stays reconstructable.
+type ContractAmendment struct {
+	ID            uint   `gorm:"primaryKey" json:"id"`
+	ContractID    uint   `gorm:"index;not null" json:"contract_id"`
+	Version       int    `gorm:"not null" json:"version"` // terms version this amendment creates when accepted
+	Status        string `gorm:"type:varchar(20);not null;index" json:"status"`
+	Summary       string `gorm:"type:text;not null" json:"summary"`         // freelancer's explanation of the change
+	Changes       string `gorm:"type:text;not null" json:"-"`               // JSON: proposed changes (see dto.AmendmentChanges)
+	PreviousTerms string `gorm:"type:text" json:"-"`                        // JSON: terms snapshot taken when accepted (see dto.ContractTerms)
+	ClientComment string `gorm:"type:text" json:"client_comment,omitempty"` // reason on reject
+
+	// Client acceptance evidence
+	RespondedAt           *time.Time `gorm:"type:timestamptz" json:"responded_at,omitempty"`
+	ClientSignedName      string     `gorm:"type:varchar(120)" json:"client_signed_name,omitempty"`
+	ClientSignerIP        string     `gorm:"type:varchar(64)" json:"client_signer_ip,omitempty"`
+	ClientSignerUserAgent string     `gorm:"type:varchar(300)" json:"client_signer_user_agent,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name
+func (ContractAmendment) TableName() string {
+	return "contract_amendments"
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AttachmentKind describes what a file is for
+const (
+	AttachmentKindPRD         = "prd"         // requirements document; fixed once the contract is sent for signing
+	AttachmentKindDeliverable = "deliverable" // work delivered against a milestone after signing
+	AttachmentKindEvidence    = "evidence"    // supporting material in a dispute; the client can upload these via the contract link
+	AttachmentKindSignature   = "signature"   // the client's typed or drawn mark, stored on sign; never uploaded directly
+	AttachmentKindOther       = "other"
+)
+
+// ContractAttachment is a file stored by content hash (CID) and linked to a contract and optionally a milestone.
+// The CID is immutable, so what was signed or delivered can always be re-verified against the stored bytes.
+type ContractAttachment struct {
+	ID               uint  `gorm:"primaryKey" json:"id"`
+	ContractID       uint  `gorm:"index;not null" json:"contract_id"`
+	MilestoneID      *uint `gorm:"index" json:"milestone_id,omitempty"`
+	UploadedByUserID uint  `gorm:"not null" json:"uploaded_by_user_id"` // 0 = the client via the contract link
+
+	Kind        string `gorm:"type:varchar(20);not null" json:"kind"` // prd | deliverable | other
+	FileName    string `gorm:"type:varchar(255);not null" json:"file_name"`
+	ContentType string `gorm:"type:varchar(100);not null" json:"content_type"`
+	Size        int64  `gorm:"not null" json:"size"`
+	CID         string `gorm:"column:cid;type:varchar(100);index;not null" json:"cid"` // CIDv1 (raw, sha2-256); blob store key
+	SHA256      string `gorm:"column:sha256;type:varchar(64);not null" json:"sha256"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName specifies the table name
+func (ContractAttachment) TableName() string {
+	return "contract_attachments"
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// CalendarFeed is a freelancer's secret calendar subscription URL. Only a hash of the token is stored; rotating
+// replaces it and the old URL stops working.
+type CalendarFeed struct {
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	FreelancerUserID uint       `gorm:"not null;uniqueIndex" json:"freelancer_user_id"`
+	TokenHash        string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"` // sha256 of the URL token
+	LastFetchedAt    *time.Time `gorm:"type:timestamptz" json:"last_fetched_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name
+func (CalendarFeed) TableName() string {
+	return "calendar_feeds"
+}
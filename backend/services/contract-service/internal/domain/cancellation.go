@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// Cancellation kinds
+const (
+	CancellationKindWithdraw = "withdraw" // freelancer, before the client signed
+	CancellationKindDecline  = "decline"  // client via token, before signing
+	CancellationKindMutual   = "mutual"   // after signing: one party requests, the other accepts
+)
+
+// Cancellation statuses
+const (
+	CancellationStatusRequested = "requested"
+	CancellationStatusAccepted  = "accepted" // contract is cancelled
+	CancellationStatusRejected  = "rejected"
+)
+
+// Contract parties
+const (
+	PartyFreelancer = "freelancer"
+	PartyClient     = "client"
+)
+
+// ContractCancellation records a withdraw, decline or mutual cancellation of a contract.
+// Withdraw and decline take effect immediately; mutual ones wait for the other party.
+type ContractCancellation struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	ContractID      uint       `gorm:"index;not null" json:"contract_id"`
+	Kind            string     `gorm:"type:varchar(20);not null" json:"kind"`         // withdraw | decline | mutual
+	RequestedBy     string     `gorm:"type:varchar(20);not null" json:"requested_by"` // freelancer | client
+	Reason          string     `gorm:"type:text;not null" json:"reason"`
+	Status          string     `gorm:"type:varchar(20);not null;index" json:"status"` // requested | accepted | rejected
+	Settlement      string     `gorm:"type:text" json:"-"`                            // JSON: milestone settlement at request time (see dto.Settlement)
+	ResponseComment string     `gorm:"type:text" json:"response_comment,omitempty"`
+	RespondedAt     *time.Time `gorm:"type:timestamptz" json:"responded_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name
+func (ContractCancellation) TableName() string {
+	return "contract_cancellations"
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// ClientEmailVerification is a one-time code a client account uses to prove it owns its email.
+// A verified row lets the account claim contracts whose client_email matches.
+type ClientEmailVerification struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	ClientUserID uint       `gorm:"index;not null" json:"client_user_id"` // from auth-service users.id (role client)
+	Email        string     `gorm:"type:varchar(255);not null;index" json:"email"`
+	CodeHash     string     `gorm:"type:varchar(100);not null" json:"-"`
+	Attempts     int        `gorm:"default:0" json:"attempts"`
+	ExpiresAt    time.Time  `gorm:"type:timestamptz;not null" json:"expires_at"`
+	VerifiedAt   *time.Time `gorm:"type:timestamptz" json:"verified_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name
+func (ClientEmailVerification) TableName() string {
+	return "client_email_verifications"
+}
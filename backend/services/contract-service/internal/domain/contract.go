@@ -10,54 +10,143 @@ import (
 const (
 	ContractStatusDraft   = "draft"
 	ContractStatusSent    = "sent"
-	ContractStatusPending = "pending" // client sent for review; freelancer can update and re-send
+	ContractStatusPending = "pending"          // client sent for review; freelancer can update and re-send
+	ContractStatusPartial = "partially_signed" // some parties signed; waits for the remaining required signers
 	ContractStatusSigned  = "signed"
 	ContractStatusActive  = "active"
 	ContractStatusDone    = "completed"
 	ContractStatusCancel  = "cancelled"
 )
 
+// Contract types: how a contract is priced and billed
+const (
+	ContractTypeFixed    = "fixed"    // total_amount split into milestones
+	ContractTypeHourly   = "hourly"   // hourly_rate; the client approves timesheet entries
+	ContractTypeRetainer = "retainer" // retainer_amount per billing cycle; a period milestone is generated each cycle
+)
+
+// Retainer billing cycles
+const (
+	BillingCycleWeekly  = "weekly"
+	BillingCycleMonthly = "monthly"
+)
+
+// MilestoneStatus values for ContractMilestone.Status
+const (
+	MilestoneStatusPending   = "pending"
+	MilestoneStatusSubmitted = "submitted"
+	MilestoneStatusApproved  = "approved"
+	MilestoneStatusPaid      = "paid"
+)
+
+// Profile visibility of a completed contract on the freelancer's public profile
+const (
+	ProfileVisibilityHidden     = "hidden"     // not listed
+	ProfileVisibilityAnonymized = "anonymized" // listed without client name, amount or value band
+	ProfileVisibilityFull       = "full"       // value band; client name and amount too when the client consented
+)
+
 // Contract represents a freelancer–client agreement
 type Contract struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	FreelancerUserID uint    `gorm:"index;not null" json:"freelancer_user_id"` // from auth-service users.id
+	ID               uint `gorm:"primaryKey" json:"id"`
+	FreelancerUserID uint `gorm:"index;not null" json:"freelancer_user_id"` // from auth-service users.id
 
 	// Project details
-	ProjectCategory    string    `gorm:"type:varchar(80);not null" json:"project_category"`
-	ProjectName        string    `gorm:"type:varchar(200);not null" json:"project_name"`
-	Description        string    `gorm:"type:text" json:"description"`
+	ProjectCategory    string     `gorm:"type:varchar(80);not null" json:"project_category"`
+	ProjectName        string     `gorm:"type:varchar(200);not null" json:"project_name"`
+	Description        string     `gorm:"type:text" json:"description"`
 	DueDate            *time.Time `gorm:"type:timestamptz" json:"due_date,omitempty"`
-	TotalAmount        float64   `gorm:"type:decimal(12,2);not null" json:"total_amount"`
-	Currency           string    `gorm:"type:varchar(3);default:INR" json:"currency"`
-	PRDFileURL         string    `gorm:"type:text" json:"prd_file_url,omitempty"`          // PRD PDF URL (IPFS later)
-	SubmissionCriteria string    `gorm:"type:text" json:"submission_criteria,omitempty"`   // submission criteria text
+	TotalAmount        float64    `gorm:"type:decimal(12,2);not null" json:"total_amount"`
+	Currency           string     `gorm:"type:varchar(3);default:INR" json:"currency"`
+	PRDFileURL         string     `gorm:"type:text" json:"prd_file_url,omitempty"`        // PRD PDF URL (IPFS later)
+	SubmissionCriteria string     `gorm:"type:text" json:"submission_criteria,omitempty"` // submission criteria text
+
+	// Pricing (ContractType*). On hourly and retainer contracts TotalAmount is accrued, not agreed: approved timesheet
+	// amounts or generated retainer periods, so totals and reporting show what was billed.
+	ContractType            string     `gorm:"type:varchar(10);not null;default:fixed;index" json:"contract_type"`
+	HourlyRate              float64    `gorm:"type:decimal(12,2);default:0" json:"hourly_rate,omitempty"`
+	WeeklyHourCap           float64    `gorm:"type:decimal(6,2);default:0" json:"weekly_hour_cap,omitempty"`  // 0 = no cap; weeks start Monday (UTC)
+	RetainerAmount          float64    `gorm:"type:decimal(12,2);default:0" json:"retainer_amount,omitempty"` // per cycle
+	BillingCycle            string     `gorm:"type:varchar(10)" json:"billing_cycle,omitempty"`               // weekly | monthly
+	RetainerCycles          int        `gorm:"default:0" json:"retainer_cycles,omitempty"`                    // 0 = until completed or cancelled
+	RetainerCyclesGenerated int        `gorm:"default:0" json:"retainer_cycles_generated,omitempty"`
+	NextCycleAt             *time.Time `gorm:"type:timestamptz;index" json:"next_cycle_at,omitempty"` // start of the next period; first is the client sign
 
 	// Client details
-	ClientName         string `gorm:"type:varchar(120);not null" json:"client_name"`
-	ClientCompanyName  string `gorm:"type:varchar(120)" json:"client_company_name,omitempty"`
-	ClientEmail        string `gorm:"type:varchar(255);not null" json:"client_email"`
-	ClientPhone        string `gorm:"type:varchar(30)" json:"client_phone,omitempty"`
+	ClientName        string `gorm:"type:varchar(120);not null" json:"client_name"`
+	ClientCompanyName string `gorm:"type:varchar(120)" json:"client_company_name,omitempty"`
+	ClientEmail       string `gorm:"type:varchar(255);not null" json:"client_email"`
+	ClientPhone       string `gorm:"type:varchar(30)" json:"client_phone,omitempty"`
 
 	// Terms
 	TermsAndConditions string `gorm:"type:text" json:"terms_and_conditions,omitempty"`
 
 	// Lifecycle
-	Status   string `gorm:"type:varchar(20);default:draft;index" json:"status"` // draft | sent | pending | signed | active | completed | cancelled
-	SentAt   *time.Time `gorm:"type:timestamptz" json:"sent_at,omitempty"`
+	Status string     `gorm:"type:varchar(20);default:draft;index" json:"status"` // draft | sent | pending | partially_signed | signed | active | completed | cancelled
+	SentAt *time.Time `gorm:"type:timestamptz" json:"sent_at,omitempty"`
 
 	// Client view & actions (no auth): token set when contract is sent; used in /public/contracts/:token
-	ClientViewToken       string     `gorm:"type:varchar(64);uniqueIndex" json:"client_view_token,omitempty"`
-	ClientReviewComment string `gorm:"type:text" json:"client_review_comment,omitempty"` // set when client sends for review
-	ClientSignedAt   *time.Time `gorm:"type:timestamptz" json:"client_signed_at,omitempty"`
-	ClientCompanyAddress string `gorm:"type:varchar(500)" json:"client_company_address,omitempty"` // required on sign: Remote | address | maps URL
-	ClientSignMetadata string  `gorm:"type:text" json:"-"` // JSON: optional gst_number, business_email, instagram, linkedin etc.; flexible for later
+	ClientViewToken      string     `gorm:"type:varchar(64);uniqueIndex" json:"client_view_token,omitempty"`
+	ClientReviewComment  string     `gorm:"type:text" json:"client_review_comment,omitempty"` // set when client sends for review
+	ClientSignedAt       *time.Time `gorm:"type:timestamptz" json:"client_signed_at,omitempty"`
+	ClientCompanyAddress string     `gorm:"type:varchar(500)" json:"client_company_address,omitempty"` // required on sign: Remote | address | maps URL
+	ClientSignMetadata   string     `gorm:"type:text" json:"-"`                                        // JSON: optional gst_number, business_email, instagram, linkedin etc.; flexible for later
+	ClientVerification   string     `gorm:"type:text" json:"-"`                                        // JSON: detail -> verification level (gst_number, business_email, linkedin); see domain/verification.go
+	ClientSignerVerified bool       `gorm:"default:false" json:"client_signer_verified"`               // signer proved control of the email via one-time code
+	ClientUserID         *uint      `gorm:"index" json:"client_user_id,omitempty"`                     // client account that claimed this contract (verified client_email)
+	SigningOrder         string     `gorm:"type:varchar(12);default:parallel" json:"signing_order"`    // parallel | sequential; only matters with additional signers
+
+	// Client signature mark and signing evidence, set on sign. ClientSignEvidence is the canonical JSON of what was
+	// signed (contract and terms digest, sign details, signature digest); ClientEvidenceHash is its sha256.
+	ClientSignatureID   *uint  `json:"client_signature_id,omitempty"`                           // ContractAttachment of kind signature
+	ClientSignatureType string `gorm:"type:varchar(10)" json:"client_signature_type,omitempty"` // typed | drawn
+	ClientSignEvidence  string `gorm:"type:text" json:"-"`
+	ClientEvidenceHash  string `gorm:"type:varchar(64)" json:"client_evidence_hash,omitempty"`
+
+	// Public profile showcase: how the completed contract appears on the freelancer's profile (ProfileVisibility*).
+	// The client is named only when they consented at sign.
+	ProfileVisibility     string     `gorm:"type:varchar(12);default:full" json:"profile_visibility"`
+	ClientPublicConsent   bool       `gorm:"default:false" json:"client_public_consent"`
+	ClientPublicConsentAt *time.Time `gorm:"type:timestamptz" json:"client_public_consent_at,omitempty"`
+
+	// Client link opens, one ContractView each. Not versioned: a view is not an edit of the contract.
+	FirstViewedAt *time.Time `gorm:"type:timestamptz" json:"first_viewed_at,omitempty"`
+	LastViewedAt  *time.Time `gorm:"type:timestamptz" json:"last_viewed_at,omitempty"`
+	ViewCount     int        `gorm:"default:0" json:"view_count"`
+
+	// Freelancer's own organisation: private notes and the archive flag (with Tags below). Not versioned and never
+	// shown to the client.
+	PrivateNotes string     `gorm:"type:text" json:"-"`
+	ArchivedAt   *time.Time `gorm:"type:timestamptz;index" json:"archived_at,omitempty"` // hidden from the default list while set
+
+	TermsVersion int        `gorm:"default:1" json:"terms_version"`                 // bumped by each accepted amendment (see ContractAmendment)
+	Version      int        `gorm:"not null;default:1" json:"version"`              // bumped by every write; the ETag, checked against If-Match on update
+	CancelledAt  *time.Time `gorm:"type:timestamptz" json:"cancelled_at,omitempty"` // see ContractCancellation for who and why
+
+	// Completion: set when the last milestone is approved; the event to user-service is retried until published
+	CompletedAt            *time.Time `gorm:"type:timestamptz" json:"completed_at,omitempty"`
+	CompletionPublishedAt  *time.Time `gorm:"type:timestamptz" json:"-"`
+	ClientRating           *int       `json:"client_rating,omitempty"` // 1-10, from the client's testimonial once the contract is completed
+	ClientRatedAt          *time.Time `gorm:"type:timestamptz" json:"client_rated_at,omitempty"`
+	ClientTestimonial      string     `gorm:"type:text" json:"client_testimonial,omitempty"`
+	TestimonialPublishedAt *time.Time `gorm:"type:timestamptz" json:"-"` // testimonial delivered to user-service
+
+	// Freelancer countersign (signed -> active)
+	FreelancerSignedAt        *time.Time `gorm:"type:timestamptz" json:"freelancer_signed_at,omitempty"`
+	FreelancerSignedName      string     `gorm:"type:varchar(120)" json:"freelancer_signed_name,omitempty"`
+	FreelancerSignerEmail     string     `gorm:"type:varchar(255)" json:"freelancer_signer_email,omitempty"` // account email from the access token
+	FreelancerSignerIP        string     `gorm:"type:varchar(64)" json:"freelancer_signer_ip,omitempty"`
+	FreelancerSignerUserAgent string     `gorm:"type:varchar(300)" json:"freelancer_signer_user_agent,omitempty"`
 
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relations (loaded when needed)
-	Milestones []ContractMilestone `gorm:"foreignKey:ContractID" json:"milestones,omitempty"`
+	Milestones  []ContractMilestone  `gorm:"foreignKey:ContractID" json:"milestones,omitempty"`
+	Attachments []ContractAttachment `gorm:"foreignKey:ContractID" json:"attachments,omitempty"`
+	Signers     []ContractSigner     `gorm:"foreignKey:ContractID" json:"signers,omitempty"`
+	Tags        []ContractTag        `gorm:"foreignKey:ContractID" json:"-"`
 }
 
 // TableName specifies the table name
@@ -67,18 +156,23 @@ func (Contract) TableName() string {
 
 // ContractMilestone represents a single milestone (payment + deliverable)
 type ContractMilestone struct {
-	ID         uint    `gorm:"primaryKey" json:"id"`
-	ContractID uint    `gorm:"index;not null" json:"contract_id"`
-	OrderIndex int     `gorm:"not null" json:"order_index"` // 0-based
+	ID         uint `gorm:"primaryKey" json:"id"`
+	ContractID uint `gorm:"index;not null" json:"contract_id"`
+	OrderIndex int  `gorm:"not null" json:"order_index"` // 0-based
 
-	Title       string  `gorm:"type:varchar(200);not null" json:"title"`
-	Description string  `gorm:"type:text" json:"description,omitempty"`
-	Amount      float64 `gorm:"type:decimal(12,2);not null" json:"amount"`
-	DueDate     *time.Time `gorm:"type:timestamptz" json:"due_date,omitempty"`
-	IsInitialPayment bool `gorm:"default:false" json:"is_initial_payment"`
+	Title            string     `gorm:"type:varchar(200);not null" json:"title"`
+	Description      string     `gorm:"type:text" json:"description,omitempty"`
+	Amount           float64    `gorm:"type:decimal(12,2);not null" json:"amount"`
+	DueDate          *time.Time `gorm:"type:timestamptz" json:"due_date,omitempty"`
+	IsInitialPayment bool       `gorm:"default:false" json:"is_initial_payment"`
 
 	// Status (Week 5: submission/approval)
-	Status string `gorm:"type:varchar(20);default:pending" json:"status"` // pending | submitted | approved | paid
+	Status         string     `gorm:"type:varchar(20);default:pending" json:"status"` // pending | submitted | approved | paid
+	SubmittedAt    *time.Time `gorm:"type:timestamptz" json:"submitted_at,omitempty"` // latest submission by the freelancer
+	SubmissionNote string     `gorm:"type:text" json:"submission_note,omitempty"`
+	ApprovedAt     *time.Time `gorm:"type:timestamptz" json:"approved_at,omitempty"`
+	RevisionCount  int        `gorm:"not null;default:0" json:"revision_count"` // times the client sent a submission back
+	ChangesNote    string     `gorm:"type:text" json:"changes_note,omitempty"`  // client's latest request for changes
 
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
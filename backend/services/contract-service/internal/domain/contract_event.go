@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// Contract event types streamed to the freelancer
+const (
+	ContractEventViewed             = "contract.viewed"
+	ContractEventSentForReview      = "contract.sent_for_review"
+	ContractEventSigned             = "contract.signed"
+	ContractEventCommentAdded       = "comment.added"
+	ContractEventMilestoneSubmitted = "milestone.submitted"
+	ContractEventTimesheetReviewed  = "timesheet.reviewed" // client approved or rejected a timesheet entry
+)
+
+// ContractEvent is one entry of a freelancer's live activity feed. IDs only grow, so they double as SSE event IDs
+// for Last-Event-ID resume.
+type ContractEvent struct {
+	ID               uint      `gorm:"primaryKey;index:idx_contract_events_freelancer,priority:2" json:"id"`
+	ContractID       uint      `gorm:"not null;index" json:"contract_id"`
+	FreelancerUserID uint      `gorm:"not null;index:idx_contract_events_freelancer,priority:1" json:"freelancer_user_id"`
+	Type             string    `gorm:"type:varchar(40);not null" json:"type"`
+	Data             string    `gorm:"type:text" json:"-"` // JSON: event-specific fields
+	CreatedAt        time.Time `gorm:"index" json:"created_at"`
+}
+
+// TableName specifies the table name
+func (ContractEvent) TableName() string {
+	return "contract_events"
+}
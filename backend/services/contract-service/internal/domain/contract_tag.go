@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// ContractTag is a freelancer-defined label on a contract (e.g. "retainer", "design"). Tags are stored lower-cased
+// and are private to the freelancer; they never appear in the client's view.
+type ContractTag struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	ContractID       uint      `gorm:"not null;uniqueIndex:idx_contract_tags_contract_tag" json:"contract_id"`
+	FreelancerUserID uint      `gorm:"not null;index:idx_contract_tags_freelancer_tag" json:"freelancer_user_id"`
+	Tag              string    `gorm:"type:varchar(30);not null;uniqueIndex:idx_contract_tags_contract_tag;index:idx_contract_tags_freelancer_tag" json:"tag"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name
+func (ContractTag) TableName() string {
+	return "contract_tags"
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// ContractView is one open of the client link (GET /api/v1/public/contracts/:token). Only coarse, anonymized
+// details are kept: the browser family and OS, and the IP with its host part zeroed.
+type ContractView struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	ContractID uint      `gorm:"not null;index" json:"contract_id"`
+	ViewedAt   time.Time `gorm:"type:timestamptz;not null" json:"viewed_at"`
+	UserAgent  string    `gorm:"type:varchar(60)" json:"user_agent"` // e.g. "Chrome on Windows"
+	IPPrefix   string    `gorm:"type:varchar(64)" json:"ip_prefix"`  // IPv4 /24 or IPv6 /48, e.g. 203.0.113.0/24
+}
+
+// TableName specifies the table name
+func (ContractView) TableName() string {
+	return "contract_views"
+}
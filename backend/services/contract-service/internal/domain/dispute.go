@@ -0,0 +1,79 @@
+package domain
+
+import "time"
+
+// Dispute statuses
+const (
+	DisputeStatusOpen             = "open"              // both parties engaged; parties have until due_at to settle before escalation
+	DisputeStatusAwaitingResponse = "awaiting_response" // awaiting_party must reply by due_at
+	DisputeStatusEscalated        = "escalated"         // waiting for an admin decision
+	DisputeStatusResolved         = "resolved"
+)
+
+// Dispute reason categories
+const (
+	DisputeCategoryQuality       = "quality"
+	DisputeCategoryScope         = "scope"
+	DisputeCategoryPayment       = "payment"
+	DisputeCategoryDeadline      = "deadline"
+	DisputeCategoryCommunication = "communication"
+	DisputeCategoryOther         = "other"
+)
+
+// Dispute resolutions
+const (
+	DisputeResolutionFreelancer = "freelancer" // decided in the freelancer's favour
+	DisputeResolutionClient     = "client"     // decided in the client's favour
+	DisputeResolutionSplit      = "split"
+	DisputeResolutionWithdrawn  = "withdrawn" // closed by the party who opened it
+)
+
+// PartyAdmin authors admin messages and resolutions in a dispute thread
+const PartyAdmin = "admin"
+
+// ContractDispute is a disagreement raised by either party on a signed contract or one of its milestones.
+// While a dispute is not resolved, milestone transitions on the contract are frozen.
+type ContractDispute struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	ContractID  uint   `gorm:"index;not null" json:"contract_id"`
+	MilestoneID *uint  `gorm:"index" json:"milestone_id,omitempty"`
+	OpenedBy    string `gorm:"type:varchar(20);not null" json:"opened_by"` // freelancer | client
+	Category    string `gorm:"type:varchar(20);not null" json:"category"`  // quality | scope | payment | deadline | communication | other
+	Reason      string `gorm:"type:text;not null" json:"reason"`
+
+	Status        string     `gorm:"type:varchar(20);not null;index" json:"status"` // open | awaiting_response | escalated | resolved
+	AwaitingParty string     `gorm:"type:varchar(20)" json:"awaiting_party,omitempty"`
+	DueAt         *time.Time `gorm:"type:timestamptz;index" json:"due_at,omitempty"` // response or settlement deadline; escalated when passed
+	EscalatedAt   *time.Time `gorm:"type:timestamptz" json:"escalated_at,omitempty"`
+
+	Resolution       string     `gorm:"type:varchar(20)" json:"resolution,omitempty"` // freelancer | client | split | withdrawn
+	ResolutionNote   string     `gorm:"type:text" json:"resolution_note,omitempty"`
+	ResolvedByUserID *uint      `json:"resolved_by_user_id,omitempty"` // admin; nil when withdrawn
+	ResolvedAt       *time.Time `gorm:"type:timestamptz" json:"resolved_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Messages []ContractDisputeMessage `gorm:"foreignKey:DisputeID" json:"messages,omitempty"`
+}
+
+// TableName specifies the table name
+func (ContractDispute) TableName() string {
+	return "contract_disputes"
+}
+
+// ContractDisputeMessage is one entry in a dispute thread. Evidence files are contract attachments referenced by ID.
+type ContractDisputeMessage struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	DisputeID     uint      `gorm:"index;not null" json:"dispute_id"`
+	Author        string    `gorm:"type:varchar(20);not null" json:"author"` // freelancer | client | admin
+	AuthorUserID  *uint     `json:"author_user_id,omitempty"`                // nil for the client via the contract link
+	Body          string    `gorm:"type:text;not null" json:"body"`
+	AttachmentIDs string    `gorm:"type:text" json:"-"` // JSON array of contract attachment IDs
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name
+func (ContractDisputeMessage) TableName() string {
+	return "contract_dispute_messages"
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// IdempotencyKey is a stored response for an Idempotency-Key header, scoped to the caller (user or contract link
+// token). A row with StatusCode 0 is a request still in flight.
+type IdempotencyKey struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	Scope        string    `gorm:"type:varchar(80);not null;uniqueIndex:idx_idempotency_scope_key" json:"scope"` // user:<id> or token:<sha256>
+	Key          string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_scope_key" json:"key"`
+	RequestHash  string    `gorm:"type:varchar(64);not null" json:"request_hash"` // sha256 of method, path and body
+	StatusCode   int       `gorm:"default:0" json:"status_code"`
+	ContentType  string    `gorm:"type:varchar(100)" json:"content_type"`
+	ResponseBody []byte    `gorm:"type:bytea" json:"-"`
+	ExpiresAt    time.Time `gorm:"type:timestamptz;not null;index" json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// ImportJobStatus represents the state of a bulk contract import
+const (
+	ImportJobStatusProcessing = "processing"
+	ImportJobStatusCompleted  = "completed"
+	ImportJobStatusFailed     = "failed"
+)
+
+// ContractImportJob tracks a CSV import that commits valid rows as draft contracts
+type ContractImportJob struct {
+	ID               uint   `gorm:"primaryKey" json:"id"`
+	FreelancerUserID uint   `gorm:"index;not null" json:"freelancer_user_id"`
+	FileName         string `gorm:"type:varchar(255)" json:"file_name,omitempty"`
+
+	Status        string `gorm:"type:varchar(20);default:processing;index" json:"status"` // processing | completed | failed
+	TotalRows     int    `gorm:"not null" json:"total_rows"`
+	ValidRows     int    `gorm:"not null" json:"valid_rows"`
+	ImportedCount int    `gorm:"default:0" json:"imported_count"`
+	RowErrors     string `gorm:"type:text" json:"-"` // JSON: []{row, errors}; invalid rows are skipped, not imported
+	ContractIDs   string `gorm:"type:text" json:"-"` // JSON: []uint of created drafts
+	ErrorMessage  string `gorm:"type:text" json:"error_message,omitempty"`
+
+	CompletedAt *time.Time `gorm:"type:timestamptz" json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name
+func (ContractImportJob) TableName() string {
+	return "contract_import_jobs"
+}
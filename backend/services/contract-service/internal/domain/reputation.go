@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// ContractReputation is the freelancer's score for one completed contract, kept with the inputs and per-component
+// values it was computed from so the score can be explained and recomputed when the formula changes.
+type ContractReputation struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	ContractID       uint      `gorm:"uniqueIndex;not null" json:"contract_id"`
+	FreelancerUserID uint      `gorm:"index;not null" json:"freelancer_user_id"`
+	FormulaVersion   int       `gorm:"not null;index" json:"formula_version"`
+	Score            float64   `gorm:"type:decimal(5,1);not null" json:"score"` // 0-100
+	Inputs           string    `gorm:"type:text" json:"-"`                      // JSON: reputation.Inputs
+	Components       string    `gorm:"type:text" json:"-"`                      // JSON: []reputation.Component
+	ComputedAt       time.Time `gorm:"type:timestamptz;not null" json:"computed_at"`
+}
+
+// TableName specifies the table name
+func (ContractReputation) TableName() string {
+	return "contract_reputations"
+}
+
+// FreelancerReputation aggregates a freelancer's contract scores. PublishedAt lags UpdatedAt until user-service
+// has the latest score.
+type FreelancerReputation struct {
+	FreelancerUserID uint       `gorm:"primaryKey;autoIncrement:false" json:"freelancer_user_id"`
+	FormulaVersion   int        `gorm:"not null" json:"formula_version"`
+	Score            float64    `gorm:"type:decimal(5,1);not null" json:"score"` // mean of contract scores
+	ContractsScored  int        `gorm:"not null" json:"contracts_scored"`
+	UpdatedAt        time.Time  `gorm:"type:timestamptz;not null" json:"updated_at"`
+	PublishedAt      *time.Time `gorm:"type:timestamptz" json:"-"`
+}
+
+// TableName specifies the table name
+func (FreelancerReputation) TableName() string {
+	return "freelancer_reputations"
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// ContractSignOTP is a one-time code emailed to the client, or to an additional signer, before signing.
+// Only the bcrypt hash is stored.
+type ContractSignOTP struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	ContractID uint       `gorm:"index;not null" json:"contract_id"`
+	SignerID   *uint      `gorm:"index" json:"signer_id,omitempty"`        // additional signer the code is for; nil = primary client
+	Email      string     `gorm:"type:varchar(255);not null" json:"email"` // where the code was sent: client_email, or the signer's email
+	CodeHash   string     `gorm:"type:varchar(100);not null" json:"-"`
+	Attempts   int        `gorm:"default:0" json:"attempts"`
+	ExpiresAt  time.Time  `gorm:"type:timestamptz;not null" json:"expires_at"`
+	ConsumedAt *time.Time `gorm:"type:timestamptz" json:"consumed_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name
+func (ContractSignOTP) TableName() string {
+	return "contract_sign_otps"
+}
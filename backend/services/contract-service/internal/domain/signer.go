@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// Signing order for contracts with additional signers
+const (
+	SigningOrderParallel   = "parallel"   // all parties may sign in any order
+	SigningOrderSequential = "sequential" // primary client first, then signers by order_index
+)
+
+// ContractSigner is an additional party (besides the primary client) who must or may sign the contract,
+// e.g. a project lead and a finance approver. Each signer gets their own token when the contract is sent; with
+// signed links it is the nonce of their link token, as client_view_token is for the client.
+type ContractSigner struct {
+	ID         uint    `gorm:"primaryKey" json:"id"`
+	ContractID uint    `gorm:"index;not null" json:"contract_id"`
+	OrderIndex int     `gorm:"not null" json:"order_index"` // 0-based; used for sequential signing
+	Name       string  `gorm:"type:varchar(120);not null" json:"name"`
+	Email      string  `gorm:"type:varchar(255);not null" json:"email"`
+	Role       string  `gorm:"type:varchar(60)" json:"role,omitempty"` // free label, e.g. project_lead, finance_approver
+	Required   bool    `gorm:"not null" json:"required"`               // contract becomes signed only when all required signers have signed
+	Token      *string `gorm:"type:varchar(64);uniqueIndex" json:"-"`  // set when the contract is sent
+
+	// Signing evidence
+	SignedAt        *time.Time `gorm:"type:timestamptz" json:"signed_at,omitempty"`
+	SignedName      string     `gorm:"type:varchar(120)" json:"signed_name,omitempty"` // name typed by the signer
+	Designation     string     `gorm:"type:varchar(120)" json:"designation,omitempty"`
+	SignerIP        string     `gorm:"type:varchar(64)" json:"signer_ip,omitempty"`
+	SignerUserAgent string     `gorm:"type:varchar(300)" json:"signer_user_agent,omitempty"`
+	Verified        bool       `gorm:"default:false" json:"verified"` // signed with a code emailed to Email
+
+	NotifiedAt *time.Time `gorm:"type:timestamptz" json:"notified_at,omitempty"` // when the sign link was emailed
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name
+func (ContractSigner) TableName() string {
+	return "contract_signers"
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// TimesheetEntry statuses
+const (
+	TimesheetStatusPending  = "pending"  // logged by the freelancer, waiting for the client
+	TimesheetStatusApproved = "approved" // billed: Amount was added to the contract total
+	TimesheetStatusRejected = "rejected" // not billed; does not count towards the weekly cap
+)
+
+// TimesheetEntry is time logged on an hourly contract. Amount is Hours at the contract's rate when logged; the
+// client approves or rejects each entry via the contract link.
+type TimesheetEntry struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	ContractID    uint       `gorm:"not null;index:idx_timesheet_contract_date" json:"contract_id"`
+	WorkDate      time.Time  `gorm:"type:date;not null;index:idx_timesheet_contract_date" json:"work_date"`
+	Hours         float64    `gorm:"type:decimal(5,2);not null" json:"hours"`
+	Description   string     `gorm:"type:varchar(1000)" json:"description"`
+	Amount        float64    `gorm:"type:decimal(12,2);not null" json:"amount"`
+	Status        string     `gorm:"type:varchar(10);not null;default:pending" json:"status"`
+	ReviewedAt    *time.Time `gorm:"type:timestamptz" json:"reviewed_at,omitempty"`
+	RejectionNote string     `gorm:"type:varchar(1000)" json:"rejection_note,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name
+func (TimesheetEntry) TableName() string {
+	return "contract_timesheet_entries"
+}
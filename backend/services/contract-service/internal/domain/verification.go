@@ -0,0 +1,17 @@
+package domain
+
+// VerificationLevel records how far an optional client detail (GST, business email, LinkedIn) was checked.
+// Stored per detail on the contract at sign time; higher levels earn the freelancer more trust points.
+const (
+	VerificationLevelNone         = "none"          // not provided
+	VerificationLevelSelfDeclared = "self_declared" // provided, not checked beyond basic format
+	VerificationLevelFormatValid  = "format_valid"  // passed structural checks (e.g. GSTIN checksum, LinkedIn profile URL)
+	VerificationLevelVerified     = "verified"      // confirmed out of band (e.g. OTP to business email)
+)
+
+// Optional client details that carry a verification level
+const (
+	ClientDetailGST           = "gst_number"
+	ClientDetailBusinessEmail = "business_email"
+	ClientDetailLinkedIn      = "linkedin"
+)
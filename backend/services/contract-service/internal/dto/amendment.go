@@ -0,0 +1,76 @@
+package dto
+
+import "time"
+
+// MilestoneChange edits, adds or removes one milestone in a change order.
+// ID set: update (or remove) that milestone; ID nil: add a new one. Only pending milestones can be changed.
+type MilestoneChange struct {
+	ID          *uint      `json:"id,omitempty"`
+	Remove      bool       `json:"remove,omitempty"`
+	Title       *string    `json:"title,omitempty" validate:"omitempty,max=200"`
+	Description *string    `json:"description,omitempty" validate:"omitempty,max=2000"`
+	Amount      *float64   `json:"amount,omitempty" validate:"omitempty,min=0"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+}
+
+// AmendmentChanges are the proposed changes; absent fields stay as they are
+type AmendmentChanges struct {
+	DueDate            *time.Time        `json:"due_date,omitempty"`
+	TotalAmount        *float64          `json:"total_amount,omitempty" validate:"omitempty,min=0"` // fixed only
+	SubmissionCriteria *string           `json:"submission_criteria,omitempty" validate:"omitempty,max=2000"`
+	TermsAndConditions *string           `json:"terms_and_conditions,omitempty" validate:"omitempty,max=10000"`
+	Milestones         []MilestoneChange `json:"milestones,omitempty" validate:"omitempty,max=50,dive"` // fixed only
+	HourlyRate         *float64          `json:"hourly_rate,omitempty" validate:"omitempty,gt=0"`       // hourly only; applies to time logged afterwards
+	WeeklyHourCap      *float64          `json:"weekly_hour_cap,omitempty" validate:"omitempty,min=0,max=168"`
+	RetainerAmount     *float64          `json:"retainer_amount,omitempty" validate:"omitempty,gt=0"` // retainer only; applies to the next period
+}
+
+// ProposeAmendmentRequest is the body for POST /api/v1/contracts/:id/amendments
+type ProposeAmendmentRequest struct {
+	Summary string           `json:"summary" validate:"required,max=2000"`
+	Changes AmendmentChanges `json:"changes"`
+}
+
+// AcceptAmendmentRequest is the body for POST /api/v1/public/contracts/:token/amendments/:amendment_id/accept
+type AcceptAmendmentRequest struct {
+	FullName string `json:"full_name" validate:"required,min=2,max=120"` // typed name, stored as evidence
+}
+
+// RejectAmendmentRequest is the body for POST /api/v1/public/contracts/:token/amendments/:amendment_id/reject
+type RejectAmendmentRequest struct {
+	Comment string `json:"comment" validate:"required,max=2000"`
+}
+
+// ContractTerms is the snapshot of the amendable terms of one version
+type ContractTerms struct {
+	Version            int                 `json:"version"`
+	DueDate            *time.Time          `json:"due_date,omitempty"`
+	TotalAmount        float64             `json:"total_amount"`
+	SubmissionCriteria string              `json:"submission_criteria,omitempty"`
+	TermsAndConditions string              `json:"terms_and_conditions,omitempty"`
+	Milestones         []MilestoneResponse `json:"milestones"`
+	ContractType       string              `json:"contract_type,omitempty"` // empty in snapshots taken before contract types
+	HourlyRate         float64             `json:"hourly_rate,omitempty"`
+	WeeklyHourCap      float64             `json:"weekly_hour_cap,omitempty"`
+	RetainerAmount     float64             `json:"retainer_amount,omitempty"`
+	BillingCycle       string              `json:"billing_cycle,omitempty"`
+	RetainerCycles     int                 `json:"retainer_cycles,omitempty"`
+}
+
+// AmendmentResponse is a change order in API responses
+type AmendmentResponse struct {
+	ID                    uint             `json:"id"`
+	ContractID            uint             `json:"contract_id"`
+	Version               int              `json:"version"`
+	Status                string           `json:"status"` // proposed | accepted | rejected | withdrawn
+	Summary               string           `json:"summary"`
+	Changes               AmendmentChanges `json:"changes"`
+	PreviousTerms         *ContractTerms   `json:"previous_terms,omitempty"` // terms replaced by this amendment (accepted only)
+	ClientComment         string           `json:"client_comment,omitempty"`
+	RespondedAt           *time.Time       `json:"responded_at,omitempty"`
+	ClientSignedName      string           `json:"client_signed_name,omitempty"`
+	ClientSignerIP        string           `json:"client_signer_ip,omitempty"`
+	ClientSignerUserAgent string           `json:"client_signer_user_agent,omitempty"`
+	CreatedAt             time.Time        `json:"created_at"`
+	UpdatedAt             time.Time        `json:"updated_at"`
+}
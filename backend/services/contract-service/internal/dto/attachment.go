@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// UploadAttachmentInput is built by the handler from a multipart upload (fields: file, kind, milestone_id)
+type UploadAttachmentInput struct {
+	Kind        string `validate:"required,oneof=prd deliverable evidence other"`
+	MilestoneID *uint
+	FileName    string `validate:"required,max=255"`
+	Content     []byte
+}
+
+// AttachmentResponse is one attachment in API responses. Download via
+// GET /api/v1/contracts/:id/attachments/:attachment_id or GET /api/v1/public/contracts/:token/attachments/:attachment_id.
+type AttachmentResponse struct {
+	ID          uint      `json:"id"`
+	ContractID  uint      `json:"contract_id"`
+	MilestoneID *uint     `json:"milestone_id,omitempty"`
+	Kind        string    `json:"kind"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CID         string    `json:"cid"`
+	SHA256      string    `json:"sha256"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+// CalendarFeedResponse describes the freelancer's calendar subscription. URL is only returned when the feed is
+// created or rotated; afterwards it cannot be read back.
+type CalendarFeedResponse struct {
+	URL           string     `json:"url,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`                // when the current URL was issued
+	LastFetchedAt *time.Time `json:"last_fetched_at,omitempty"` // last time a calendar app fetched it
+}
@@ -0,0 +1,54 @@
+package dto
+
+import "time"
+
+// CancelRequest is the body for withdraw, decline and cancellation requests
+type CancelRequest struct {
+	Reason string `json:"reason" validate:"required,max=2000"`
+}
+
+// RespondCancellationRequest is the body for rejecting a cancellation request; comment is optional on accept
+type RespondCancellationRequest struct {
+	Comment string `json:"comment,omitempty" validate:"omitempty,max=2000"`
+}
+
+// SettlementMilestone is one milestone's outcome if the contract is cancelled
+type SettlementMilestone struct {
+	ID      uint    `json:"id"`
+	Title   string  `json:"title"`
+	Amount  float64 `json:"amount"`
+	Status  string  `json:"status"`  // milestone status at the time
+	Outcome string  `json:"outcome"` // paid | owed | void
+}
+
+// Settlement summarises money on cancellation: paid milestones stay paid, delivered (submitted/approved) ones are owed,
+// pending ones are void. On hourly contracts approved time is owed as well.
+type Settlement struct {
+	Currency           string                `json:"currency"`
+	PaidAmount         float64               `json:"paid_amount"`
+	OwedAmount         float64               `json:"owed_amount"` // includes approved_time_amount
+	VoidAmount         float64               `json:"void_amount"`
+	ApprovedTimeAmount float64               `json:"approved_time_amount,omitempty"` // hourly: approved timesheet entries
+	Milestones         []SettlementMilestone `json:"milestones"`
+}
+
+// CancellationResponse is a cancellation record in API responses
+type CancellationResponse struct {
+	ID              uint        `json:"id"`
+	ContractID      uint        `json:"contract_id"`
+	Kind            string      `json:"kind"`         // withdraw | decline | mutual
+	RequestedBy     string      `json:"requested_by"` // freelancer | client
+	Reason          string      `json:"reason"`
+	Status          string      `json:"status"` // requested | accepted | rejected
+	Settlement      *Settlement `json:"settlement,omitempty"`
+	ResponseComment string      `json:"response_comment,omitempty"`
+	RespondedAt     *time.Time  `json:"responded_at,omitempty"`
+	CreatedAt       time.Time   `json:"created_at"`
+}
+
+// CancellationStateResponse is returned by GET .../cancellation: the latest record (if any) and what a cancellation now would settle
+type CancellationStateResponse struct {
+	ContractStatus string                `json:"contract_status"`
+	Latest         *CancellationResponse `json:"latest,omitempty"`
+	Settlement     Settlement            `json:"settlement"` // preview based on current milestones
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// ClientEmailCodeResponse is returned when a verification code is emailed to a client account
+type ClientEmailCodeResponse struct {
+	SentTo    string    `json:"sent_to"` // masked, e.g. a***@example.com
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ClaimContractsRequest is the body for POST /api/v1/client/claim
+type ClaimContractsRequest struct {
+	OTPCode string `json:"otp_code" validate:"required,len=6,numeric"`
+}
+
+// ClaimContractsResponse reports the verified email and how many contracts were newly linked
+type ClaimContractsResponse struct {
+	Email   string `json:"email"`
+	Claimed int64  `json:"claimed"`
+}
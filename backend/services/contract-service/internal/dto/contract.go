@@ -9,11 +9,20 @@ type CreateContractRequest struct {
 	ProjectName        string     `json:"project_name" validate:"required,min=2,max=200"`
 	Description        string     `json:"description" validate:"omitempty,max=5000"`
 	DueDate            *time.Time `json:"due_date,omitempty"`
-	TotalAmount        float64    `json:"total_amount" validate:"required,min=0"`
+	TotalAmount        float64    `json:"total_amount" validate:"required_if=ContractType fixed,required_without=ContractType,min=0"` // fixed only
 	Currency           string     `json:"currency" validate:"omitempty,len=3"`
 	PRDFileURL         string     `json:"prd_file_url,omitempty" validate:"omitempty,url"`
 	SubmissionCriteria string     `json:"submission_criteria,omitempty" validate:"omitempty,max=2000"`
 
+	// Pricing: fixed (default) uses total_amount and milestones; hourly uses hourly_rate and an optional weekly cap;
+	// retainer bills retainer_amount every billing_cycle, for retainer_cycles periods (0 = until completed)
+	ContractType   string  `json:"contract_type,omitempty" validate:"omitempty,oneof=fixed hourly retainer"`
+	HourlyRate     float64 `json:"hourly_rate,omitempty" validate:"required_if=ContractType hourly,min=0"`
+	WeeklyHourCap  float64 `json:"weekly_hour_cap,omitempty" validate:"omitempty,min=0,max=168"`
+	RetainerAmount float64 `json:"retainer_amount,omitempty" validate:"required_if=ContractType retainer,min=0"`
+	BillingCycle   string  `json:"billing_cycle,omitempty" validate:"required_if=ContractType retainer,omitempty,oneof=weekly monthly"`
+	RetainerCycles int     `json:"retainer_cycles,omitempty" validate:"omitempty,min=0,max=120"`
+
 	// Client
 	ClientName        string `json:"client_name" validate:"required,max=120"`
 	ClientCompanyName string `json:"client_company_name,omitempty" validate:"omitempty,max=120"`
@@ -23,105 +32,199 @@ type CreateContractRequest struct {
 	// Terms
 	TermsAndConditions string `json:"terms_and_conditions,omitempty" validate:"omitempty,max=10000"`
 
-	// Milestones (at least one; first can be initial payment)
-	Milestones []MilestoneInput `json:"milestones" validate:"required,min=1,dive"`
+	// Milestones (fixed only, at least one; first can be initial payment). Retainer periods are generated.
+	Milestones []MilestoneInput `json:"milestones" validate:"required_if=ContractType fixed,required_without=ContractType,omitempty,min=1,dive"`
+
+	// Additional signers besides the client (e.g. finance approver); optional
+	SigningOrder string        `json:"signing_order,omitempty" validate:"omitempty,oneof=parallel sequential"`
+	Signers      []SignerInput `json:"signers,omitempty" validate:"omitempty,max=10,dive"`
+
+	// How the contract appears on the public profile once completed; default full. Change later via profile-visibility.
+	ProfileVisibility string `json:"profile_visibility,omitempty" validate:"omitempty,oneof=hidden anonymized full"`
 }
 
 // MilestoneInput is one milestone in create/update payload
 type MilestoneInput struct {
-	Title             string     `json:"title" validate:"required,max=200"`
-	Description       string     `json:"description,omitempty" validate:"omitempty,max=2000"`
-	Amount            float64    `json:"amount" validate:"required,min=0"`
-	DueDate           *time.Time `json:"due_date,omitempty"`
-	IsInitialPayment  bool       `json:"is_initial_payment"`
+	Title            string     `json:"title" validate:"required,max=200"`
+	Description      string     `json:"description,omitempty" validate:"omitempty,max=2000"`
+	Amount           float64    `json:"amount" validate:"required,min=0"`
+	DueDate          *time.Time `json:"due_date,omitempty"`
+	IsInitialPayment bool       `json:"is_initial_payment"`
 }
 
 // UpdateContractRequest is the payload for updating a draft contract
 type UpdateContractRequest struct {
-	ProjectCategory    *string    `json:"project_category,omitempty" validate:"omitempty,max=80"`
-	ProjectName        *string    `json:"project_name,omitempty" validate:"omitempty,max=200"`
-	Description        *string    `json:"description,omitempty" validate:"omitempty,max=5000"`
-	DueDate            *time.Time `json:"due_date,omitempty"`
-	TotalAmount        *float64   `json:"total_amount,omitempty" validate:"omitempty,min=0"`
-	Currency           *string    `json:"currency,omitempty" validate:"omitempty,len=3"`
-	PRDFileURL         *string    `json:"prd_file_url,omitempty" validate:"omitempty,url"`
-	SubmissionCriteria *string    `json:"submission_criteria,omitempty" validate:"omitempty,max=2000"`
-	ClientName         *string    `json:"client_name,omitempty" validate:"omitempty,max=120"`
-	ClientCompanyName  *string    `json:"client_company_name,omitempty" validate:"omitempty,max=120"`
-	ClientEmail        *string    `json:"client_email,omitempty" validate:"omitempty,email"`
-	ClientPhone        *string    `json:"client_phone,omitempty" validate:"omitempty,max=30"`
-	TermsAndConditions *string    `json:"terms_and_conditions,omitempty" validate:"omitempty,max=10000"`
+	ProjectCategory    *string          `json:"project_category,omitempty" validate:"omitempty,max=80"`
+	ProjectName        *string          `json:"project_name,omitempty" validate:"omitempty,max=200"`
+	Description        *string          `json:"description,omitempty" validate:"omitempty,max=5000"`
+	DueDate            *time.Time       `json:"due_date,omitempty"`
+	TotalAmount        *float64         `json:"total_amount,omitempty" validate:"omitempty,min=0"`
+	Currency           *string          `json:"currency,omitempty" validate:"omitempty,len=3"`
+	PRDFileURL         *string          `json:"prd_file_url,omitempty" validate:"omitempty,url"`
+	SubmissionCriteria *string          `json:"submission_criteria,omitempty" validate:"omitempty,max=2000"`
+	ContractType       *string          `json:"contract_type,omitempty" validate:"omitempty,oneof=fixed hourly retainer"` // switching away from fixed drops the milestones
+	HourlyRate         *float64         `json:"hourly_rate,omitempty" validate:"omitempty,min=0"`
+	WeeklyHourCap      *float64         `json:"weekly_hour_cap,omitempty" validate:"omitempty,min=0,max=168"`
+	RetainerAmount     *float64         `json:"retainer_amount,omitempty" validate:"omitempty,min=0"`
+	BillingCycle       *string          `json:"billing_cycle,omitempty" validate:"omitempty,oneof=weekly monthly"`
+	RetainerCycles     *int             `json:"retainer_cycles,omitempty" validate:"omitempty,min=0,max=120"`
+	ClientName         *string          `json:"client_name,omitempty" validate:"omitempty,max=120"`
+	ClientCompanyName  *string          `json:"client_company_name,omitempty" validate:"omitempty,max=120"`
+	ClientEmail        *string          `json:"client_email,omitempty" validate:"omitempty,email"`
+	ClientPhone        *string          `json:"client_phone,omitempty" validate:"omitempty,max=30"`
+	TermsAndConditions *string          `json:"terms_and_conditions,omitempty" validate:"omitempty,max=10000"`
 	Milestones         []MilestoneInput `json:"milestones,omitempty" validate:"omitempty,dive"`
+	SigningOrder       *string          `json:"signing_order,omitempty" validate:"omitempty,oneof=parallel sequential"`
+	Signers            []SignerInput    `json:"signers,omitempty" validate:"omitempty,max=10,dive"` // when present (even empty) replaces all additional signers
 }
 
 // ContractResponse is the API response for a contract (with milestones)
 type ContractResponse struct {
-	ID                 uint                 `json:"id"`
-	FreelancerUserID   uint                 `json:"freelancer_user_id"`
-	ProjectCategory    string               `json:"project_category"`
-	ProjectName        string               `json:"project_name"`
-	Description        string               `json:"description"`
-	DueDate            *time.Time           `json:"due_date,omitempty"`
-	TotalAmount        float64              `json:"total_amount"`
-	Currency           string               `json:"currency"`
-	PRDFileURL         string               `json:"prd_file_url,omitempty"`
-	SubmissionCriteria string               `json:"submission_criteria,omitempty"`
-	ClientName         string               `json:"client_name"`
-	ClientCompanyName  string               `json:"client_company_name,omitempty"`
-	ClientEmail        string               `json:"client_email"`
-	ClientPhone        string               `json:"client_phone,omitempty"`
-	TermsAndConditions string               `json:"terms_and_conditions,omitempty"`
-	Status             string               `json:"status"`
-	SentAt             *time.Time           `json:"sent_at,omitempty"`
-	ShareableLink      string               `json:"shareable_link,omitempty"` // Set when status is sent; base URL + /:id
-	Milestones         []MilestoneResponse  `json:"milestones"`
-	CreatedAt          time.Time            `json:"created_at"`
-	UpdatedAt          time.Time            `json:"updated_at"`
+	ID                      uint                 `json:"id"`
+	FreelancerUserID        uint                 `json:"freelancer_user_id"`
+	ProjectCategory         string               `json:"project_category"`
+	ProjectName             string               `json:"project_name"`
+	Description             string               `json:"description"`
+	DueDate                 *time.Time           `json:"due_date,omitempty"`
+	TotalAmount             float64              `json:"total_amount"` // hourly and retainer: billed so far (approved time, generated periods)
+	Currency                string               `json:"currency"`
+	PRDFileURL              string               `json:"prd_file_url,omitempty"`
+	SubmissionCriteria      string               `json:"submission_criteria,omitempty"`
+	ContractType            string               `json:"contract_type"` // fixed | hourly | retainer
+	HourlyRate              float64              `json:"hourly_rate,omitempty"`
+	WeeklyHourCap           float64              `json:"weekly_hour_cap,omitempty"`
+	RetainerAmount          float64              `json:"retainer_amount,omitempty"` // per billing cycle
+	BillingCycle            string               `json:"billing_cycle,omitempty"`
+	RetainerCycles          int                  `json:"retainer_cycles,omitempty"`           // 0 = open-ended
+	RetainerCyclesGenerated int                  `json:"retainer_cycles_generated,omitempty"` // periods billed so far
+	NextCycleAt             *time.Time           `json:"next_cycle_at,omitempty"`
+	ClientName              string               `json:"client_name"`
+	ClientCompanyName       string               `json:"client_company_name,omitempty"`
+	ClientEmail             string               `json:"client_email"`
+	ClientPhone             string               `json:"client_phone,omitempty"`
+	TermsAndConditions      string               `json:"terms_and_conditions,omitempty"`
+	Status                  string               `json:"status"`
+	SentAt                  *time.Time           `json:"sent_at,omitempty"`
+	ShareableLink           string               `json:"shareable_link,omitempty"`         // Set when status is sent; base URL + /:id
+	ClientSignerVerified    bool                 `json:"client_signer_verified,omitempty"` // signer confirmed a one-time code sent to their email
+	ProfileVisibility       string               `json:"profile_visibility"`               // hidden | anonymized | full
+	ClientPublicConsent     bool                 `json:"client_public_consent"`            // client agreed to be named on the freelancer's profile
+	ClientPublicConsentAt   *time.Time           `json:"client_public_consent_at,omitempty"`
+	FirstViewedAt           *time.Time           `json:"first_viewed_at,omitempty"` // client link opens; null until the client opens it
+	LastViewedAt            *time.Time           `json:"last_viewed_at,omitempty"`
+	ViewCount               int                  `json:"view_count"`
+	Tags                    []string             `json:"tags"`                          // freelancer's own labels
+	PrivateNotes            string               `json:"private_notes,omitempty"`       // freelancer only; never in the client view
+	ArchivedAt              *time.Time           `json:"archived_at,omitempty"`         // hidden from the default list while set
+	ClientVerification      map[string]string    `json:"client_verification,omitempty"` // set on sign: gst_number, business_email, linkedin -> none | self_declared | format_valid | verified
+	ClientSignedAt          *time.Time           `json:"client_signed_at,omitempty"`
+	ClientSignature         *SignatureResponse   `json:"client_signature,omitempty"`     // mark and evidence hash from the client's sign
+	FreelancerSignedAt      *time.Time           `json:"freelancer_signed_at,omitempty"` // countersign; status becomes active
+	FreelancerSignedName    string               `json:"freelancer_signed_name,omitempty"`
+	SigningOrder            string               `json:"signing_order"`
+	Signers                 []SignerResponse     `json:"signers,omitempty"`
+	TermsVersion            int                  `json:"terms_version"`
+	Version                 int                  `json:"version"` // also the ETag; send it as If-Match on update
+	CancelledAt             *time.Time           `json:"cancelled_at,omitempty"`
+	CompletedAt             *time.Time           `json:"completed_at,omitempty"`       // last milestone approved
+	ClientRating            *int                 `json:"client_rating,omitempty"`      // 1-10, from the client's testimonial
+	ClientTestimonial       string               `json:"client_testimonial,omitempty"` // comment left with the rating
+	Milestones              []MilestoneResponse  `json:"milestones"`
+	Attachments             []AttachmentResponse `json:"attachments,omitempty"`
+	CreatedAt               time.Time            `json:"created_at"`
+	UpdatedAt               time.Time            `json:"updated_at"`
 }
 
 // MilestoneResponse is one milestone in API response
 type MilestoneResponse struct {
-	ID                uint       `json:"id"`
-	OrderIndex        int        `json:"order_index"`
-	Title             string     `json:"title"`
-	Description       string     `json:"description,omitempty"`
-	Amount            float64    `json:"amount"`
-	DueDate           *time.Time `json:"due_date,omitempty"`
-	IsInitialPayment  bool       `json:"is_initial_payment"`
-	Status            string     `json:"status"`
-	CreatedAt         time.Time  `json:"created_at"`
-	UpdatedAt         time.Time  `json:"updated_at"`
+	ID               uint       `json:"id"`
+	OrderIndex       int        `json:"order_index"`
+	Title            string     `json:"title"`
+	Description      string     `json:"description,omitempty"`
+	Amount           float64    `json:"amount"`
+	DueDate          *time.Time `json:"due_date,omitempty"`
+	IsInitialPayment bool       `json:"is_initial_payment"`
+	Status           string     `json:"status"` // pending | submitted | approved | paid
+	SubmittedAt      *time.Time `json:"submitted_at,omitempty"`
+	SubmissionNote   string     `json:"submission_note,omitempty"`
+	ApprovedAt       *time.Time `json:"approved_at,omitempty"`
+	RevisionCount    int        `json:"revision_count"`
+	ChangesNote      string     `json:"changes_note,omitempty"` // client's latest request for changes
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
 }
 
 // ListContractsQuery is used for GET /contracts query params
 type ListContractsQuery struct {
-	Status string `json:"status"` // draft, sent, ...
-	Page   int    `json:"page"`
-	Limit  int    `json:"limit"`
+	Status   string `json:"status"`   // draft, sent, ...
+	Tag      string `json:"tag"`      // only contracts with this tag
+	Search   string `json:"q"`        // matches project, client name, client company and private notes
+	Archived string `json:"archived"` // false (default) | true | all; with q the default is all
+	Page     int    `json:"page"`
+	Limit    int    `json:"limit"`
+}
+
+// SetContractTagsRequest is the body for PUT /api/v1/contracts/:id/tags; it replaces the contract's tags.
+// Tags are trimmed and lower-cased; duplicates are dropped.
+type SetContractTagsRequest struct {
+	Tags []string `json:"tags" validate:"max=20,dive,required,max=30"`
+}
+
+// UpdateContractNotesRequest is the body for PUT /api/v1/contracts/:id/notes (empty clears the notes)
+type UpdateContractNotesRequest struct {
+	Notes string `json:"notes" validate:"max=10000"`
+}
+
+// TagCountResponse is one of the freelancer's tags with the number of contracts using it
+type TagCountResponse struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
 }
 
 // PublicContractViewResponse is returned by GET /api/v1/public/contracts/:token (no auth). Safe for client view.
 type PublicContractViewResponse struct {
-	ID                  uint                 `json:"id"`
-	ProjectCategory     string               `json:"project_category"`
-	ProjectName         string               `json:"project_name"`
-	Description         string               `json:"description"`
-	DueDate             *time.Time           `json:"due_date,omitempty"`
-	TotalAmount         float64              `json:"total_amount"`
-	Currency            string               `json:"currency"`
-	PRDFileURL          string               `json:"prd_file_url,omitempty"`
-	SubmissionCriteria  string               `json:"submission_criteria,omitempty"`
-	ClientName          string               `json:"client_name"`
-	ClientCompanyName   string               `json:"client_company_name,omitempty"`
-	ClientEmail         string               `json:"client_email"`
-	ClientPhone         string               `json:"client_phone,omitempty"`
-	TermsAndConditions  string               `json:"terms_and_conditions,omitempty"`
-	Status              string               `json:"status"`
-	SentAt              *time.Time           `json:"sent_at,omitempty"`
-	ClientReviewComment string               `json:"client_review_comment,omitempty"` // set when status is pending
-	Milestones          []MilestoneResponse  `json:"milestones"`
-	CreatedAt           time.Time            `json:"created_at"`
-	UpdatedAt           time.Time            `json:"updated_at"`
+	ID                      uint                 `json:"id"`
+	ProjectCategory         string               `json:"project_category"`
+	ProjectName             string               `json:"project_name"`
+	Description             string               `json:"description"`
+	DueDate                 *time.Time           `json:"due_date,omitempty"`
+	TotalAmount             float64              `json:"total_amount"` // hourly and retainer: billed so far
+	Currency                string               `json:"currency"`
+	PRDFileURL              string               `json:"prd_file_url,omitempty"`
+	SubmissionCriteria      string               `json:"submission_criteria,omitempty"`
+	ContractType            string               `json:"contract_type"` // fixed | hourly | retainer
+	HourlyRate              float64              `json:"hourly_rate,omitempty"`
+	WeeklyHourCap           float64              `json:"weekly_hour_cap,omitempty"`
+	RetainerAmount          float64              `json:"retainer_amount,omitempty"` // per billing cycle
+	BillingCycle            string               `json:"billing_cycle,omitempty"`
+	RetainerCycles          int                  `json:"retainer_cycles,omitempty"`           // 0 = open-ended
+	RetainerCyclesGenerated int                  `json:"retainer_cycles_generated,omitempty"` // periods billed so far
+	NextCycleAt             *time.Time           `json:"next_cycle_at,omitempty"`
+	ClientName              string               `json:"client_name"`
+	ClientCompanyName       string               `json:"client_company_name,omitempty"`
+	ClientEmail             string               `json:"client_email"`
+	ClientPhone             string               `json:"client_phone,omitempty"`
+	TermsAndConditions      string               `json:"terms_and_conditions,omitempty"`
+	Status                  string               `json:"status"`
+	SentAt                  *time.Time           `json:"sent_at,omitempty"`
+	ClientReviewComment     string               `json:"client_review_comment,omitempty"` // set when status is pending
+	SignRequiresCode        bool                 `json:"sign_requires_code"`              // sign needs otp_code from request-code
+	ClientSignerVerified    bool                 `json:"client_signer_verified,omitempty"`
+	ClientSignature         *SignatureResponse   `json:"client_signature,omitempty"` // set once the client signed
+	ProfileVisibility       string               `json:"profile_visibility"`         // what the client consents to with allow_public_profile
+	ClientPublicConsent     bool                 `json:"client_public_consent"`
+	SigningOrder            string               `json:"signing_order"`
+	Signers                 []SignerStatus       `json:"signers"`                        // primary client first, then additional signers
+	FreelancerSignedAt      *time.Time           `json:"freelancer_signed_at,omitempty"` // freelancer countersigned
+	TermsVersion            int                  `json:"terms_version"`
+	CancelledAt             *time.Time           `json:"cancelled_at,omitempty"`
+	CompletedAt             *time.Time           `json:"completed_at,omitempty"`       // last milestone approved
+	ClientRating            *int                 `json:"client_rating,omitempty"`      // 1-10, from the client's testimonial
+	ClientTestimonial       string               `json:"client_testimonial,omitempty"` // comment left with the rating
+	Milestones              []MilestoneResponse  `json:"milestones"`
+	Attachments             []AttachmentResponse `json:"attachments,omitempty"`
+	CreatedAt               time.Time            `json:"created_at"`
+	UpdatedAt               time.Time            `json:"updated_at"`
 }
 
 // SendForReviewRequest is the body for POST /api/v1/public/contracts/:token/send-for-review
@@ -136,8 +239,79 @@ type SignRequest struct {
 	Email          string `json:"email,omitempty" validate:"omitempty,email,max=255"`
 	Phone          string `json:"phone,omitempty" validate:"omitempty,max=30"`
 	CompanyName    string `json:"company_name,omitempty" validate:"omitempty,max=120"`
-	GSTNumber      string `json:"gst_number,omitempty" validate:"omitempty,max=20"`
+	GSTNumber      string `json:"gst_number,omitempty" validate:"omitempty,max=20"` // validated as GSTIN (format, state code, PAN, checksum)
 	BusinessEmail  string `json:"business_email,omitempty" validate:"omitempty,email,max=255"`
 	Instagram      string `json:"instagram,omitempty" validate:"omitempty,max=100"`
 	LinkedIn       string `json:"linkedin,omitempty" validate:"omitempty,url,max=300"`
+	OTPCode        string `json:"otp_code,omitempty" validate:"omitempty,len=6,numeric"` // required when sign codes are enforced
+	// AllowPublicProfile lets the freelancer name the client (and show the amount) when the completed contract is
+	// showcased with profile_visibility full. Recorded with the sign time.
+	AllowPublicProfile bool `json:"allow_public_profile,omitempty"`
+	// Signature is the visible mark; required when the server enforces signature capture
+	Signature *SignatureInput `json:"signature,omitempty" validate:"omitempty"`
+}
+
+// SignatureInput is the client's mark on sign: a typed name in one of the styles, or a drawn signature.
+// Image is an SVG or PNG, as a data URL (data:image/png;base64,...), plain base64, or raw SVG markup.
+type SignatureInput struct {
+	Type      string `json:"type" validate:"required,oneof=typed drawn"`
+	TypedName string `json:"typed_name,omitempty" validate:"required_if=Type typed,max=120"`
+	Style     string `json:"style,omitempty" validate:"omitempty,oneof=script cursive formal"` // typed only; default script
+	Image     string `json:"image,omitempty" validate:"required_if=Type drawn,max=400000"`
+}
+
+// SignatureResponse describes the client's signature mark. The image is the attachment with AttachmentID
+// (download it from the attachments route); EvidenceHash is the sha256 of the signing evidence it is bound to.
+type SignatureResponse struct {
+	Type         string `json:"type,omitempty"`  // typed | drawn; empty when signed without a mark
+	Style        string `json:"style,omitempty"` // typed only
+	AttachmentID *uint  `json:"attachment_id,omitempty"`
+	ContentType  string `json:"content_type,omitempty"` // image/svg+xml | image/png
+	SHA256       string `json:"sha256,omitempty"`       // of the image
+	EvidenceHash string `json:"evidence_hash"`
+}
+
+// RequestSignCodeResponse tells the client where the code went (masked) and until when it is valid
+type RequestSignCodeResponse struct {
+	SentTo    string    `json:"sent_to"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CountersignRequest is the body for POST /api/v1/contracts/:id/countersign
+type CountersignRequest struct {
+	FullName string `json:"full_name" validate:"required,min=2,max=120"` // typed name, stored as evidence
+}
+
+// SubmitMilestoneRequest is the body for POST /api/v1/contracts/:id/milestones/:milestone_id/submit
+// Deliverable files are uploaded separately as attachments with kind=deliverable and the milestone_id.
+type SubmitMilestoneRequest struct {
+	Note string `json:"note,omitempty" validate:"omitempty,max=2000"`
+}
+
+// MilestoneApprovalResponse is returned when the client approves a milestone
+type MilestoneApprovalResponse struct {
+	Milestone      MilestoneResponse `json:"milestone"`
+	ContractStatus string            `json:"contract_status"`        // completed once every milestone is approved
+	CompletedAt    *time.Time        `json:"completed_at,omitempty"` // set when this approval completed the contract
+}
+
+// CompleteContractResponse is returned by POST /api/v1/contracts/:id/complete
+type CompleteContractResponse struct {
+	ContractStatus string     `json:"contract_status"`
+	CompletedAt    *time.Time `json:"completed_at"`
+}
+
+// ContractViewResponse is one open of the client link
+type ContractViewResponse struct {
+	ViewedAt  time.Time `json:"viewed_at"`
+	UserAgent string    `json:"user_agent"` // browser family and OS, e.g. "Chrome on Windows"
+	IPPrefix  string    `json:"ip_prefix"`  // anonymized: IPv4 /24 or IPv6 /48
+}
+
+// ContractViewsResponse is the response for GET /api/v1/contracts/:id/views
+type ContractViewsResponse struct {
+	FirstViewedAt *time.Time             `json:"first_viewed_at,omitempty"`
+	LastViewedAt  *time.Time             `json:"last_viewed_at,omitempty"`
+	ViewCount     int                    `json:"view_count"`
+	Views         []ContractViewResponse `json:"views"` // most recent first, up to 100
 }
@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ContractEventResponse is one event on GET /api/v1/contracts/events; it is the SSE data line and its ID the SSE id
+type ContractEventResponse struct {
+	ID         uint            `json:"id"`
+	ContractID uint            `json:"contract_id"`
+	Type       string          `json:"type"`
+	Data       json.RawMessage `json:"data"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
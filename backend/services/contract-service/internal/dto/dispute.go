@@ -0,0 +1,58 @@
+package dto
+
+import "time"
+
+// OpenDisputeRequest is the body for opening a dispute on a contract or one of its milestones.
+// attachment_ids reference files already uploaded to the contract (kind evidence, deliverables, ...).
+type OpenDisputeRequest struct {
+	MilestoneID   *uint  `json:"milestone_id,omitempty"`
+	Category      string `json:"category" validate:"required,oneof=quality scope payment deadline communication other"`
+	Reason        string `json:"reason" validate:"required,max=5000"`
+	AttachmentIDs []uint `json:"attachment_ids,omitempty" validate:"omitempty,max=10"`
+}
+
+// DisputeMessageRequest adds a message to the dispute thread
+type DisputeMessageRequest struct {
+	Body          string `json:"body" validate:"required,max=5000"`
+	AttachmentIDs []uint `json:"attachment_ids,omitempty" validate:"omitempty,max=10"`
+}
+
+// EscalateDisputeRequest optionally explains why a party escalates to an admin
+type EscalateDisputeRequest struct {
+	Comment string `json:"comment,omitempty" validate:"omitempty,max=2000"`
+}
+
+// ResolveDisputeRequest is the admin decision
+type ResolveDisputeRequest struct {
+	Resolution string `json:"resolution" validate:"required,oneof=freelancer client split"`
+	Note       string `json:"note" validate:"required,max=5000"`
+}
+
+// DisputeMessageResponse is one message in the thread
+type DisputeMessageResponse struct {
+	ID            uint      `json:"id"`
+	Author        string    `json:"author"` // freelancer | client | admin
+	Body          string    `json:"body"`
+	AttachmentIDs []uint    `json:"attachment_ids,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// DisputeResponse is a dispute with its thread (messages only on single-dispute responses)
+type DisputeResponse struct {
+	ID             uint                     `json:"id"`
+	ContractID     uint                     `json:"contract_id"`
+	MilestoneID    *uint                    `json:"milestone_id,omitempty"`
+	OpenedBy       string                   `json:"opened_by"`
+	Category       string                   `json:"category"`
+	Reason         string                   `json:"reason"`
+	Status         string                   `json:"status"` // open | awaiting_response | escalated | resolved
+	AwaitingParty  string                   `json:"awaiting_party,omitempty"`
+	DueAt          *time.Time               `json:"due_at,omitempty"`
+	EscalatedAt    *time.Time               `json:"escalated_at,omitempty"`
+	Resolution     string                   `json:"resolution,omitempty"`
+	ResolutionNote string                   `json:"resolution_note,omitempty"`
+	ResolvedAt     *time.Time               `json:"resolved_at,omitempty"`
+	Messages       []DisputeMessageResponse `json:"messages,omitempty"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+}
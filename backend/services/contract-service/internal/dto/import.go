@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+// ImportRow is one parsed CSV row. Request is nil when the row could not be mapped (e.g. bad number or date).
+type ImportRow struct {
+	Row     int                    // 1-based data row number (header excluded)
+	Request *CreateContractRequest // mapped row; validated with the same tags as POST /contracts
+	Errors  []string
+}
+
+// ImportRowError reports why a row was rejected
+type ImportRowError struct {
+	Row    int      `json:"row"`
+	Errors []string `json:"errors"`
+}
+
+// ImportContractsResponse is returned by POST /api/v1/contracts/import (dry run and commit)
+type ImportContractsResponse struct {
+	JobID     uint             `json:"job_id,omitempty"` // set when not a dry run; poll GET /api/v1/contracts/imports/:job_id
+	DryRun    bool             `json:"dry_run"`
+	Status    string           `json:"status,omitempty"`
+	TotalRows int              `json:"total_rows"`
+	ValidRows int              `json:"valid_rows"`
+	Errors    []ImportRowError `json:"errors"`
+}
+
+// ImportJobResponse is returned by GET /api/v1/contracts/imports/:job_id
+type ImportJobResponse struct {
+	ID            uint             `json:"id"`
+	FileName      string           `json:"file_name,omitempty"`
+	Status        string           `json:"status"` // processing | completed | failed
+	TotalRows     int              `json:"total_rows"`
+	ValidRows     int              `json:"valid_rows"`
+	ImportedCount int              `json:"imported_count"`
+	ContractIDs   []uint           `json:"contract_ids"`
+	Errors        []ImportRowError `json:"errors"`
+	ErrorMessage  string           `json:"error_message,omitempty"`
+	CompletedAt   *time.Time       `json:"completed_at,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
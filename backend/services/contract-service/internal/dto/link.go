@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+// IssueLinkRequest asks for an extra client link, e.g. view-only for the client's colleagues
+type IssueLinkRequest struct {
+	Scopes        []string `json:"scopes" validate:"required,min=1,dive,oneof=view review sign"`
+	ExpiresInDays int      `json:"expires_in_days,omitempty" validate:"omitempty,min=1,max=365"` // default 30
+}
+
+// ClientLinkResponse is an issued client link
+type ClientLinkResponse struct {
+	Link      string    `json:"link,omitempty"` // empty when SHAREABLE_LINK_BASE_URL is not set
+	Token     string    `json:"token"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
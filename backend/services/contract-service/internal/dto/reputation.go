@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/reputation"
+)
+
+// RequestMilestoneChangesRequest is the client's body when sending a submitted milestone back for rework
+type RequestMilestoneChangesRequest struct {
+	Comment string `json:"comment" validate:"required,max=2000"`
+}
+
+// ContractReputationResponse explains a contract's score: the inputs it was computed from and each weighted component.
+type ContractReputationResponse struct {
+	ContractID     uint                   `json:"contract_id"`
+	Score          float64                `json:"score"`
+	FormulaVersion int                    `json:"formula_version"`
+	Components     []reputation.Component `json:"components"`
+	Inputs         reputation.Inputs      `json:"inputs"`
+	ComputedAt     time.Time              `json:"computed_at"`
+}
+
+// ContractScoreSummary is one row of the freelancer's reputation breakdown
+type ContractScoreSummary struct {
+	ContractID     uint      `json:"contract_id"`
+	Score          float64   `json:"score"`
+	FormulaVersion int       `json:"formula_version"`
+	ComputedAt     time.Time `json:"computed_at"`
+}
+
+// ReputationSummaryResponse is the freelancer's aggregate score (mean of current-formula contract scores)
+type ReputationSummaryResponse struct {
+	Score           float64                `json:"score"`
+	ContractsScored int                    `json:"contracts_scored"`
+	FormulaVersion  int                    `json:"formula_version"`
+	UpdatedAt       *time.Time             `json:"updated_at,omitempty"`
+	Contracts       []ContractScoreSummary `json:"contracts"`
+}
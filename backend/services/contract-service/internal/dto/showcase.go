@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// SetProfileVisibilityRequest sets how a completed contract appears on the freelancer's public profile
+type SetProfileVisibilityRequest struct {
+	Visibility string `json:"visibility" validate:"required,oneof=hidden anonymized full"`
+}
+
+// ContractSummary is a redacted completed contract for the freelancer's public profile; no project details or
+// documents. Anonymized contracts carry no client, amount or value band. Full ones carry the value band, and the
+// client and exact amount only when the client consented at sign.
+type ContractSummary struct {
+	ContractID        uint      `json:"contract_id"`
+	Visibility        string    `json:"visibility"` // anonymized | full
+	ProjectCategory   string    `json:"project_category"`
+	ContractType      string    `json:"contract_type"`        // fixed | hourly | retainer
+	ValueBand         string    `json:"value_band,omitempty"` // e.g. "1k-5k"; see service.valueBand
+	Amount            *float64  `json:"amount,omitempty"`
+	Currency          string    `json:"currency,omitempty"`
+	DurationDays      int       `json:"duration_days"` // client sign to completion
+	CompletedAt       time.Time `json:"completed_at"`
+	OnTime            bool      `json:"on_time"`
+	ClientName        string    `json:"client_name,omitempty"`
+	ClientCompanyName string    `json:"client_company_name,omitempty"`
+	Rating            *int      `json:"rating,omitempty"` // 1-10, from the client's testimonial
+	Testimonial       string    `json:"testimonial,omitempty"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+import "time"
+
+// SignerInput is one additional signer in create/update payload
+type SignerInput struct {
+	Name     string `json:"name" validate:"required,min=2,max=120"`
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Role     string `json:"role,omitempty" validate:"omitempty,max=60"` // e.g. project_lead, finance_approver
+	Required *bool  `json:"required,omitempty"`                         // default true
+}
+
+// SignerResponse is an additional signer as seen by the freelancer (includes link and evidence)
+type SignerResponse struct {
+	ID              uint       `json:"id"`
+	OrderIndex      int        `json:"order_index"`
+	Name            string     `json:"name"`
+	Email           string     `json:"email"`
+	Role            string     `json:"role,omitempty"`
+	Required        bool       `json:"required"`
+	Status          string     `json:"status"`              // pending | signed
+	SignLink        string     `json:"sign_link,omitempty"` // set once the contract is sent
+	NotifiedAt      *time.Time `json:"notified_at,omitempty"`
+	SignedAt        *time.Time `json:"signed_at,omitempty"`
+	SignedName      string     `json:"signed_name,omitempty"`
+	Designation     string     `json:"designation,omitempty"`
+	SignerIP        string     `json:"signer_ip,omitempty"`
+	SignerUserAgent string     `json:"signer_user_agent,omitempty"`
+	Verified        bool       `json:"verified"` // signed with a code emailed to the signer
+}
+
+// SignerStatus is a party's signing status in the public contract view (no emails or evidence)
+type SignerStatus struct {
+	Name     string     `json:"name"`
+	Role     string     `json:"role,omitempty"`
+	Required bool       `json:"required"`
+	Status   string     `json:"status"` // pending | signed
+	SignedAt *time.Time `json:"signed_at,omitempty"`
+}
+
+// SignerViewResponse is returned by GET /api/v1/public/signers/:token
+type SignerViewResponse struct {
+	Signer           SignerStatus                `json:"signer"`
+	CanSign          bool                        `json:"can_sign"`           // false when already signed, not this signer's turn, or the contract is not open for signing
+	SignRequiresCode bool                        `json:"sign_requires_code"` // sign needs otp_code from request-code
+	Contract         *PublicContractViewResponse `json:"contract"`
+}
+
+// SignerSignRequest is the body for POST /api/v1/public/signers/:token/sign
+type SignerSignRequest struct {
+	FullName    string `json:"full_name" validate:"required,min=2,max=120"` // typed name, stored as evidence
+	Designation string `json:"designation,omitempty" validate:"omitempty,max=120"`
+	OTPCode     string `json:"otp_code,omitempty" validate:"omitempty,len=6,numeric"` // from POST .../sign/request-code
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+// SubmitTestimonialRequest is the client's one-time rating (1-10) and comment on a completed contract
+type SubmitTestimonialRequest struct {
+	Rating  int    `json:"rating" validate:"required,min=1,max=10"`
+	Comment string `json:"comment" validate:"required,max=2000"`
+}
+
+// TestimonialResponse confirms a stored testimonial
+type TestimonialResponse struct {
+	ContractID  uint      `json:"contract_id"`
+	Rating      int       `json:"rating"`
+	Comment     string    `json:"comment"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
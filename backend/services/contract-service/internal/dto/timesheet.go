@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+// LogTimesheetRequest is the body for POST /api/v1/contracts/:id/timesheet
+type LogTimesheetRequest struct {
+	WorkDate    string  `json:"work_date" validate:"required,datetime=2006-01-02"` // day the work was done; not in the future
+	Hours       float64 `json:"hours" validate:"required,min=0.25,max=24"`
+	Description string  `json:"description" validate:"required,max=1000"`
+}
+
+// RejectTimesheetEntryRequest is the body for POST /api/v1/public/contracts/:token/timesheet/:entry_id/reject
+type RejectTimesheetEntryRequest struct {
+	Comment string `json:"comment" validate:"required,max=1000"`
+}
+
+// TimesheetEntryResponse is one logged entry
+type TimesheetEntryResponse struct {
+	ID            uint       `json:"id"`
+	WorkDate      string     `json:"work_date"` // YYYY-MM-DD
+	Hours         float64    `json:"hours"`
+	Description   string     `json:"description"`
+	Amount        float64    `json:"amount"` // hours at the rate when logged
+	Status        string     `json:"status"` // pending | approved | rejected
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	RejectionNote string     `json:"rejection_note,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// TimesheetSummary totals an hourly contract's timesheet. Approved amounts are already in the contract's total_amount.
+type TimesheetSummary struct {
+	HourlyRate       float64 `json:"hourly_rate"`
+	Currency         string  `json:"currency"`
+	WeeklyHourCap    float64 `json:"weekly_hour_cap,omitempty"`
+	CurrentWeekHours float64 `json:"current_week_hours"` // pending and approved, this week (Monday to Sunday, UTC)
+	ApprovedHours    float64 `json:"approved_hours"`
+	ApprovedAmount   float64 `json:"approved_amount"`
+	PendingHours     float64 `json:"pending_hours"`
+	PendingAmount    float64 `json:"pending_amount"`
+}
+
+// TimesheetResponse is the response for GET .../timesheet
+type TimesheetResponse struct {
+	Summary TimesheetSummary         `json:"summary"`
+	Entries []TimesheetEntryResponse `json:"entries"` // most recent work date first
+}
@@ -0,0 +1,66 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// InternalTokenHeader carries the shared secret on service-to-service calls.
+const InternalTokenHeader = "X-Internal-Token"
+
+// HTTPPublisher posts events to user-service's internal API.
+type HTTPPublisher struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewHTTPPublisher builds a publisher for baseURL (e.g. http://user-service:8081) authenticated with token.
+func NewHTTPPublisher(baseURL, token string) *HTTPPublisher {
+	return &HTTPPublisher{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *HTTPPublisher) PublishContractCompleted(ctx context.Context, evt ContractCompleted) error {
+	return p.post(ctx, "/internal/v1/events/contract-completed", evt)
+}
+
+func (p *HTTPPublisher) PublishReputationUpdated(ctx context.Context, evt ReputationUpdated) error {
+	return p.post(ctx, "/internal/v1/events/reputation-updated", evt)
+}
+
+func (p *HTTPPublisher) PublishTestimonialSubmitted(ctx context.Context, evt TestimonialSubmitted) error {
+	return p.post(ctx, "/internal/v1/events/testimonial-submitted", evt)
+}
+
+func (p *HTTPPublisher) post(ctx context.Context, path string, body interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+path, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(InternalTokenHeader, p.token)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s: %s", path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// ContractCompleted is published once a contract reaches completed (every milestone approved, or completed by the
+// freelancer for hourly and open-ended retainer contracts).
+// user-service turns it into a verified project on the freelancer's profile, keyed by ContractID.
+type ContractCompleted struct {
+	ContractID        uint       `json:"contract_id"`
+	FreelancerUserID  uint       `json:"freelancer_user_id"`
+	ProjectCategory   string     `json:"project_category"`
+	ProjectName       string     `json:"project_name"`
+	Description       string     `json:"description,omitempty"`
+	ClientName        string     `json:"client_name"`
+	ClientCompanyName string     `json:"client_company_name,omitempty"`
+	ContractType      string     `json:"contract_type"` // fixed | hourly | retainer
+	TotalAmount       float64    `json:"total_amount"`  // hourly and retainer: what was billed (approved time, retainer periods)
+	Currency          string     `json:"currency"`
+	StartedAt         *time.Time `json:"started_at,omitempty"` // client signed
+	DueDate           *time.Time `json:"due_date,omitempty"`
+	CompletedAt       time.Time  `json:"completed_at"`
+	OnTime            bool       `json:"on_time"` // every milestone (and the contract) submitted by its due date
+}
+
+// ReputationUpdated carries a freelancer's aggregate reputation after any of their contract scores changed.
+// user-service stores it in the profile stats (reputation_score); the latest event wins.
+type ReputationUpdated struct {
+	FreelancerUserID uint      `json:"freelancer_user_id"`
+	Score            float64   `json:"score"` // 0-100
+	ContractsScored  int       `json:"contracts_scored"`
+	FormulaVersion   int       `json:"formula_version"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// TestimonialSubmitted is published when the client leaves a rating and comment on a completed contract.
+// user-service adds it to the freelancer's profile as a verified testimonial, keyed by ContractID.
+type TestimonialSubmitted struct {
+	ContractID        uint      `json:"contract_id"`
+	FreelancerUserID  uint      `json:"freelancer_user_id"`
+	ClientName        string    `json:"client_name"`
+	ClientCompanyName string    `json:"client_company_name,omitempty"`
+	ClientEmail       string    `json:"client_email"`
+	ProjectName       string    `json:"project_name"`
+	Rating            int       `json:"rating"` // 1-10
+	Comment           string    `json:"comment"`
+	SubmittedAt       time.Time `json:"submitted_at"`
+}
+
+// Publisher delivers contract lifecycle events to other services. Publishing may be retried, so consumers
+// must be idempotent.
+type Publisher interface {
+	PublishContractCompleted(ctx context.Context, evt ContractCompleted) error
+	PublishReputationUpdated(ctx context.Context, evt ReputationUpdated) error
+	PublishTestimonialSubmitted(ctx context.Context, evt TestimonialSubmitted) error
+}
+
+// NoopPublisher drops events. Use when user-service is not reachable (development).
+type NoopPublisher struct{}
+
+func (NoopPublisher) PublishContractCompleted(context.Context, ContractCompleted) error { return nil }
+
+func (NoopPublisher) PublishReputationUpdated(context.Context, ReputationUpdated) error { return nil }
+
+func (NoopPublisher) PublishTestimonialSubmitted(context.Context, TestimonialSubmitted) error {
+	return nil
+}
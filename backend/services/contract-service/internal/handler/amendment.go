@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/middleware"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/service"
+)
+
+// AmendmentHandler serves change orders: freelancer proposes/lists/withdraws, client accepts/rejects via the contract link.
+type AmendmentHandler struct {
+	validator *middleware.Validator
+	svc       *service.AmendmentService
+}
+
+func NewAmendmentHandler(svc *service.AmendmentService) *AmendmentHandler {
+	return &AmendmentHandler{
+		validator: middleware.NewValidator(),
+		svc:       svc,
+	}
+}
+
+func (h *AmendmentHandler) RegisterRoutes(r chi.Router, authMw func(http.Handler) http.Handler) {
+	r.With(authMw).Group(func(r chi.Router) {
+		r.Post("/api/v1/contracts/{id}/amendments", h.Propose)
+		r.Get("/api/v1/contracts/{id}/amendments", h.List)
+		r.Post("/api/v1/contracts/{id}/amendments/{amendmentId}/withdraw", h.Withdraw)
+	})
+	r.Get("/api/v1/public/contracts/{token}/amendments", h.ListByClientToken)
+	r.Post("/api/v1/public/contracts/{token}/amendments/{amendmentId}/accept", h.Accept)
+	r.Post("/api/v1/public/contracts/{token}/amendments/{amendmentId}/reject", h.Reject)
+}
+
+func (h *AmendmentHandler) Propose(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	var req dto.ProposeAmendmentRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.Propose(r.Context(), uint(id), r.Context().Value("user_id").(uint), &req)
+	if err != nil {
+		respondAmendmentError(w, err, "Failed to propose change order")
+		return
+	}
+	respondSuccess(w, http.StatusCreated, out, "Change order sent to client")
+}
+
+func (h *AmendmentHandler) List(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	out, err := h.svc.List(r.Context(), uint(id), r.Context().Value("user_id").(uint))
+	if err != nil {
+		respondAmendmentError(w, err, "Failed to list change orders")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+func (h *AmendmentHandler) Withdraw(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	amendmentID, err := strconv.ParseUint(chi.URLParam(r, "amendmentId"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid amendment ID", "BAD_REQUEST")
+		return
+	}
+	out, err := h.svc.Withdraw(r.Context(), uint(id), r.Context().Value("user_id").(uint), uint(amendmentID))
+	if err != nil {
+		respondAmendmentError(w, err, "Failed to withdraw change order")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Change order withdrawn")
+}
+
+// ListByClientToken returns the change orders for the client view (no auth).
+func (h *AmendmentHandler) ListByClientToken(w http.ResponseWriter, r *http.Request) {
+	out, err := h.svc.ListByClientToken(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		respondAmendmentError(w, err, "Failed to list change orders")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+// Accept applies the change order as a new terms version (no auth). Body: { "full_name": "..." }.
+func (h *AmendmentHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	amendmentID, err := strconv.ParseUint(chi.URLParam(r, "amendmentId"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid amendment ID", "BAD_REQUEST")
+		return
+	}
+	var req dto.AcceptAmendmentRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.Accept(r.Context(), chi.URLParam(r, "token"), uint(amendmentID), &req, clientIP(r), r.UserAgent())
+	if err != nil {
+		respondAmendmentError(w, err, "Failed to accept change order")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Change order accepted")
+}
+
+// Reject closes the change order with the client's reason (no auth). Body: { "comment": "..." }.
+func (h *AmendmentHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	amendmentID, err := strconv.ParseUint(chi.URLParam(r, "amendmentId"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid amendment ID", "BAD_REQUEST")
+		return
+	}
+	var req dto.RejectAmendmentRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.Reject(r.Context(), chi.URLParam(r, "token"), uint(amendmentID), &req)
+	if err != nil {
+		respondAmendmentError(w, err, "Failed to reject change order")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Change order rejected")
+}
+
+func respondAmendmentError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrContractNotFound):
+		respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+	case errors.Is(err, repository.ErrAmendmentNotFound):
+		respondError(w, http.StatusNotFound, "Change order not found", "NOT_FOUND")
+	case errors.Is(err, service.ErrAmendmentNotAllowed):
+		respondError(w, http.StatusConflict, err.Error(), "AMENDMENT_NOT_ALLOWED")
+	case errors.Is(err, service.ErrAmendmentPending):
+		respondError(w, http.StatusConflict, err.Error(), "AMENDMENT_PENDING")
+	case errors.Is(err, service.ErrAmendmentClosed):
+		respondError(w, http.StatusConflict, err.Error(), "AMENDMENT_CLOSED")
+	case errors.Is(err, service.ErrAmendmentStale):
+		respondError(w, http.StatusConflict, err.Error(), "AMENDMENT_STALE")
+	case errors.Is(err, service.ErrEmptyAmendment), errors.Is(err, service.ErrIncompleteMilestone):
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+	case errors.Is(err, service.ErrInvalidMilestone):
+		respondError(w, http.StatusBadRequest, err.Error(), "INVALID_MILESTONE")
+	case errors.Is(err, service.ErrAmendmentType), errors.Is(err, service.ErrInvalidContractTerms):
+		respondError(w, http.StatusBadRequest, err.Error(), "INVALID_CONTRACT_TERMS")
+	case errors.Is(err, service.ErrMilestoneLocked):
+		respondError(w, http.StatusConflict, err.Error(), "MILESTONE_LOCKED")
+	case errors.Is(err, service.ErrMilestonesFrozen):
+		respondError(w, http.StatusConflict, err.Error(), "MILESTONES_FROZEN")
+	default:
+		respondError(w, http.StatusInternalServerError, fallback, "INTERNAL_ERROR")
+	}
+}
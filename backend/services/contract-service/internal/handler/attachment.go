@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/middleware"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/service"
+)
+
+// AttachmentHandler serves upload, list, download and delete of contract attachments.
+type AttachmentHandler struct {
+	validator *middleware.Validator
+	svc       *service.AttachmentService
+}
+
+func NewAttachmentHandler(svc *service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		validator: middleware.NewValidator(),
+		svc:       svc,
+	}
+}
+
+func (h *AttachmentHandler) RegisterRoutes(r chi.Router, authMw func(http.Handler) http.Handler) {
+	r.With(authMw).Group(func(r chi.Router) {
+		r.Post("/api/v1/contracts/{id}/attachments", h.Upload)
+		r.Get("/api/v1/contracts/{id}/attachments", h.List)
+		r.Get("/api/v1/contracts/{id}/attachments/{attachmentId}", h.Download)
+		r.Delete("/api/v1/contracts/{id}/attachments/{attachmentId}", h.Delete)
+	})
+	// Client download and dispute evidence upload via the contract link (no auth)
+	r.Get("/api/v1/public/contracts/{token}/attachments/{attachmentId}", h.DownloadByClientToken)
+	r.Post("/api/v1/public/contracts/{token}/attachments", h.UploadByClientToken)
+}
+
+// Upload accepts multipart/form-data: file (required), kind (prd | deliverable | evidence | other), milestone_id (optional).
+func (h *AttachmentHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	in, ok := h.readUpload(w, r, "other")
+	if !ok {
+		return
+	}
+	out, err := h.svc.Upload(r.Context(), uint(id), r.Context().Value("user_id").(uint), in)
+	if err != nil {
+		respondUploadError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusCreated, out, "Attachment uploaded")
+}
+
+// UploadByClientToken lets the client upload dispute evidence via the contract link (no auth). Same multipart
+// fields as Upload; kind must be evidence (the default here).
+func (h *AttachmentHandler) UploadByClientToken(w http.ResponseWriter, r *http.Request) {
+	in, ok := h.readUpload(w, r, "evidence")
+	if !ok {
+		return
+	}
+	out, err := h.svc.UploadByClientToken(r.Context(), chi.URLParam(r, "token"), in)
+	if err != nil {
+		respondUploadError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusCreated, out, "Attachment uploaded")
+}
+
+// readUpload parses and validates the multipart upload; defaultKind applies when kind is omitted.
+func (h *AttachmentHandler) readUpload(w http.ResponseWriter, r *http.Request, defaultKind string) (*dto.UploadAttachmentInput, bool) {
+	// allow some room for multipart headers and form fields on top of the file limit
+	r.Body = http.MaxBytesReader(w, r.Body, h.svc.MaxBytes()+64<<10)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			respondError(w, http.StatusRequestEntityTooLarge, service.ErrAttachmentTooLarge.Error(), "ATTACHMENT_TOO_LARGE")
+			return nil, false
+		}
+		respondError(w, http.StatusBadRequest, "multipart field 'file' is required", "BAD_REQUEST")
+		return nil, false
+	}
+	defer file.Close()
+	content, err := io.ReadAll(io.LimitReader(file, h.svc.MaxBytes()+1))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Failed to read file", "BAD_REQUEST")
+		return nil, false
+	}
+	in := dto.UploadAttachmentInput{
+		Kind:     r.FormValue("kind"),
+		FileName: header.Filename,
+		Content:  content,
+	}
+	if in.Kind == "" {
+		in.Kind = defaultKind
+	}
+	if v := r.FormValue("milestone_id"); v != "" {
+		mid, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid milestone_id", "BAD_REQUEST")
+			return nil, false
+		}
+		m := uint(mid)
+		in.MilestoneID = &m
+	}
+	if err := h.validator.ValidateStruct(&in); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return nil, false
+	}
+	return &in, true
+}
+
+func respondUploadError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrContractNotFound):
+		respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+	case errors.Is(err, service.ErrAttachmentTooLarge):
+		respondError(w, http.StatusRequestEntityTooLarge, err.Error(), "ATTACHMENT_TOO_LARGE")
+	case errors.Is(err, service.ErrAttachmentEmpty):
+		respondError(w, http.StatusBadRequest, err.Error(), "ATTACHMENT_EMPTY")
+	case errors.Is(err, service.ErrAttachmentTypeNotAllowed):
+		respondError(w, http.StatusUnsupportedMediaType, err.Error(), "ATTACHMENT_TYPE_NOT_ALLOWED")
+	case errors.Is(err, service.ErrAttachmentNotAllowed):
+		respondError(w, http.StatusConflict, err.Error(), "ATTACHMENT_NOT_ALLOWED")
+	case errors.Is(err, service.ErrInvalidMilestone), errors.Is(err, service.ErrMilestoneRequired):
+		respondError(w, http.StatusBadRequest, err.Error(), "INVALID_MILESTONE")
+	default:
+		respondError(w, http.StatusInternalServerError, "Failed to upload attachment", "INTERNAL_ERROR")
+	}
+}
+
+func (h *AttachmentHandler) List(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	out, err := h.svc.List(r.Context(), uint(id), r.Context().Value("user_id").(uint))
+	if err != nil {
+		if errors.Is(err, repository.ErrContractNotFound) {
+			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to list attachments", "INTERNAL_ERROR")
+		return
+	}
+	if out == nil {
+		out = []dto.AttachmentResponse{}
+	}
+	respondSuccess(w, http.StatusOK, map[string]interface{}{"attachments": out}, "OK")
+}
+
+func (h *AttachmentHandler) Download(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	attachmentID, err := strconv.ParseUint(chi.URLParam(r, "attachmentId"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid attachment ID", "BAD_REQUEST")
+		return
+	}
+	meta, rc, err := h.svc.Open(r.Context(), uint(id), r.Context().Value("user_id").(uint), uint(attachmentID))
+	h.stream(w, r, meta, rc, err)
+}
+
+// DownloadByClientToken streams an attachment for the client view (no auth). Token from URL.
+func (h *AttachmentHandler) DownloadByClientToken(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	if token == "" {
+		respondError(w, http.StatusBadRequest, "Missing token", "BAD_REQUEST")
+		return
+	}
+	attachmentID, err := strconv.ParseUint(chi.URLParam(r, "attachmentId"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid attachment ID", "BAD_REQUEST")
+		return
+	}
+	meta, rc, err := h.svc.OpenByClientToken(r.Context(), token, uint(attachmentID))
+	h.stream(w, r, meta, rc, err)
+}
+
+func (h *AttachmentHandler) stream(w http.ResponseWriter, r *http.Request, meta *dto.AttachmentResponse, rc io.ReadCloser, err error) {
+	if err != nil {
+		if errors.Is(err, repository.ErrContractNotFound) {
+			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+			return
+		}
+		if errors.Is(err, repository.ErrAttachmentNotFound) {
+			respondError(w, http.StatusNotFound, "Attachment not found", "NOT_FOUND")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to get attachment", "INTERNAL_ERROR")
+		return
+	}
+	defer rc.Close()
+	// content is immutable per CID, so the CID is a strong validator
+	etag := `"` + meta.CID + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", meta.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(meta.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": meta.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, rc)
+}
+
+func (h *AttachmentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	attachmentID, err := strconv.ParseUint(chi.URLParam(r, "attachmentId"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid attachment ID", "BAD_REQUEST")
+		return
+	}
+	if err := h.svc.Delete(r.Context(), uint(id), r.Context().Value("user_id").(uint), uint(attachmentID)); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrContractNotFound):
+			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+		case errors.Is(err, repository.ErrAttachmentNotFound):
+			respondError(w, http.StatusNotFound, "Attachment not found", "NOT_FOUND")
+		case errors.Is(err, service.ErrNotDraft):
+			respondError(w, http.StatusBadRequest, "Attachments can only be removed from draft or pending contracts", "NOT_DRAFT")
+		default:
+			respondError(w, http.StatusInternalServerError, "Failed to delete attachment", "INTERNAL_ERROR")
+		}
+		return
+	}
+	respondSuccess(w, http.StatusOK, map[string]string{"message": "Attachment deleted"}, "OK")
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/ical"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/service"
+)
+
+// CalendarHandler serves contract deadlines as iCalendar: one contract for download, or all of the freelancer's
+// contracts through a secret subscription URL calendar apps poll.
+type CalendarHandler struct {
+	svc *service.CalendarService
+}
+
+func NewCalendarHandler(svc *service.CalendarService) *CalendarHandler {
+	return &CalendarHandler{svc: svc}
+}
+
+func (h *CalendarHandler) RegisterRoutes(r chi.Router, authMw func(http.Handler) http.Handler) {
+	r.With(authMw).Group(func(r chi.Router) {
+		r.Get("/api/v1/contracts/{id}/calendar.ics", h.ContractCalendar)
+		r.Get("/api/v1/calendar/feed", h.GetFeed)
+		r.Post("/api/v1/calendar/feed", h.CreateFeed)
+		r.Delete("/api/v1/calendar/feed", h.DeleteFeed)
+	})
+	// Public (no auth): the token in the URL is the credential
+	r.Get("/api/v1/public/calendar/{token}/deadlines.ics", h.Feed)
+}
+
+// ContractCalendar downloads one contract's deadlines as an .ics file.
+func (h *CalendarHandler) ContractCalendar(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	body, err := h.svc.ContractCalendar(r.Context(), uint(id), r.Context().Value("user_id").(uint))
+	if err != nil {
+		if errors.Is(err, repository.ErrContractNotFound) {
+			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to export calendar", "INTERNAL_ERROR")
+		return
+	}
+	w.Header().Set("Content-Disposition", `attachment; filename="contract-`+strconv.FormatUint(id, 10)+`.ics"`)
+	respondCalendar(w, body)
+}
+
+// Feed is the subscription calendar behind the secret URL.
+func (h *CalendarHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	body, err := h.svc.FeedCalendar(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		if errors.Is(err, repository.ErrCalendarFeedNotFound) {
+			respondError(w, http.StatusNotFound, "Calendar feed not found", "NOT_FOUND")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to export calendar", "INTERNAL_ERROR")
+		return
+	}
+	respondCalendar(w, body)
+}
+
+func (h *CalendarHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	out, err := h.svc.GetFeed(r.Context(), r.Context().Value("user_id").(uint))
+	if err != nil {
+		respondFeedError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+// CreateFeed issues the subscription URL, replacing (and revoking) any previous one. The URL is only shown here.
+func (h *CalendarHandler) CreateFeed(w http.ResponseWriter, r *http.Request) {
+	out, err := h.svc.CreateFeed(r.Context(), r.Context().Value("user_id").(uint))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create calendar feed", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusCreated, out, "Calendar feed created; the URL is shown only once")
+}
+
+func (h *CalendarHandler) DeleteFeed(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.DeleteFeed(r.Context(), r.Context().Value("user_id").(uint)); err != nil {
+		respondFeedError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, map[string]string{"message": "Calendar feed revoked"}, "OK")
+}
+
+func respondFeedError(w http.ResponseWriter, err error) {
+	if errors.Is(err, repository.ErrCalendarFeedNotFound) {
+		respondError(w, http.StatusNotFound, "No calendar feed; create one first", "CALENDAR_FEED_NOT_FOUND")
+		return
+	}
+	respondError(w, http.StatusInternalServerError, "Failed to get calendar feed", "INTERNAL_ERROR")
+}
+
+func respondCalendar(w http.ResponseWriter, body []byte) {
+	w.Header().Set("Content-Type", ical.ContentType)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
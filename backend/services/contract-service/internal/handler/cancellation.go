@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/middleware"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/service"
+)
+
+// CancellationHandler serves withdraw/decline before signing and mutual cancellation after signing.
+type CancellationHandler struct {
+	validator *middleware.Validator
+	svc       *service.CancellationService
+}
+
+func NewCancellationHandler(svc *service.CancellationService) *CancellationHandler {
+	return &CancellationHandler{
+		validator: middleware.NewValidator(),
+		svc:       svc,
+	}
+}
+
+func (h *CancellationHandler) RegisterRoutes(r chi.Router, authMw func(http.Handler) http.Handler) {
+	r.With(authMw).Group(func(r chi.Router) {
+		r.Post("/api/v1/contracts/{id}/withdraw", h.Withdraw)
+		r.Get("/api/v1/contracts/{id}/cancellation", h.Get)
+		r.Post("/api/v1/contracts/{id}/cancellation", h.Request)
+		r.Post("/api/v1/contracts/{id}/cancellation/accept", h.Accept)
+		r.Post("/api/v1/contracts/{id}/cancellation/reject", h.Reject)
+	})
+	// Client side via the contract link (no auth)
+	r.Post("/api/v1/public/contracts/{token}/decline", h.Decline)
+	r.Get("/api/v1/public/contracts/{token}/cancellation", h.GetByClient)
+	r.Post("/api/v1/public/contracts/{token}/cancellation", h.RequestByClient)
+	r.Post("/api/v1/public/contracts/{token}/cancellation/accept", h.AcceptByClient)
+	r.Post("/api/v1/public/contracts/{token}/cancellation/reject", h.RejectByClient)
+}
+
+func (h *CancellationHandler) contractID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *CancellationHandler) userID(r *http.Request) uint {
+	return r.Context().Value("user_id").(uint)
+}
+
+// Withdraw cancels a sent contract before the client signed. Body: { "reason": "..." }.
+func (h *CancellationHandler) Withdraw(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.contractID(w, r)
+	if !ok {
+		return
+	}
+	var req dto.CancelRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.Withdraw(r.Context(), id, h.userID(r), req.Reason)
+	if err != nil {
+		respondCancellationError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Contract withdrawn")
+}
+
+func (h *CancellationHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.contractID(w, r)
+	if !ok {
+		return
+	}
+	out, err := h.svc.GetByFreelancer(r.Context(), id, h.userID(r))
+	if err != nil {
+		respondCancellationError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+// Request asks the client to agree to cancel a signed/active contract. Body: { "reason": "..." }.
+func (h *CancellationHandler) Request(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.contractID(w, r)
+	if !ok {
+		return
+	}
+	var req dto.CancelRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.RequestByFreelancer(r.Context(), id, h.userID(r), req.Reason)
+	if err != nil {
+		respondCancellationError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusCreated, out, "Cancellation requested")
+}
+
+func (h *CancellationHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	h.respondByFreelancer(w, r, true)
+}
+
+func (h *CancellationHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.respondByFreelancer(w, r, false)
+}
+
+func (h *CancellationHandler) respondByFreelancer(w http.ResponseWriter, r *http.Request, accept bool) {
+	id, ok := h.contractID(w, r)
+	if !ok {
+		return
+	}
+	req, ok := h.decodeResponse(w, r)
+	if !ok {
+		return
+	}
+	out, err := h.svc.RespondByFreelancer(r.Context(), id, h.userID(r), accept, req.Comment)
+	if err != nil {
+		respondCancellationError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, cancellationResponseMessage(accept))
+}
+
+// Decline lets the client refuse the contract before signing (no auth). Body: { "reason": "..." }.
+func (h *CancellationHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	var req dto.CancelRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.Decline(r.Context(), chi.URLParam(r, "token"), req.Reason)
+	if err != nil {
+		respondCancellationError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Contract declined")
+}
+
+func (h *CancellationHandler) GetByClient(w http.ResponseWriter, r *http.Request) {
+	out, err := h.svc.GetByClient(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		respondCancellationError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+// RequestByClient asks the freelancer to agree to cancel a signed/active contract (no auth).
+func (h *CancellationHandler) RequestByClient(w http.ResponseWriter, r *http.Request) {
+	var req dto.CancelRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.RequestByClient(r.Context(), chi.URLParam(r, "token"), req.Reason)
+	if err != nil {
+		respondCancellationError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusCreated, out, "Cancellation requested")
+}
+
+func (h *CancellationHandler) AcceptByClient(w http.ResponseWriter, r *http.Request) {
+	h.respondByClient(w, r, true)
+}
+
+func (h *CancellationHandler) RejectByClient(w http.ResponseWriter, r *http.Request) {
+	h.respondByClient(w, r, false)
+}
+
+func (h *CancellationHandler) respondByClient(w http.ResponseWriter, r *http.Request, accept bool) {
+	req, ok := h.decodeResponse(w, r)
+	if !ok {
+		return
+	}
+	out, err := h.svc.RespondByClient(r.Context(), chi.URLParam(r, "token"), accept, req.Comment)
+	if err != nil {
+		respondCancellationError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, cancellationResponseMessage(accept))
+}
+
+// decodeResponse reads the optional { "comment": "..." } body.
+func (h *CancellationHandler) decodeResponse(w http.ResponseWriter, r *http.Request) (*dto.RespondCancellationRequest, bool) {
+	var req dto.RespondCancellationRequest
+	if r.ContentLength != 0 {
+		if err := h.validator.ValidateJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return nil, false
+		}
+	}
+	return &req, true
+}
+
+func cancellationResponseMessage(accept bool) string {
+	if accept {
+		return "Contract cancelled"
+	}
+	return "Cancellation rejected"
+}
+
+func respondCancellationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrContractNotFound):
+		respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+	case errors.Is(err, service.ErrCancelNotAllowed):
+		respondError(w, http.StatusConflict, err.Error(), "CANCEL_NOT_ALLOWED")
+	case errors.Is(err, service.ErrCancellationPending):
+		respondError(w, http.StatusConflict, err.Error(), "CANCELLATION_PENDING")
+	case errors.Is(err, service.ErrNoCancellationRequest):
+		respondError(w, http.StatusNotFound, err.Error(), "NO_CANCELLATION_REQUEST")
+	case errors.Is(err, service.ErrOwnCancellationRequest):
+		respondError(w, http.StatusConflict, err.Error(), "OWN_CANCELLATION_REQUEST")
+	default:
+		respondError(w, http.StatusInternalServerError, "Failed to process cancellation", "INTERNAL_ERROR")
+	}
+}
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/middleware"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/service"
+)
+
+// ClientHandler serves the client dashboard: contracts received by a client account (role client).
+type ClientHandler struct {
+	validator *middleware.Validator
+	svc       *service.ClientService
+}
+
+func NewClientHandler(svc *service.ClientService) *ClientHandler {
+	return &ClientHandler{
+		validator: middleware.NewValidator(),
+		svc:       svc,
+	}
+}
+
+func (h *ClientHandler) RegisterRoutes(r chi.Router, authMw, idempotencyMw func(http.Handler) http.Handler) {
+	r.With(authMw, middleware.RequireRole("client")).Route("/api/v1/client", func(r chi.Router) {
+		r.Post("/claim/request-code", h.RequestClaimCode)
+		r.Post("/claim", h.Claim)
+		r.Get("/contracts", h.List)
+		r.Get("/contracts/{id}", h.Get)
+		r.Post("/contracts/{id}/send-for-review", h.SendForReview)
+		r.With(idempotencyMw).Post("/contracts/{id}/sign", h.Sign)
+	})
+}
+
+func (h *ClientHandler) userID(r *http.Request) uint {
+	return r.Context().Value("user_id").(uint)
+}
+
+func (h *ClientHandler) userEmail(r *http.Request) string {
+	email, _ := r.Context().Value("user_email").(string)
+	return email
+}
+
+// RequestClaimCode emails a verification code to the account email.
+func (h *ClientHandler) RequestClaimCode(w http.ResponseWriter, r *http.Request) {
+	out, err := h.svc.RequestClaimCode(r.Context(), h.userID(r), h.userEmail(r))
+	if err != nil {
+		if errors.Is(err, service.ErrClientEmailRequired) {
+			respondError(w, http.StatusBadRequest, err.Error(), "BAD_REQUEST")
+			return
+		}
+		if errors.Is(err, service.ErrSignCodeTooSoon) {
+			respondError(w, http.StatusTooManyRequests, err.Error(), "SIGN_CODE_TOO_SOON")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to send verification code", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Verification code sent")
+}
+
+// Claim verifies the account email with the code and links contracts addressed to it. Body: { "otp_code": "123456" }.
+func (h *ClientHandler) Claim(w http.ResponseWriter, r *http.Request) {
+	var req dto.ClaimContractsRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.Claim(r.Context(), h.userID(r), h.userEmail(r), req.OTPCode)
+	if err != nil {
+		if errors.Is(err, service.ErrClientEmailRequired) {
+			respondError(w, http.StatusBadRequest, err.Error(), "BAD_REQUEST")
+			return
+		}
+		if respondSignCodeError(w, err) {
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to claim contracts", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Email verified")
+}
+
+func (h *ClientHandler) List(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	list, total, err := h.svc.List(r.Context(), h.userID(r), h.userEmail(r), status, page, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list contracts", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, map[string]interface{}{
+		"contracts": list,
+		"total":     total,
+		"page":      page,
+		"limit":     limit,
+	}, "OK")
+}
+
+func (h *ClientHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	out, err := h.svc.Get(r.Context(), uint(id), h.userID(r))
+	if err != nil {
+		if errors.Is(err, repository.ErrContractNotFound) {
+			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to get contract", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+func (h *ClientHandler) SendForReview(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	var req dto.SendForReviewRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	if err := h.svc.SendForReview(r.Context(), uint(id), h.userID(r), &req); err != nil {
+		if errors.Is(err, repository.ErrContractNotFound) {
+			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+			return
+		}
+		if errors.Is(err, service.ErrAlreadyPending) {
+			respondError(w, http.StatusConflict, "Contract is already pending review", "ALREADY_PENDING")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to send for review", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, map[string]string{"message": "Sent for review"}, "OK")
+}
+
+// Sign records client sign from the dashboard. Same body as the public sign route; otp_code is not needed.
+func (h *ClientHandler) Sign(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	var req dto.SignRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.Sign(r.Context(), uint(id), h.userID(r), h.userEmail(r), &req)
+	if err != nil {
+		if errors.Is(err, repository.ErrContractNotFound) {
+			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+			return
+		}
+		if errors.Is(err, service.ErrClientEmailRequired) {
+			respondError(w, http.StatusBadRequest, err.Error(), "BAD_REQUEST")
+			return
+		}
+		if errors.Is(err, service.ErrClientEmailNotVerified) {
+			respondError(w, http.StatusForbidden, err.Error(), "EMAIL_NOT_VERIFIED")
+			return
+		}
+		if errors.Is(err, service.ErrAlreadySigned) {
+			respondError(w, http.StatusConflict, "Contract was already signed", "ALREADY_SIGNED")
+			return
+		}
+		if errors.Is(err, service.ErrInvalidCompanyAddr) {
+			respondError(w, http.StatusBadRequest, err.Error(), "INVALID_COMPANY_ADDRESS")
+			return
+		}
+		if errors.Is(err, service.ErrInvalidGSTNumber) {
+			respondError(w, http.StatusBadRequest, err.Error(), "INVALID_GST_NUMBER")
+			return
+		}
+		if respondSignatureError(w, err) {
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to sign contract", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Contract signed")
+}
@@ -13,25 +13,37 @@ import (
 )
 
 type ContractHandler struct {
-	validator *middleware.Validator
-	svc       *service.ContractService
+	validator      *middleware.Validator
+	svc            *service.ContractService
+	requireIfMatch bool
 }
 
-func NewContractHandler(svc *service.ContractService) *ContractHandler {
+// NewContractHandler creates the contract handler. With requireIfMatch, an update without If-Match is refused with 428.
+func NewContractHandler(svc *service.ContractService, requireIfMatch bool) *ContractHandler {
 	return &ContractHandler{
-		validator: middleware.NewValidator(),
-		svc:       svc,
+		validator:      middleware.NewValidator(),
+		svc:            svc,
+		requireIfMatch: requireIfMatch,
 	}
 }
 
-func (h *ContractHandler) RegisterRoutes(r chi.Router, authMw func(http.Handler) http.Handler) {
+// RegisterRoutes registers the contract routes; idempotencyMw (middleware.Idempotency) guards create, send and sign.
+func (h *ContractHandler) RegisterRoutes(r chi.Router, authMw, idempotencyMw func(http.Handler) http.Handler) {
 	r.Route("/api/v1/contracts", func(r chi.Router) {
 		r.With(authMw).Group(func(r chi.Router) {
-			r.Post("/", h.Create)
+			r.With(idempotencyMw).Post("/", h.Create)
 			r.Get("/", h.List)
+			r.Get("/tags", h.ListTags)
 			r.Get("/{id}", h.GetByID)
+			r.Get("/{id}/views", h.ListViews)
 			r.Put("/{id}", h.Update)
-			r.Post("/{id}/send", h.Send)
+			r.Put("/{id}/tags", h.SetTags)
+			r.Put("/{id}/notes", h.UpdateNotes)
+			r.Post("/{id}/archive", h.Archive)
+			r.Post("/{id}/unarchive", h.Unarchive)
+			r.With(idempotencyMw).Post("/{id}/send", h.Send)
+			r.Post("/{id}/countersign", h.Countersign)
+			r.Post("/{id}/links", h.IssueLink)
 			r.Delete("/{id}", h.Delete)
 		})
 	})
@@ -39,7 +51,9 @@ func (h *ContractHandler) RegisterRoutes(r chi.Router, authMw func(http.Handler)
 	r.Route("/api/v1/public/contracts", func(r chi.Router) {
 		r.Get("/{token}", h.GetByClientToken)
 		r.Post("/{token}/send-for-review", h.SendForReview)
-		r.Post("/{token}/sign", h.Sign)
+		r.Post("/{token}/sign/request-code", h.RequestSignCode)
+		r.With(idempotencyMw).Post("/{token}/sign", h.Sign)
+		r.Post("/{token}/links", h.IssueLinkByClientToken)
 	})
 }
 
@@ -55,6 +69,14 @@ func (h *ContractHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 	out, err := h.svc.Create(r.Context(), h.userID(r), &req)
 	if err != nil {
+		if err == service.ErrDuplicateSigner {
+			respondError(w, http.StatusBadRequest, err.Error(), "DUPLICATE_SIGNER")
+			return
+		}
+		if err == service.ErrInvalidContractTerms {
+			respondError(w, http.StatusBadRequest, err.Error(), "INVALID_CONTRACT_TERMS")
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to create contract", "INTERNAL_ERROR")
 		return
 	}
@@ -76,56 +98,183 @@ func (h *ContractHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusInternalServerError, "Failed to get contract", "INTERNAL_ERROR")
 		return
 	}
+	w.Header().Set("ETag", versionETag(out.Version))
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+// ListViews returns when and from what kind of device the client opened the contract link.
+func (h *ContractHandler) ListViews(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	out, err := h.svc.ListViews(r.Context(), uint(id), h.userID(r))
+	if err != nil {
+		if errors.Is(err, repository.ErrContractNotFound) {
+			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to get contract views", "INTERNAL_ERROR")
+		return
+	}
 	respondSuccess(w, http.StatusOK, out, "OK")
 }
 
+// List returns the freelancer's contracts. Query: status, tag, q (search), archived (false | true | all), page, limit.
 func (h *ContractHandler) List(w http.ResponseWriter, r *http.Request) {
-	status := r.URL.Query().Get("status")
-	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
 	if page < 1 {
 		page = 1
 	}
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
 	if limit < 1 || limit > 100 {
 		limit = 20
 	}
-	list, total, err := h.svc.List(r.Context(), h.userID(r), status, page, limit)
+	archived := query.Get("archived")
+	if archived != "" && archived != repository.ArchivedExclude && archived != repository.ArchivedOnly && archived != repository.ArchivedInclude {
+		respondError(w, http.StatusBadRequest, "archived must be false, true or all", "VALIDATION_ERROR")
+		return
+	}
+	q := &dto.ListContractsQuery{Status: query.Get("status"), Tag: query.Get("tag"), Search: query.Get("q"), Archived: archived, Page: page, Limit: limit}
+	list, total, err := h.svc.List(r.Context(), h.userID(r), q)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to list contracts", "INTERNAL_ERROR")
 		return
 	}
 	respondSuccess(w, http.StatusOK, map[string]interface{}{
-		"contracts":   list,
-		"total":       total,
-		"page":        page,
-		"limit":       limit,
+		"contracts": list,
+		"total":     total,
+		"page":      page,
+		"limit":     limit,
 	}, "OK")
 }
 
+// ListTags returns the freelancer's tags with contract counts, most used first.
+func (h *ContractHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	out, err := h.svc.ListTags(r.Context(), h.userID(r))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list tags", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+// SetTags replaces the contract's tags. Body: { "tags": ["retainer", "design"] }.
+func (h *ContractHandler) SetTags(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	var req dto.SetContractTagsRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.SetTags(r.Context(), uint(id), h.userID(r), &req)
+	h.respondOrganize(w, out, err, "Failed to set tags")
+}
+
+// UpdateNotes sets the freelancer's private notes. Body: { "notes": "..." }.
+func (h *ContractHandler) UpdateNotes(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	var req dto.UpdateContractNotesRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.UpdateNotes(r.Context(), uint(id), h.userID(r), &req)
+	h.respondOrganize(w, out, err, "Failed to update notes")
+}
+
+// Archive hides the contract from the default list.
+func (h *ContractHandler) Archive(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	out, err := h.svc.Archive(r.Context(), uint(id), h.userID(r))
+	h.respondOrganize(w, out, err, "Failed to archive contract")
+}
+
+// Unarchive restores an archived contract to the default list.
+func (h *ContractHandler) Unarchive(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	out, err := h.svc.Unarchive(r.Context(), uint(id), h.userID(r))
+	h.respondOrganize(w, out, err, "Failed to restore contract")
+}
+
+// respondOrganize writes the result of a tags, notes or archive change.
+func (h *ContractHandler) respondOrganize(w http.ResponseWriter, out *dto.ContractResponse, err error, failure string) {
+	if err != nil {
+		if errors.Is(err, repository.ErrContractNotFound) {
+			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, failure, "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
 func (h *ContractHandler) Update(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
 	if err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
 		return
 	}
+	expectedVersion, err := ifMatchVersion(r, h.requireIfMatch)
+	if err != nil {
+		respondIfMatchError(w, err)
+		return
+	}
 	var req dto.UpdateContractRequest
 	if err := h.validator.ValidateJSON(r, &req); err != nil {
 		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
 		return
 	}
-	out, err := h.svc.Update(r.Context(), uint(id), h.userID(r), &req)
+	out, err := h.svc.Update(r.Context(), uint(id), h.userID(r), expectedVersion, &req)
 	if err != nil {
 		if err == repository.ErrContractNotFound {
 			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
 			return
 		}
+		if err == repository.ErrVersionConflict {
+			current, getErr := h.svc.GetByID(r.Context(), uint(id), h.userID(r))
+			if getErr != nil {
+				respondError(w, http.StatusInternalServerError, "Failed to get contract", "INTERNAL_ERROR")
+				return
+			}
+			respondPreconditionFailed(w, versionETag(current.Version), current)
+			return
+		}
 		if err == service.ErrNotDraft {
 			respondError(w, http.StatusBadRequest, "Only draft contracts can be updated", "NOT_DRAFT")
 			return
 		}
+		if err == service.ErrDuplicateSigner {
+			respondError(w, http.StatusBadRequest, err.Error(), "DUPLICATE_SIGNER")
+			return
+		}
+		if err == service.ErrInvalidContractTerms {
+			respondError(w, http.StatusBadRequest, err.Error(), "INVALID_CONTRACT_TERMS")
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to update contract", "INTERNAL_ERROR")
 		return
 	}
+	w.Header().Set("ETag", versionETag(out.Version))
 	respondSuccess(w, http.StatusOK, out, "Contract updated")
 }
 
@@ -183,7 +332,7 @@ func (h *ContractHandler) GetByClientToken(w http.ResponseWriter, r *http.Reques
 		respondError(w, http.StatusBadRequest, "Missing token", "BAD_REQUEST")
 		return
 	}
-	out, err := h.svc.GetByClientToken(r.Context(), token)
+	out, err := h.svc.GetByClientToken(r.Context(), token, clientIP(r), r.UserAgent())
 	if err != nil {
 		if errors.Is(err, repository.ErrContractNotFound) {
 			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
@@ -248,8 +397,161 @@ func (h *ContractHandler) Sign(w http.ResponseWriter, r *http.Request) {
 			respondError(w, http.StatusBadRequest, err.Error(), "INVALID_COMPANY_ADDRESS")
 			return
 		}
+		if errors.Is(err, service.ErrInvalidGSTNumber) {
+			respondError(w, http.StatusBadRequest, err.Error(), "INVALID_GST_NUMBER")
+			return
+		}
+		if respondSignCodeError(w, err) || respondSignatureError(w, err) {
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to sign contract", "INTERNAL_ERROR")
 		return
 	}
 	respondSuccess(w, http.StatusOK, out, "Contract signed")
 }
+
+// RequestSignCode emails a one-time sign code to the contract's client_email (no auth, no body).
+func (h *ContractHandler) RequestSignCode(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	if token == "" {
+		respondError(w, http.StatusBadRequest, "Missing token", "BAD_REQUEST")
+		return
+	}
+	out, err := h.svc.RequestSignCode(r.Context(), token)
+	if err != nil {
+		if errors.Is(err, repository.ErrContractNotFound) {
+			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+			return
+		}
+		if errors.Is(err, service.ErrAlreadySigned) {
+			respondError(w, http.StatusConflict, "Contract was already signed", "ALREADY_SIGNED")
+			return
+		}
+		if errors.Is(err, service.ErrSignCodeTooSoon) {
+			respondError(w, http.StatusTooManyRequests, err.Error(), "SIGN_CODE_TOO_SOON")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to send sign code", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Sign code sent")
+}
+
+// respondSignCodeError maps one-time sign code errors; returns false if err is not one of them.
+func respondSignCodeError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrSignCodeRequired):
+		respondError(w, http.StatusBadRequest, err.Error(), "SIGN_CODE_REQUIRED")
+	case errors.Is(err, service.ErrInvalidSignCode):
+		respondError(w, http.StatusBadRequest, err.Error(), "INVALID_SIGN_CODE")
+	case errors.Is(err, service.ErrSignCodeExpired):
+		respondError(w, http.StatusBadRequest, err.Error(), "SIGN_CODE_EXPIRED")
+	case errors.Is(err, service.ErrSignCodeAttemptsExceeded):
+		respondError(w, http.StatusTooManyRequests, err.Error(), "SIGN_CODE_ATTEMPTS_EXCEEDED")
+	default:
+		return false
+	}
+	return true
+}
+
+// respondSignatureError maps signature capture errors from sign; false when err is not one of them.
+func respondSignatureError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrSignatureRequired):
+		respondError(w, http.StatusBadRequest, err.Error(), "SIGNATURE_REQUIRED")
+	case errors.Is(err, service.ErrInvalidSignature):
+		respondError(w, http.StatusBadRequest, err.Error(), "INVALID_SIGNATURE")
+	case errors.Is(err, service.ErrSignatureTooLarge):
+		respondError(w, http.StatusRequestEntityTooLarge, err.Error(), "SIGNATURE_TOO_LARGE")
+	case errors.Is(err, service.ErrSignatureCaptureDisabled):
+		respondError(w, http.StatusBadRequest, err.Error(), "SIGNATURE_NOT_SUPPORTED")
+	default:
+		return false
+	}
+	return true
+}
+
+// Countersign records the freelancer's signature after the client signed; status signed -> active. Body: { "full_name": "..." }.
+func (h *ContractHandler) Countersign(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	var req dto.CountersignRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	email, _ := r.Context().Value("user_email").(string)
+	out, err := h.svc.Countersign(r.Context(), uint(id), h.userID(r), email, &req, clientIP(r), r.UserAgent())
+	if err != nil {
+		if errors.Is(err, repository.ErrContractNotFound) {
+			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+			return
+		}
+		if errors.Is(err, service.ErrNotSignedByClient) {
+			respondError(w, http.StatusConflict, err.Error(), "NOT_SIGNED")
+			return
+		}
+		if errors.Is(err, service.ErrAlreadyCountersigned) {
+			respondError(w, http.StatusConflict, err.Error(), "ALREADY_COUNTERSIGNED")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to countersign contract", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Contract countersigned")
+}
+
+// IssueLink creates an extra client link for a sent contract. Body: { "scopes": ["view"], "expires_in_days": 30 }.
+func (h *ContractHandler) IssueLink(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	var req dto.IssueLinkRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.IssueClientLink(r.Context(), uint(id), h.userID(r), &req)
+	if err != nil {
+		respondLinkError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusCreated, out, "Link created")
+}
+
+// IssueLinkByClientToken lets a link holder share the contract, e.g. view-only with colleagues (no auth). Same body
+// as IssueLink; scopes and expiry cannot exceed the caller's link.
+func (h *ContractHandler) IssueLinkByClientToken(w http.ResponseWriter, r *http.Request) {
+	var req dto.IssueLinkRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	claims, _ := middleware.LinkClaims(r.Context())
+	out, err := h.svc.IssueClientLinkByToken(r.Context(), chi.URLParam(r, "token"), claims, &req)
+	if err != nil {
+		respondLinkError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusCreated, out, "Link created")
+}
+
+func respondLinkError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrContractNotFound):
+		respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+	case errors.Is(err, service.ErrSignedLinksDisabled):
+		respondError(w, http.StatusConflict, err.Error(), "SIGNED_LINKS_DISABLED")
+	case errors.Is(err, service.ErrNoClientLink):
+		respondError(w, http.StatusConflict, err.Error(), "NOT_SENT")
+	case errors.Is(err, service.ErrLinkScopeExceeded):
+		respondError(w, http.StatusForbidden, err.Error(), "LINK_SCOPE")
+	default:
+		respondError(w, http.StatusInternalServerError, "Failed to create link", "INTERNAL_ERROR")
+	}
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/service"
+)
+
+// sseRetryMillis is the reconnect delay suggested to EventSource clients.
+const sseRetryMillis = 3000
+
+// ContractEventHandler streams activity on the freelancer's contracts as server-sent events.
+type ContractEventHandler struct {
+	svc       *service.ContractEventService
+	heartbeat time.Duration
+}
+
+// NewContractEventHandler builds the handler. A comment line is sent every heartbeat (<= 0 means 25s) so proxies
+// keep idle streams open and dead clients are noticed.
+func NewContractEventHandler(svc *service.ContractEventService, heartbeat time.Duration) *ContractEventHandler {
+	if heartbeat <= 0 {
+		heartbeat = 25 * time.Second
+	}
+	return &ContractEventHandler{svc: svc, heartbeat: heartbeat}
+}
+
+func (h *ContractEventHandler) RegisterRoutes(r chi.Router, authMw func(http.Handler) http.Handler) {
+	r.With(authMw).Get("/api/v1/contracts/events", h.Stream)
+}
+
+// Stream sends the freelancer's contract events as they happen (text/event-stream). With Last-Event-ID (header, or
+// ?last_event_id for clients that cannot set it) the events missed since that ID are sent first; without it the
+// stream starts with the next event. Optional ?contract_id limits it to one contract.
+func (h *ContractEventHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(uint)
+	var contractID uint
+	if v := r.URL.Query().Get("contract_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid contract_id", "BAD_REQUEST")
+			return
+		}
+		contractID = uint(id)
+	}
+	lastID, resume, err := lastEventID(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Last-Event-ID must be an event id", "INVALID_LAST_EVENT_ID")
+		return
+	}
+	// subscribe before reading the starting point so an event written in between still wakes the stream
+	wake, unsubscribe := h.svc.Subscribe(userID)
+	defer unsubscribe()
+	if !resume {
+		if lastID, err = h.svc.LatestID(r.Context(), userID); err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to open event stream", "INTERNAL_ERROR")
+			return
+		}
+	}
+
+	rc := http.NewResponseController(w)
+	// the stream outlives the server's write timeout; heartbeats detect dead clients instead
+	_ = rc.SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", sseRetryMillis)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	pending := resume
+	for {
+		if pending {
+			if lastID, err = h.sendAfter(r.Context(), w, userID, contractID, lastID); err != nil {
+				if r.Context().Err() == nil {
+					log.Printf("[contract-events] stream for user %d: %v", userID, err)
+				}
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+			pending = false
+		}
+		select {
+		case <-r.Context().Done():
+			return
+		case <-wake:
+			pending = true
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// sendAfter writes every stored event after lastID and returns the ID of the last one written.
+func (h *ContractEventHandler) sendAfter(ctx context.Context, w io.Writer, userID, contractID, lastID uint) (uint, error) {
+	for {
+		list, err := h.svc.ListAfter(ctx, userID, contractID, lastID)
+		if err != nil || len(list) == 0 {
+			return lastID, err
+		}
+		for _, e := range list {
+			b, err := json.Marshal(e)
+			if err != nil {
+				return lastID, err
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, b); err != nil {
+				return lastID, err
+			}
+			lastID = e.ID
+		}
+	}
+}
+
+// lastEventID reads the resume point; resume is false when the client sent none.
+func lastEventID(r *http.Request) (id uint, resume bool, err error) {
+	v := r.Header.Get("Last-Event-ID")
+	if v == "" {
+		v = r.URL.Query().Get("last_event_id")
+	}
+	if v == "" {
+		return 0, false, nil
+	}
+	n, err := strconv.ParseUint(v, 10, 32)
+	if err != nil {
+		return 0, false, err
+	}
+	return uint(n), true, nil
+}
@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/middleware"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/service"
+)
+
+const maxImportFileBytes = 5 << 20 // 5 MB
+
+var (
+	errEmptyCSV       = errors.New("CSV has no data rows")
+	errTooManyRows    = errors.New("CSV has too many rows")
+	errMissingColumns = errors.New("CSV header is missing required columns")
+)
+
+// ImportHandler serves bulk CSV import of contracts as drafts.
+type ImportHandler struct {
+	validator *middleware.Validator
+	svc       *service.ImportService
+	maxRows   int
+}
+
+// NewImportHandler creates the import handler. maxRows caps data rows per file; if <= 0, 500 is used.
+func NewImportHandler(svc *service.ImportService, maxRows int) *ImportHandler {
+	if maxRows <= 0 {
+		maxRows = 500
+	}
+	return &ImportHandler{
+		validator: middleware.NewValidator(),
+		svc:       svc,
+		maxRows:   maxRows,
+	}
+}
+
+func (h *ImportHandler) RegisterRoutes(r chi.Router, authMw func(http.Handler) http.Handler) {
+	r.With(authMw).Group(func(r chi.Router) {
+		r.Post("/api/v1/contracts/import", h.Import)
+		r.Get("/api/v1/contracts/imports/{jobId}", h.GetJob)
+	})
+}
+
+// Import accepts a CSV (multipart field "file" or a text/csv body). ?dry_run=true only validates.
+func (h *ImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileBytes)
+	src, fileName, err := importSource(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "BAD_REQUEST")
+		return
+	}
+	defer src.Close()
+
+	rows, err := h.parseContractCSV(src)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "INVALID_CSV")
+		return
+	}
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+	userID := r.Context().Value("user_id").(uint)
+	out, err := h.svc.Import(r.Context(), userID, fileName, rows, dryRun)
+	if err != nil {
+		if errors.Is(err, service.ErrNoValidRows) {
+			respondError(w, http.StatusUnprocessableEntity, "No valid rows to import; run with dry_run=true to see row errors", "NO_VALID_ROWS")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to import contracts", "INTERNAL_ERROR")
+		return
+	}
+	if dryRun {
+		respondSuccess(w, http.StatusOK, out, "Dry run complete")
+		return
+	}
+	respondSuccess(w, http.StatusAccepted, out, "Import started")
+}
+
+// GetJob returns the status of an import job started by the current user.
+func (h *ImportHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "jobId"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid import job ID", "BAD_REQUEST")
+		return
+	}
+	userID := r.Context().Value("user_id").(uint)
+	out, err := h.svc.GetJob(r.Context(), uint(id), userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrImportJobNotFound) {
+			respondError(w, http.StatusNotFound, "Import job not found", "NOT_FOUND")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to get import job", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+func importSource(r *http.Request) (io.ReadCloser, string, error) {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			return nil, "", errors.New("multipart field 'file' is required")
+		}
+		return file, header.Filename, nil
+	}
+	return r.Body, "", nil
+}
+
+// Columns expected in the CSV header (case-insensitive). Milestones are flattened as
+// milestone_<n>_title, milestone_<n>_description, milestone_<n>_amount, milestone_<n>_due_date,
+// milestone_<n>_is_initial_payment with n starting at 1.
+var importRequiredColumns = []string{"project_category", "project_name", "total_amount", "client_name", "client_email", "milestone_1_title", "milestone_1_amount"}
+
+// parseContractCSV maps each data row to a CreateContractRequest and validates it with the same tags as POST /contracts.
+func (h *ImportHandler) parseContractCSV(src io.Reader) ([]dto.ImportRow, error) {
+	reader := csv.NewReader(src)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errEmptyCSV
+		}
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	var missing []string
+	for _, name := range importRequiredColumns {
+		if _, ok := cols[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", errMissingColumns, strings.Join(missing, ", "))
+	}
+	milestoneNums := milestoneColumnNumbers(cols)
+
+	var rows []dto.ImportRow
+	for n := 1; ; n++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if len(rows) >= h.maxRows {
+			return nil, fmt.Errorf("%w (max %d)", errTooManyRows, h.maxRows)
+		}
+		if err != nil {
+			rows = append(rows, dto.ImportRow{Row: n, Errors: []string{err.Error()}})
+			continue
+		}
+		rows = append(rows, h.mapImportRow(n, record, cols, milestoneNums))
+	}
+	if len(rows) == 0 {
+		return nil, errEmptyCSV
+	}
+	return rows, nil
+}
+
+func (h *ImportHandler) mapImportRow(n int, record []string, cols map[string]int, milestoneNums []int) dto.ImportRow {
+	get := func(name string) string {
+		if i, ok := cols[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	var errs []string
+	req := &dto.CreateContractRequest{
+		ProjectCategory:    get("project_category"),
+		ProjectName:        get("project_name"),
+		Description:        get("description"),
+		Currency:           strings.ToUpper(get("currency")),
+		PRDFileURL:         get("prd_file_url"),
+		SubmissionCriteria: get("submission_criteria"),
+		ClientName:         get("client_name"),
+		ClientCompanyName:  get("client_company_name"),
+		ClientEmail:        get("client_email"),
+		ClientPhone:        get("client_phone"),
+		TermsAndConditions: get("terms_and_conditions"),
+	}
+	if v := get("total_amount"); v != "" {
+		amount, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			errs = append(errs, "total_amount: not a number")
+		}
+		req.TotalAmount = amount
+	}
+	if v := get("due_date"); v != "" {
+		t, err := parseImportDate(v)
+		if err != nil {
+			errs = append(errs, "due_date: use YYYY-MM-DD or RFC 3339")
+		}
+		req.DueDate = t
+	}
+	for _, m := range milestoneNums {
+		prefix := "milestone_" + strconv.Itoa(m) + "_"
+		title, amountStr := get(prefix+"title"), get(prefix+"amount")
+		desc, dueStr, initialStr := get(prefix+"description"), get(prefix+"due_date"), get(prefix+"is_initial_payment")
+		if title == "" && amountStr == "" && desc == "" && dueStr == "" {
+			continue // unused milestone columns
+		}
+		in := dto.MilestoneInput{Title: title, Description: desc}
+		if amountStr != "" {
+			amount, err := strconv.ParseFloat(amountStr, 64)
+			if err != nil {
+				errs = append(errs, prefix+"amount: not a number")
+			}
+			in.Amount = amount
+		}
+		if dueStr != "" {
+			t, err := parseImportDate(dueStr)
+			if err != nil {
+				errs = append(errs, prefix+"due_date: use YYYY-MM-DD or RFC 3339")
+			}
+			in.DueDate = t
+		}
+		if initialStr != "" {
+			initial, err := strconv.ParseBool(initialStr)
+			if err != nil {
+				errs = append(errs, prefix+"is_initial_payment: use true or false")
+			}
+			in.IsInitialPayment = initial
+		}
+		req.Milestones = append(req.Milestones, in)
+	}
+	if err := h.validator.ValidateStruct(req); err != nil {
+		errs = append(errs, strings.Split(err.Error(), "; ")...)
+	}
+	return dto.ImportRow{Row: n, Request: req, Errors: errs}
+}
+
+// milestoneColumnNumbers returns the sorted milestone numbers present in the header (milestone_<n>_title).
+func milestoneColumnNumbers(cols map[string]int) []int {
+	var nums []int
+	for name := range cols {
+		if !strings.HasPrefix(name, "milestone_") || !strings.HasSuffix(name, "_title") {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, "milestone_"), "_title"))
+		if err == nil && n > 0 {
+			nums = append(nums, n)
+		}
+	}
+	sort.Ints(nums)
+	return nums
+}
+
+func parseImportDate(s string) (*time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
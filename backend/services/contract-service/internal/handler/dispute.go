@@ -0,0 +1,370 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/middleware"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/service"
+)
+
+// DisputeHandler serves disputes for both parties and the admin resolution queue.
+type DisputeHandler struct {
+	validator *middleware.Validator
+	svc       *service.DisputeService
+}
+
+func NewDisputeHandler(svc *service.DisputeService) *DisputeHandler {
+	return &DisputeHandler{
+		validator: middleware.NewValidator(),
+		svc:       svc,
+	}
+}
+
+func (h *DisputeHandler) RegisterRoutes(r chi.Router, authMw func(http.Handler) http.Handler) {
+	r.With(authMw).Group(func(r chi.Router) {
+		r.Post("/api/v1/contracts/{id}/disputes", h.Open)
+		r.Get("/api/v1/contracts/{id}/disputes", h.List)
+		r.Get("/api/v1/contracts/{id}/disputes/{dispute_id}", h.Get)
+		r.Post("/api/v1/contracts/{id}/disputes/{dispute_id}/messages", h.Post)
+		r.Post("/api/v1/contracts/{id}/disputes/{dispute_id}/escalate", h.Escalate)
+		r.Post("/api/v1/contracts/{id}/disputes/{dispute_id}/withdraw", h.Withdraw)
+	})
+	r.With(authMw, middleware.RequireRole("admin")).Route("/api/v1/admin/disputes", func(r chi.Router) {
+		r.Get("/", h.AdminList)
+		r.Get("/{dispute_id}", h.AdminGet)
+		r.Post("/{dispute_id}/messages", h.AdminPost)
+		r.Post("/{dispute_id}/resolve", h.Resolve)
+	})
+	// Client side via the contract link (no auth)
+	r.Post("/api/v1/public/contracts/{token}/disputes", h.OpenByClient)
+	r.Get("/api/v1/public/contracts/{token}/disputes", h.ListByClient)
+	r.Get("/api/v1/public/contracts/{token}/disputes/{dispute_id}", h.GetByClient)
+	r.Post("/api/v1/public/contracts/{token}/disputes/{dispute_id}/messages", h.PostByClient)
+	r.Post("/api/v1/public/contracts/{token}/disputes/{dispute_id}/escalate", h.EscalateByClient)
+	r.Post("/api/v1/public/contracts/{token}/disputes/{dispute_id}/withdraw", h.WithdrawByClient)
+}
+
+func (h *DisputeHandler) userID(r *http.Request) uint {
+	return r.Context().Value("user_id").(uint)
+}
+
+func (h *DisputeHandler) contractID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *DisputeHandler) disputeID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "dispute_id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid dispute ID", "BAD_REQUEST")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *DisputeHandler) ids(w http.ResponseWriter, r *http.Request) (contractID, disputeID uint, ok bool) {
+	if contractID, ok = h.contractID(w, r); !ok {
+		return 0, 0, false
+	}
+	if disputeID, ok = h.disputeID(w, r); !ok {
+		return 0, 0, false
+	}
+	return contractID, disputeID, true
+}
+
+// Open raises a dispute. Body: { "category": "...", "reason": "...", "milestone_id": 12, "attachment_ids": [3] }.
+func (h *DisputeHandler) Open(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.contractID(w, r)
+	if !ok {
+		return
+	}
+	var req dto.OpenDisputeRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.OpenByFreelancer(r.Context(), id, h.userID(r), &req)
+	if err != nil {
+		respondDisputeError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusCreated, out, "Dispute opened")
+}
+
+func (h *DisputeHandler) List(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.contractID(w, r)
+	if !ok {
+		return
+	}
+	out, err := h.svc.ListByFreelancer(r.Context(), id, h.userID(r))
+	if err != nil {
+		respondDisputeError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+func (h *DisputeHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, disputeID, ok := h.ids(w, r)
+	if !ok {
+		return
+	}
+	out, err := h.svc.GetByFreelancer(r.Context(), id, h.userID(r), disputeID)
+	if err != nil {
+		respondDisputeError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+// Post adds a message. Body: { "body": "...", "attachment_ids": [3] }.
+func (h *DisputeHandler) Post(w http.ResponseWriter, r *http.Request) {
+	id, disputeID, ok := h.ids(w, r)
+	if !ok {
+		return
+	}
+	var req dto.DisputeMessageRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.PostByFreelancer(r.Context(), id, h.userID(r), disputeID, &req)
+	if err != nil {
+		respondDisputeError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusCreated, out, "Message added")
+}
+
+func (h *DisputeHandler) Escalate(w http.ResponseWriter, r *http.Request) {
+	id, disputeID, ok := h.ids(w, r)
+	if !ok {
+		return
+	}
+	req, ok := h.decodeEscalate(w, r)
+	if !ok {
+		return
+	}
+	out, err := h.svc.EscalateByFreelancer(r.Context(), id, h.userID(r), disputeID, req)
+	if err != nil {
+		respondDisputeError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Dispute escalated")
+}
+
+func (h *DisputeHandler) Withdraw(w http.ResponseWriter, r *http.Request) {
+	id, disputeID, ok := h.ids(w, r)
+	if !ok {
+		return
+	}
+	out, err := h.svc.WithdrawByFreelancer(r.Context(), id, h.userID(r), disputeID)
+	if err != nil {
+		respondDisputeError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Dispute withdrawn")
+}
+
+func (h *DisputeHandler) OpenByClient(w http.ResponseWriter, r *http.Request) {
+	var req dto.OpenDisputeRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.OpenByClient(r.Context(), chi.URLParam(r, "token"), &req)
+	if err != nil {
+		respondDisputeError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusCreated, out, "Dispute opened")
+}
+
+func (h *DisputeHandler) ListByClient(w http.ResponseWriter, r *http.Request) {
+	out, err := h.svc.ListByClient(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		respondDisputeError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+func (h *DisputeHandler) GetByClient(w http.ResponseWriter, r *http.Request) {
+	disputeID, ok := h.disputeID(w, r)
+	if !ok {
+		return
+	}
+	out, err := h.svc.GetByClient(r.Context(), chi.URLParam(r, "token"), disputeID)
+	if err != nil {
+		respondDisputeError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+func (h *DisputeHandler) PostByClient(w http.ResponseWriter, r *http.Request) {
+	disputeID, ok := h.disputeID(w, r)
+	if !ok {
+		return
+	}
+	var req dto.DisputeMessageRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.PostByClient(r.Context(), chi.URLParam(r, "token"), disputeID, &req)
+	if err != nil {
+		respondDisputeError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusCreated, out, "Message added")
+}
+
+func (h *DisputeHandler) EscalateByClient(w http.ResponseWriter, r *http.Request) {
+	disputeID, ok := h.disputeID(w, r)
+	if !ok {
+		return
+	}
+	req, ok := h.decodeEscalate(w, r)
+	if !ok {
+		return
+	}
+	out, err := h.svc.EscalateByClient(r.Context(), chi.URLParam(r, "token"), disputeID, req)
+	if err != nil {
+		respondDisputeError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Dispute escalated")
+}
+
+func (h *DisputeHandler) WithdrawByClient(w http.ResponseWriter, r *http.Request) {
+	disputeID, ok := h.disputeID(w, r)
+	if !ok {
+		return
+	}
+	out, err := h.svc.WithdrawByClient(r.Context(), chi.URLParam(r, "token"), disputeID)
+	if err != nil {
+		respondDisputeError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Dispute withdrawn")
+}
+
+// AdminList returns disputes across contracts. Query: ?status=escalated&page=1&limit=20.
+func (h *DisputeHandler) AdminList(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	list, total, err := h.svc.AdminList(r.Context(), r.URL.Query().Get("status"), page, limit)
+	if err != nil {
+		respondDisputeError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, map[string]interface{}{
+		"disputes": list,
+		"total":    total,
+		"page":     page,
+		"limit":    limit,
+	}, "OK")
+}
+
+func (h *DisputeHandler) AdminGet(w http.ResponseWriter, r *http.Request) {
+	disputeID, ok := h.disputeID(w, r)
+	if !ok {
+		return
+	}
+	out, err := h.svc.AdminGet(r.Context(), disputeID)
+	if err != nil {
+		respondDisputeError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+func (h *DisputeHandler) AdminPost(w http.ResponseWriter, r *http.Request) {
+	disputeID, ok := h.disputeID(w, r)
+	if !ok {
+		return
+	}
+	var req dto.DisputeMessageRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.AdminPost(r.Context(), disputeID, h.userID(r), &req)
+	if err != nil {
+		respondDisputeError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusCreated, out, "Message added")
+}
+
+// Resolve records the admin decision. Body: { "resolution": "freelancer" | "client" | "split", "note": "..." }.
+func (h *DisputeHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	disputeID, ok := h.disputeID(w, r)
+	if !ok {
+		return
+	}
+	var req dto.ResolveDisputeRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.Resolve(r.Context(), disputeID, h.userID(r), &req)
+	if err != nil {
+		respondDisputeError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Dispute resolved")
+}
+
+// decodeEscalate reads the optional { "comment": "..." } body.
+func (h *DisputeHandler) decodeEscalate(w http.ResponseWriter, r *http.Request) (*dto.EscalateDisputeRequest, bool) {
+	var req dto.EscalateDisputeRequest
+	if r.ContentLength != 0 {
+		if err := h.validator.ValidateJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return nil, false
+		}
+	}
+	return &req, true
+}
+
+func respondDisputeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrContractNotFound):
+		respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+	case errors.Is(err, repository.ErrDisputeNotFound):
+		respondError(w, http.StatusNotFound, "Dispute not found", "DISPUTE_NOT_FOUND")
+	case errors.Is(err, service.ErrDisputeNotAllowed):
+		respondError(w, http.StatusConflict, err.Error(), "DISPUTE_NOT_ALLOWED")
+	case errors.Is(err, service.ErrDisputeAlreadyOpen):
+		respondError(w, http.StatusConflict, err.Error(), "DISPUTE_ALREADY_OPEN")
+	case errors.Is(err, service.ErrDisputeResolved):
+		respondError(w, http.StatusConflict, err.Error(), "DISPUTE_RESOLVED")
+	case errors.Is(err, service.ErrDisputeAlreadyEscalated):
+		respondError(w, http.StatusConflict, err.Error(), "DISPUTE_ALREADY_ESCALATED")
+	case errors.Is(err, service.ErrNotDisputeOpener):
+		respondError(w, http.StatusForbidden, err.Error(), "NOT_DISPUTE_OPENER")
+	case errors.Is(err, service.ErrInvalidMilestone):
+		respondError(w, http.StatusBadRequest, err.Error(), "INVALID_MILESTONE")
+	case errors.Is(err, service.ErrInvalidDisputeAttachment):
+		respondError(w, http.StatusBadRequest, err.Error(), "INVALID_ATTACHMENT")
+	default:
+		respondError(w, http.StatusInternalServerError, "Failed to process dispute", "INTERNAL_ERROR")
+	}
+}
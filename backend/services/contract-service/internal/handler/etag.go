@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	errInvalidIfMatch  = errors.New(`If-Match must be "*" or a single ETag from a previous response`)
+	errIfMatchRequired = errors.New(`If-Match is required: send the ETag from a previous response, or "*" to overwrite whatever is stored`)
+)
+
+// versionETag is the strong ETag for a record version, e.g. "3".
+func versionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ifMatchVersion returns the version in the If-Match header; 0 for "*" (no precondition). A missing header is
+// errIfMatchRequired when required, so a client that never read the ETag cannot overwrite another tab's changes;
+// otherwise it is 0 too, the unconditional write older clients rely on.
+func ifMatchVersion(r *http.Request, required bool) (int, error) {
+	v := strings.TrimSpace(r.Header.Get("If-Match"))
+	if v == "" {
+		if required {
+			return 0, errIfMatchRequired
+		}
+		return 0, nil
+	}
+	if v == "*" {
+		return 0, nil
+	}
+	// our ETags are strong and quoted; weak ones never match under If-Match
+	if len(v) < 3 || v[0] != '"' || v[len(v)-1] != '"' {
+		return 0, errInvalidIfMatch
+	}
+	n, err := strconv.Atoi(v[1 : len(v)-1])
+	if err != nil || n < 1 {
+		return 0, errInvalidIfMatch
+	}
+	return n, nil
+}
+
+// respondIfMatchError answers a missing If-Match with 428 and a malformed one with 400.
+func respondIfMatchError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errIfMatchRequired) {
+		respondError(w, http.StatusPreconditionRequired, err.Error(), "PRECONDITION_REQUIRED")
+		return
+	}
+	respondError(w, http.StatusBadRequest, err.Error(), "INVALID_IF_MATCH")
+}
+
+// respondPreconditionFailed answers a stale If-Match with 412, the current representation and its ETag.
+func respondPreconditionFailed(w http.ResponseWriter, etag string, current interface{}) {
+	w.Header().Set("ETag", etag)
+	respondJSON(w, http.StatusPreconditionFailed, PreconditionFailedResponse{
+		Error:   http.StatusText(http.StatusPreconditionFailed),
+		Message: "The resource was changed since it was read; retry against the current version",
+		Code:    "VERSION_CONFLICT",
+		Data:    current,
+	})
+}
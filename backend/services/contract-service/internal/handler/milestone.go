@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/middleware"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/service"
+)
+
+// MilestoneHandler serves milestone submission by the freelancer and approval or change requests by the client, and
+// explicit completion of hourly and open-ended retainer contracts.
+type MilestoneHandler struct {
+	validator *middleware.Validator
+	svc       *service.MilestoneService
+}
+
+func NewMilestoneHandler(svc *service.MilestoneService) *MilestoneHandler {
+	return &MilestoneHandler{
+		validator: middleware.NewValidator(),
+		svc:       svc,
+	}
+}
+
+func (h *MilestoneHandler) RegisterRoutes(r chi.Router, authMw func(http.Handler) http.Handler) {
+	r.With(authMw).Post("/api/v1/contracts/{id}/milestones/{milestone_id}/submit", h.Submit)
+	r.With(authMw).Post("/api/v1/contracts/{id}/complete", h.Complete)
+	r.With(authMw, middleware.RequireRole("client")).Group(func(r chi.Router) {
+		r.Post("/api/v1/client/contracts/{id}/milestones/{milestone_id}/approve", h.ApproveByClientAccount)
+		r.Post("/api/v1/client/contracts/{id}/milestones/{milestone_id}/request-changes", h.RequestChangesByClientAccount)
+	})
+	// Client approval via the contract link (no auth)
+	r.Post("/api/v1/public/contracts/{token}/milestones/{milestone_id}/approve", h.ApproveByClientToken)
+	r.Post("/api/v1/public/contracts/{token}/milestones/{milestone_id}/request-changes", h.RequestChangesByClientToken)
+}
+
+func (h *MilestoneHandler) ids(w http.ResponseWriter, r *http.Request, withContract bool) (contractID, milestoneID uint, ok bool) {
+	if withContract {
+		id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+			return 0, 0, false
+		}
+		contractID = uint(id)
+	}
+	mid, err := strconv.ParseUint(chi.URLParam(r, "milestone_id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid milestone ID", "BAD_REQUEST")
+		return 0, 0, false
+	}
+	return contractID, uint(mid), true
+}
+
+// Submit marks a milestone as delivered. Optional body { "note": "..." }; upload deliverables as attachments first.
+func (h *MilestoneHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	id, milestoneID, ok := h.ids(w, r, true)
+	if !ok {
+		return
+	}
+	var req dto.SubmitMilestoneRequest
+	if r.ContentLength != 0 {
+		if err := h.validator.ValidateJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+			return
+		}
+	}
+	userID := r.Context().Value("user_id").(uint)
+	out, err := h.svc.Submit(r.Context(), id, userID, milestoneID, &req)
+	if err != nil {
+		respondMilestoneError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Milestone submitted")
+}
+
+// Complete ends an hourly or open-ended retainer contract; nothing may be left for the client to approve.
+func (h *MilestoneHandler) Complete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	userID := r.Context().Value("user_id").(uint)
+	out, err := h.svc.Complete(r.Context(), uint(id), userID)
+	if err != nil {
+		respondMilestoneError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Contract completed")
+}
+
+func (h *MilestoneHandler) ApproveByClientToken(w http.ResponseWriter, r *http.Request) {
+	_, milestoneID, ok := h.ids(w, r, false)
+	if !ok {
+		return
+	}
+	out, err := h.svc.ApproveByClientToken(r.Context(), chi.URLParam(r, "token"), milestoneID)
+	if err != nil {
+		respondMilestoneError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, approvalMessage(out))
+}
+
+func (h *MilestoneHandler) ApproveByClientAccount(w http.ResponseWriter, r *http.Request) {
+	id, milestoneID, ok := h.ids(w, r, true)
+	if !ok {
+		return
+	}
+	userID := r.Context().Value("user_id").(uint)
+	out, err := h.svc.ApproveByClientAccount(r.Context(), id, userID, milestoneID)
+	if err != nil {
+		respondMilestoneError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, approvalMessage(out))
+}
+
+// RequestChangesByClientToken sends a submitted milestone back for rework. Body: { "comment": "..." }.
+func (h *MilestoneHandler) RequestChangesByClientToken(w http.ResponseWriter, r *http.Request) {
+	_, milestoneID, ok := h.ids(w, r, false)
+	if !ok {
+		return
+	}
+	var req dto.RequestMilestoneChangesRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.RequestChangesByClientToken(r.Context(), chi.URLParam(r, "token"), milestoneID, &req)
+	if err != nil {
+		respondMilestoneError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Changes requested")
+}
+
+func (h *MilestoneHandler) RequestChangesByClientAccount(w http.ResponseWriter, r *http.Request) {
+	id, milestoneID, ok := h.ids(w, r, true)
+	if !ok {
+		return
+	}
+	var req dto.RequestMilestoneChangesRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	userID := r.Context().Value("user_id").(uint)
+	out, err := h.svc.RequestChangesByClientAccount(r.Context(), id, userID, milestoneID, &req)
+	if err != nil {
+		respondMilestoneError(w, err)
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Changes requested")
+}
+
+func approvalMessage(out *dto.MilestoneApprovalResponse) string {
+	if out.CompletedAt != nil {
+		return "Milestone approved; contract completed"
+	}
+	return "Milestone approved"
+}
+
+func respondMilestoneError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrContractNotFound):
+		respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+	case errors.Is(err, repository.ErrMilestoneNotFound):
+		respondError(w, http.StatusNotFound, "Milestone not found", "MILESTONE_NOT_FOUND")
+	case errors.Is(err, service.ErrMilestoneNotActive):
+		respondError(w, http.StatusConflict, err.Error(), "CONTRACT_NOT_ACTIVE")
+	case errors.Is(err, service.ErrMilestoneNotPending):
+		respondError(w, http.StatusConflict, err.Error(), "MILESTONE_ALREADY_SUBMITTED")
+	case errors.Is(err, service.ErrMilestonesFrozen):
+		respondError(w, http.StatusConflict, err.Error(), "MILESTONES_FROZEN")
+	case errors.Is(err, service.ErrMilestoneNotSubmitted):
+		respondError(w, http.StatusConflict, err.Error(), "MILESTONE_NOT_SUBMITTED")
+	case errors.Is(err, service.ErrCompletesOnApproval):
+		respondError(w, http.StatusConflict, err.Error(), "COMPLETES_ON_APPROVAL")
+	case errors.Is(err, service.ErrNotCompletable):
+		respondError(w, http.StatusConflict, err.Error(), "CONTRACT_NOT_COMPLETABLE")
+	default:
+		respondError(w, http.StatusInternalServerError, "Failed to update milestone", "INTERNAL_ERROR")
+	}
+}
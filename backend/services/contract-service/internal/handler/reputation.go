@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/middleware"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/service"
+)
+
+// ReputationHandler serves the freelancer's reputation scores and the admin recompute.
+type ReputationHandler struct {
+	svc *service.ReputationService
+}
+
+func NewReputationHandler(svc *service.ReputationService) *ReputationHandler {
+	return &ReputationHandler{svc: svc}
+}
+
+func (h *ReputationHandler) RegisterRoutes(r chi.Router, authMw func(http.Handler) http.Handler) {
+	r.With(authMw).Group(func(r chi.Router) {
+		r.Get("/api/v1/reputation", h.Summary)
+		r.Get("/api/v1/contracts/{id}/reputation", h.GetContractScore)
+	})
+	r.With(authMw, middleware.RequireRole("admin")).Post("/api/v1/admin/reputation/recompute", h.Recompute)
+}
+
+// Summary returns the freelancer's aggregate score and per-contract scores.
+func (h *ReputationHandler) Summary(w http.ResponseWriter, r *http.Request) {
+	out, err := h.svc.Summary(r.Context(), r.Context().Value("user_id").(uint))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get reputation", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+// GetContractScore explains one contract's score: components, weights and the stored inputs.
+func (h *ReputationHandler) GetContractScore(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	out, err := h.svc.GetContractScore(r.Context(), uint(id), r.Context().Value("user_id").(uint))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrContractNotFound):
+			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+		case errors.Is(err, repository.ErrReputationNotFound):
+			respondError(w, http.StatusNotFound, "Contract has not been scored yet", "REPUTATION_NOT_FOUND")
+		default:
+			respondError(w, http.StatusInternalServerError, "Failed to get reputation", "INTERNAL_ERROR")
+		}
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+// Recompute scores contracts missing a current-formula score now instead of waiting for the job.
+func (h *ReputationHandler) Recompute(w http.ResponseWriter, r *http.Request) {
+	n, err := h.svc.Refresh(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to recompute reputation", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, map[string]int64{"contracts_scored": n}, "OK")
+}
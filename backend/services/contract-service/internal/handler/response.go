@@ -11,6 +11,14 @@ type ErrorResponse struct {
 	Code    string `json:"code,omitempty"`
 }
 
+// PreconditionFailedResponse is a 412 error carrying the current representation, so the client can merge and retry.
+type PreconditionFailedResponse struct {
+	Error   string      `json:"error"`
+	Message string      `json:"message,omitempty"`
+	Code    string      `json:"code,omitempty"`
+	Data    interface{} `json:"data"`
+}
+
 type SuccessResponse struct {
 	Data    interface{} `json:"data"`
 	Message string      `json:"message,omitempty"`
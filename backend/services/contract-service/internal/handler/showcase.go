@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/middleware"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/service"
+)
+
+// ShowcaseHandler serves the freelancer's per-contract profile visibility and, for user-service, the redacted
+// contract summaries shown on public profiles.
+type ShowcaseHandler struct {
+	validator *middleware.Validator
+	svc       *service.ShowcaseService
+}
+
+func NewShowcaseHandler(svc *service.ShowcaseService) *ShowcaseHandler {
+	return &ShowcaseHandler{
+		validator: middleware.NewValidator(),
+		svc:       svc,
+	}
+}
+
+func (h *ShowcaseHandler) RegisterRoutes(r chi.Router, authMw func(http.Handler) http.Handler) {
+	r.With(authMw).Put("/api/v1/contracts/{id}/profile-visibility", h.SetProfileVisibility)
+}
+
+// RegisterInternalRoutes registers the service-to-service routes, guarded by the shared X-Internal-Token.
+func (h *ShowcaseHandler) RegisterInternalRoutes(r chi.Router, internalToken string) {
+	r.With(middleware.RequireInternalToken(internalToken)).Get("/internal/v1/freelancers/{user_id}/contract-summaries", h.ListForProfile)
+}
+
+// SetProfileVisibility sets how the contract appears on the public profile. Body: { "visibility": "hidden" | "anonymized" | "full" }.
+func (h *ShowcaseHandler) SetProfileVisibility(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return
+	}
+	var req dto.SetProfileVisibilityRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	if err := h.svc.SetProfileVisibility(r.Context(), uint(id), r.Context().Value("user_id").(uint), req.Visibility); err != nil {
+		if errors.Is(err, repository.ErrContractNotFound) {
+			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to update profile visibility", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, map[string]interface{}{"contract_id": uint(id), "profile_visibility": req.Visibility}, "OK")
+}
+
+// ListForProfile returns the freelancer's showcased completed contracts (user-service, public profile).
+func (h *ShowcaseHandler) ListForProfile(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseUint(chi.URLParam(r, "user_id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID", "BAD_REQUEST")
+		return
+	}
+	out, err := h.svc.ListForProfile(r.Context(), uint(userID))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list contracts", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, map[string]interface{}{"contracts": out}, "OK")
+}
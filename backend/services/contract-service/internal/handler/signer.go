@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/middleware"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/service"
+)
+
+// SignerHandler serves the public routes of additional signers; each signer has their own token.
+type SignerHandler struct {
+	validator *middleware.Validator
+	svc       *service.ContractService
+}
+
+func NewSignerHandler(svc *service.ContractService) *SignerHandler {
+	return &SignerHandler{
+		validator: middleware.NewValidator(),
+		svc:       svc,
+	}
+}
+
+func (h *SignerHandler) RegisterRoutes(r chi.Router, idempotencyMw func(http.Handler) http.Handler) {
+	r.Route("/api/v1/public/signers", func(r chi.Router) {
+		r.Get("/{token}", h.Get)
+		r.Post("/{token}/sign/request-code", h.RequestSignCode)
+		r.With(idempotencyMw).Post("/{token}/sign", h.Sign)
+	})
+}
+
+// Get returns the contract, this signer's status and whether they can sign now (no auth).
+func (h *SignerHandler) Get(w http.ResponseWriter, r *http.Request) {
+	out, err := h.svc.GetBySignerToken(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		if errors.Is(err, repository.ErrContractNotFound) {
+			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to get contract", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+// RequestSignCode emails a one-time sign code to this signer's email (no auth, no body).
+func (h *SignerHandler) RequestSignCode(w http.ResponseWriter, r *http.Request) {
+	out, err := h.svc.RequestSignerSignCode(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		if errors.Is(err, repository.ErrContractNotFound) {
+			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+			return
+		}
+		if errors.Is(err, service.ErrSignerAlreadySigned) {
+			respondError(w, http.StatusConflict, err.Error(), "ALREADY_SIGNED")
+			return
+		}
+		if errors.Is(err, service.ErrNotSignersTurn) {
+			respondError(w, http.StatusConflict, err.Error(), "NOT_SIGNERS_TURN")
+			return
+		}
+		if errors.Is(err, service.ErrSignCodeTooSoon) {
+			respondError(w, http.StatusTooManyRequests, err.Error(), "SIGN_CODE_TOO_SOON")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to send sign code", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Sign code sent")
+}
+
+// Sign records this signer's signature (no auth). Body: { "full_name": "...", "designation": "...", "otp_code": "..." }.
+func (h *SignerHandler) Sign(w http.ResponseWriter, r *http.Request) {
+	var req dto.SignerSignRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.SignAsSigner(r.Context(), chi.URLParam(r, "token"), &req, clientIP(r), r.UserAgent())
+	if err != nil {
+		if errors.Is(err, repository.ErrContractNotFound) {
+			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+			return
+		}
+		if errors.Is(err, service.ErrSignerAlreadySigned) {
+			respondError(w, http.StatusConflict, err.Error(), "ALREADY_SIGNED")
+			return
+		}
+		if errors.Is(err, service.ErrNotSignersTurn) {
+			respondError(w, http.StatusConflict, err.Error(), "NOT_SIGNERS_TURN")
+			return
+		}
+		if respondSignCodeError(w, err) {
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to sign contract", "INTERNAL_ERROR")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Contract signed")
+}
+
+// clientIP returns the request's remote IP without the port (RemoteAddr is already the real IP behind RealIP middleware).
+func clientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
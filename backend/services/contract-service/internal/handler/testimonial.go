@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/middleware"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/service"
+)
+
+// TestimonialHandler serves the client's testimonial on a completed contract (contract link, no auth).
+type TestimonialHandler struct {
+	validator *middleware.Validator
+	svc       *service.TestimonialService
+}
+
+func NewTestimonialHandler(svc *service.TestimonialService) *TestimonialHandler {
+	return &TestimonialHandler{
+		validator: middleware.NewValidator(),
+		svc:       svc,
+	}
+}
+
+func (h *TestimonialHandler) RegisterRoutes(r chi.Router) {
+	r.Post("/api/v1/public/contracts/{token}/testimonial", h.SubmitByClientToken)
+}
+
+// SubmitByClientToken stores the client's rating and comment. Body: { "rating": 1-10, "comment": "..." }.
+func (h *TestimonialHandler) SubmitByClientToken(w http.ResponseWriter, r *http.Request) {
+	var req dto.SubmitTestimonialRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.SubmitByClientToken(r.Context(), chi.URLParam(r, "token"), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrContractNotFound):
+			respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+		case errors.Is(err, service.ErrContractNotCompleted):
+			respondError(w, http.StatusConflict, err.Error(), "CONTRACT_NOT_COMPLETED")
+		case errors.Is(err, service.ErrTestimonialExists):
+			respondError(w, http.StatusConflict, err.Error(), "TESTIMONIAL_EXISTS")
+		default:
+			respondError(w, http.StatusInternalServerError, "Failed to save testimonial", "INTERNAL_ERROR")
+		}
+		return
+	}
+	respondSuccess(w, http.StatusCreated, out, "Thank you for your feedback")
+}
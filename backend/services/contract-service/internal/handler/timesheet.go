@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/middleware"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/service"
+)
+
+// TimesheetHandler serves timesheets of hourly contracts: the freelancer logs time, the client reviews it via the contract link.
+type TimesheetHandler struct {
+	validator *middleware.Validator
+	svc       *service.TimesheetService
+}
+
+func NewTimesheetHandler(svc *service.TimesheetService) *TimesheetHandler {
+	return &TimesheetHandler{
+		validator: middleware.NewValidator(),
+		svc:       svc,
+	}
+}
+
+func (h *TimesheetHandler) RegisterRoutes(r chi.Router, authMw func(http.Handler) http.Handler) {
+	r.With(authMw).Group(func(r chi.Router) {
+		r.Get("/api/v1/contracts/{id}/timesheet", h.List)
+		r.Post("/api/v1/contracts/{id}/timesheet", h.Log)
+		r.Delete("/api/v1/contracts/{id}/timesheet/{entry_id}", h.Delete)
+	})
+	// Client review via the contract link (no auth)
+	r.Get("/api/v1/public/contracts/{token}/timesheet", h.ListByClientToken)
+	r.Post("/api/v1/public/contracts/{token}/timesheet/{entry_id}/approve", h.ApproveByClientToken)
+	r.Post("/api/v1/public/contracts/{token}/timesheet/{entry_id}/reject", h.RejectByClientToken)
+}
+
+func (h *TimesheetHandler) contractID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid contract ID", "BAD_REQUEST")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *TimesheetHandler) entryID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "entry_id"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid timesheet entry ID", "BAD_REQUEST")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *TimesheetHandler) List(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.contractID(w, r)
+	if !ok {
+		return
+	}
+	userID := r.Context().Value("user_id").(uint)
+	out, err := h.svc.ListForFreelancer(r.Context(), id, userID)
+	if err != nil {
+		respondTimesheetError(w, err, "Failed to get timesheet")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+// Log adds time. Body: { "work_date": "2026-10-05", "hours": 2.5, "description": "..." }.
+func (h *TimesheetHandler) Log(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.contractID(w, r)
+	if !ok {
+		return
+	}
+	var req dto.LogTimesheetRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	userID := r.Context().Value("user_id").(uint)
+	out, err := h.svc.Log(r.Context(), id, userID, &req)
+	if err != nil {
+		respondTimesheetError(w, err, "Failed to log time")
+		return
+	}
+	respondSuccess(w, http.StatusCreated, out, "Time logged; sent to the client for approval")
+}
+
+func (h *TimesheetHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.contractID(w, r)
+	if !ok {
+		return
+	}
+	entryID, ok := h.entryID(w, r)
+	if !ok {
+		return
+	}
+	userID := r.Context().Value("user_id").(uint)
+	if err := h.svc.Delete(r.Context(), id, userID, entryID); err != nil {
+		respondTimesheetError(w, err, "Failed to delete timesheet entry")
+		return
+	}
+	respondSuccess(w, http.StatusOK, nil, "Timesheet entry deleted")
+}
+
+func (h *TimesheetHandler) ListByClientToken(w http.ResponseWriter, r *http.Request) {
+	out, err := h.svc.ListByClientToken(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		respondTimesheetError(w, err, "Failed to get timesheet")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "OK")
+}
+
+func (h *TimesheetHandler) ApproveByClientToken(w http.ResponseWriter, r *http.Request) {
+	entryID, ok := h.entryID(w, r)
+	if !ok {
+		return
+	}
+	out, err := h.svc.ApproveByClientToken(r.Context(), chi.URLParam(r, "token"), entryID)
+	if err != nil {
+		respondTimesheetError(w, err, "Failed to approve timesheet entry")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Timesheet entry approved")
+}
+
+// RejectByClientToken rejects an entry. Body: { "comment": "..." }.
+func (h *TimesheetHandler) RejectByClientToken(w http.ResponseWriter, r *http.Request) {
+	entryID, ok := h.entryID(w, r)
+	if !ok {
+		return
+	}
+	var req dto.RejectTimesheetEntryRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+	out, err := h.svc.RejectByClientToken(r.Context(), chi.URLParam(r, "token"), entryID, &req)
+	if err != nil {
+		respondTimesheetError(w, err, "Failed to reject timesheet entry")
+		return
+	}
+	respondSuccess(w, http.StatusOK, out, "Timesheet entry rejected")
+}
+
+func respondTimesheetError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrContractNotFound):
+		respondError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+	case errors.Is(err, repository.ErrTimesheetEntryNotFound):
+		respondError(w, http.StatusNotFound, "Timesheet entry not found", "NOT_FOUND")
+	case errors.Is(err, service.ErrNotHourly):
+		respondError(w, http.StatusConflict, err.Error(), "NOT_HOURLY")
+	case errors.Is(err, service.ErrTimesheetNotActive):
+		respondError(w, http.StatusConflict, err.Error(), "CONTRACT_NOT_ACTIVE")
+	case errors.Is(err, service.ErrMilestonesFrozen):
+		respondError(w, http.StatusConflict, err.Error(), "MILESTONES_FROZEN")
+	case errors.Is(err, service.ErrTimesheetEntryClosed):
+		respondError(w, http.StatusConflict, err.Error(), "TIMESHEET_ENTRY_CLOSED")
+	case errors.Is(err, repository.ErrWeeklyCapExceeded):
+		respondError(w, http.StatusConflict, err.Error(), "WEEKLY_CAP_EXCEEDED")
+	case errors.Is(err, service.ErrFutureWorkDate):
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+	default:
+		respondError(w, http.StatusInternalServerError, fallback, "INTERNAL_ERROR")
+	}
+}
@@ -0,0 +1,106 @@
+// Package ical writes RFC 5545 calendars: VEVENTs with stable UIDs, escaped text and folded lines.
+package ical
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentType is the media type of a calendar body.
+const ContentType = "text/calendar; charset=utf-8"
+
+// maxLineOctets is the longest content line before folding (RFC 5545 section 3.1).
+const maxLineOctets = 75
+
+// Event is one VEVENT. An AllDay event covers the date of Start (in UTC); otherwise it is a point in time.
+type Event struct {
+	UID          string // stable across exports, so calendar apps update the event instead of adding another
+	Sequence     int    // revision; raise it when the event changes
+	Summary      string
+	Description  string
+	Start        time.Time
+	AllDay       bool
+	Cancelled    bool
+	LastModified time.Time
+}
+
+// Calendar is a VCALENDAR with a product id and display name.
+type Calendar struct {
+	ProdID string // e.g. -//Defellix//Contracts//EN
+	Name   string // X-WR-CALNAME, shown by subscribing apps
+	Events []Event
+}
+
+// Bytes renders the calendar with CRLF line endings. stamp is the DTSTAMP of every event (the time of export).
+func (c *Calendar) Bytes(stamp time.Time) []byte {
+	var b bytes.Buffer
+	w := func(name, value string) { writeLine(&b, name+":"+value) }
+	w("BEGIN", "VCALENDAR")
+	w("VERSION", "2.0")
+	w("PRODID", c.ProdID)
+	w("CALSCALE", "GREGORIAN")
+	w("METHOD", "PUBLISH")
+	if c.Name != "" {
+		w("X-WR-CALNAME", escapeText(c.Name))
+	}
+	for _, e := range c.Events {
+		w("BEGIN", "VEVENT")
+		w("UID", e.UID)
+		w("DTSTAMP", utcDateTime(stamp))
+		if e.AllDay {
+			day := e.Start.UTC()
+			w("DTSTART;VALUE=DATE", day.Format("20060102"))
+			w("DTEND;VALUE=DATE", day.AddDate(0, 0, 1).Format("20060102"))
+		} else {
+			w("DTSTART", utcDateTime(e.Start))
+		}
+		w("SEQUENCE", strconv.Itoa(e.Sequence))
+		w("SUMMARY", escapeText(e.Summary))
+		if e.Description != "" {
+			w("DESCRIPTION", escapeText(e.Description))
+		}
+		if !e.LastModified.IsZero() {
+			w("LAST-MODIFIED", utcDateTime(e.LastModified))
+		}
+		w("TRANSP", "TRANSPARENT") // deadlines do not block time
+		if e.Cancelled {
+			w("STATUS", "CANCELLED")
+		}
+		w("END", "VEVENT")
+	}
+	w("END", "VCALENDAR")
+	return b.Bytes()
+}
+
+func utcDateTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escapeText escapes a TEXT value: backslash, semicolon, comma and newlines.
+func escapeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+// writeLine writes one content line, folded at 75 octets without splitting a UTF-8 sequence.
+func writeLine(b *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !startsRune(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1 // continuation lines start with a space
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func startsRune(c byte) bool {
+	return c&0xC0 != 0x80
+}
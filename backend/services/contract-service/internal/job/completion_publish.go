@@ -0,0 +1,43 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// CompletionPublishRunner retries contract completion events that did not reach user-service. Start in a goroutine from main.
+type CompletionPublishRunner struct {
+	run      func(ctx context.Context) (int64, error)
+	interval time.Duration
+}
+
+// NewCompletionPublishRunner builds a runner that calls publishPending every interval.
+// publishPending is typically (*service.MilestoneService).PublishPendingCompletions.
+func NewCompletionPublishRunner(publishPending func(context.Context) (int64, error), interval time.Duration) *CompletionPublishRunner {
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+	return &CompletionPublishRunner{run: publishPending, interval: interval}
+}
+
+// Start blocks and runs the job every interval until ctx is cancelled. Call in a goroutine.
+func (r *CompletionPublishRunner) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := r.run(ctx)
+			if err != nil {
+				log.Printf("[completion-publish] error: %v", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("[completion-publish] published %d completion(s)", n)
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ContractEventCleanupRunner deletes contract events past their retention periodically. Start in a goroutine from main.
+type ContractEventCleanupRunner struct {
+	run      func(ctx context.Context) (int64, error)
+	interval time.Duration
+}
+
+// NewContractEventCleanupRunner builds a runner that calls deleteExpired every interval.
+// deleteExpired is typically service.ContractEventService.DeleteExpired.
+func NewContractEventCleanupRunner(deleteExpired func(context.Context) (int64, error), interval time.Duration) *ContractEventCleanupRunner {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &ContractEventCleanupRunner{run: deleteExpired, interval: interval}
+}
+
+// Start blocks and runs the job every interval until ctx is cancelled. Call in a goroutine.
+func (r *ContractEventCleanupRunner) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := r.run(ctx)
+			if err != nil {
+				log.Printf("[contract-event-cleanup] error: %v", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("[contract-event-cleanup] deleted %d expired contract event(s)", n)
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// DisputeDeadlineRunner escalates disputes whose response or settlement deadline passed. Start in a goroutine from main.
+type DisputeDeadlineRunner struct {
+	run      func(ctx context.Context) (int64, error)
+	interval time.Duration
+}
+
+// NewDisputeDeadlineRunner builds a runner that calls escalateOverdue every interval.
+// escalateOverdue is typically (*service.DisputeService).EscalateOverdue.
+func NewDisputeDeadlineRunner(escalateOverdue func(context.Context) (int64, error), interval time.Duration) *DisputeDeadlineRunner {
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+	return &DisputeDeadlineRunner{run: escalateOverdue, interval: interval}
+}
+
+// Start blocks and runs the job every interval until ctx is cancelled. Call in a goroutine.
+func (r *DisputeDeadlineRunner) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := r.run(ctx)
+			if err != nil {
+				log.Printf("[dispute-deadlines] error: %v", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("[dispute-deadlines] escalated %d overdue dispute(s)", n)
+			}
+		}
+	}
+}
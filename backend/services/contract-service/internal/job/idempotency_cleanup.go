@@ -0,0 +1,43 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// IdempotencyCleanupRunner deletes expired Idempotency-Key responses periodically. Start in a goroutine from main.
+type IdempotencyCleanupRunner struct {
+	run      func(ctx context.Context) (int64, error)
+	interval time.Duration
+}
+
+// NewIdempotencyCleanupRunner builds a runner that calls deleteExpired every interval.
+// deleteExpired typically wraps repository.IdempotencyRepository.DeleteExpired with time.Now().
+func NewIdempotencyCleanupRunner(deleteExpired func(context.Context) (int64, error), interval time.Duration) *IdempotencyCleanupRunner {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &IdempotencyCleanupRunner{run: deleteExpired, interval: interval}
+}
+
+// Start blocks and runs the job every interval until ctx is cancelled. Call in a goroutine.
+func (r *IdempotencyCleanupRunner) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := r.run(ctx)
+			if err != nil {
+				log.Printf("[idempotency-cleanup] error: %v", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("[idempotency-cleanup] deleted %d expired idempotency key(s)", n)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ReputationRunner scores completed contracts that lack a current-formula score (new completions, or all of them
+// after a formula version bump) and re-sends unpublished aggregates. Start in a goroutine from main.
+type ReputationRunner struct {
+	run      func(ctx context.Context) (int64, error)
+	interval time.Duration
+}
+
+// NewReputationRunner builds a runner that calls refresh every interval.
+// refresh is typically (*service.ReputationService).Refresh.
+func NewReputationRunner(refresh func(context.Context) (int64, error), interval time.Duration) *ReputationRunner {
+	if interval <= 0 {
+		interval = 30 * time.Minute
+	}
+	return &ReputationRunner{run: refresh, interval: interval}
+}
+
+// Start blocks and runs the job every interval until ctx is cancelled. Call in a goroutine.
+func (r *ReputationRunner) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := r.run(ctx)
+			if err != nil {
+				log.Printf("[reputation] error: %v", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("[reputation] scored %d contract(s)", n)
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RetainerCycleRunner generates the period milestones of retainer contracts periodically. Start in a goroutine from main.
+type RetainerCycleRunner struct {
+	run      func(ctx context.Context) (int64, error)
+	interval time.Duration
+}
+
+// NewRetainerCycleRunner builds a runner that calls generate every interval.
+// generate is typically service.MilestoneService.GenerateRetainerCycles.
+func NewRetainerCycleRunner(generate func(context.Context) (int64, error), interval time.Duration) *RetainerCycleRunner {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &RetainerCycleRunner{run: generate, interval: interval}
+}
+
+// Start blocks and runs the job every interval until ctx is cancelled. Call in a goroutine.
+func (r *RetainerCycleRunner) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := r.run(ctx)
+			if err != nil {
+				log.Printf("[retainer-cycles] error: %v", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("[retainer-cycles] generated %d retainer period(s)", n)
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// TestimonialPublishRunner retries client testimonials that did not reach user-service. Start in a goroutine from main.
+type TestimonialPublishRunner struct {
+	run      func(ctx context.Context) (int64, error)
+	interval time.Duration
+}
+
+// NewTestimonialPublishRunner builds a runner that calls publishPending every interval.
+// publishPending is typically (*service.TestimonialService).PublishPending.
+func NewTestimonialPublishRunner(publishPending func(context.Context) (int64, error), interval time.Duration) *TestimonialPublishRunner {
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+	return &TestimonialPublishRunner{run: publishPending, interval: interval}
+}
+
+// Start blocks and runs the job every interval until ctx is cancelled. Call in a goroutine.
+func (r *TestimonialPublishRunner) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := r.run(ctx)
+			if err != nil {
+				log.Printf("[testimonial-publish] error: %v", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("[testimonial-publish] published %d testimonial(s)", n)
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package linktoken
+
+import "fmt"
+
+// Scope is a set of actions a client link allows.
+type Scope uint8
+
+const (
+	ScopeView   Scope = 1 << iota // read the contract, attachments, threads
+	ScopeReview                   // send for review, decline
+	ScopeSign                     // sign and act on the signed contract: milestones, amendments, disputes, cancellation, testimonial
+	ScopeAll    = ScopeView | ScopeReview | ScopeSign
+)
+
+var scopeNames = []struct {
+	scope Scope
+	name  string
+}{
+	{ScopeView, "view"},
+	{ScopeReview, "review"},
+	{ScopeSign, "sign"},
+}
+
+// Has reports whether s includes every scope in want.
+func (s Scope) Has(want Scope) bool {
+	return s&want == want
+}
+
+// Names lists the scopes in s, e.g. ["view", "sign"].
+func (s Scope) Names() []string {
+	var out []string
+	for _, n := range scopeNames {
+		if s.Has(n.scope) {
+			out = append(out, n.name)
+		}
+	}
+	return out
+}
+
+// ParseScopes reads scope names; view is implied by any other scope, since acting on a contract needs reading it.
+func ParseScopes(names []string) (Scope, error) {
+	var s Scope
+	for _, name := range names {
+		found := false
+		for _, n := range scopeNames {
+			if n.name == name {
+				s |= n.scope
+				found = true
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("unknown link scope %q", name)
+		}
+	}
+	if s != 0 {
+		s |= ScopeView
+	}
+	return s, nil
+}
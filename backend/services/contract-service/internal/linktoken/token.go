@@ -0,0 +1,187 @@
+package linktoken
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	version = 1
+	macLen  = 16 // truncated HMAC-SHA256; 128 bits is plenty for an online check
+	// minKeyLen is the shortest accepted signing secret
+	minKeyLen = 32
+)
+
+var (
+	ErrMalformed  = errors.New("malformed link token")
+	ErrSignature  = errors.New("invalid link token signature")
+	ErrUnknownKey = errors.New("link token signed with an unknown key")
+	ErrExpired    = errors.New("link token expired")
+)
+
+var b64 = base64.RawURLEncoding
+
+// Claims is what a client link token carries. Nonce is the contract's client_view_token, so a token only resolves
+// while the contract still has that nonce.
+type Claims struct {
+	KeyID      string
+	ContractID uint
+	Nonce      string
+	Scopes     Scope
+	ExpiresAt  time.Time
+}
+
+// Keyset signs with the current key and verifies with any listed key, so keys can be rotated: add the new key
+// first, keep the old ones until links signed with them have expired, then drop them.
+type Keyset struct {
+	current string
+	keys    map[string][]byte
+}
+
+// ParseKeyset reads "kid:secret,kid:secret"; the first entry signs new tokens. Secrets must be at least 32 bytes.
+// An empty spec returns nil (signed links disabled).
+func ParseKeyset(spec string) (*Keyset, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+	ks := &Keyset{keys: make(map[string][]byte)}
+	for _, entry := range strings.Split(spec, ",") {
+		kid, secret, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || kid == "" || len(kid) > 16 {
+			return nil, fmt.Errorf("link key %q: want kid:secret with a kid of 1-16 characters", entry)
+		}
+		if len(secret) < minKeyLen {
+			return nil, fmt.Errorf("link key %q: secret must be at least %d bytes", kid, minKeyLen)
+		}
+		if _, dup := ks.keys[kid]; dup {
+			return nil, fmt.Errorf("link key %q listed twice", kid)
+		}
+		ks.keys[kid] = []byte(secret)
+		if ks.current == "" {
+			ks.current = kid
+		}
+	}
+	return ks, nil
+}
+
+// Issue returns a signed token for c with the current key. KeyID is ignored and set on return.
+func (k *Keyset) Issue(c Claims) (string, error) {
+	nonce, err := uuid.Parse(c.Nonce)
+	if err != nil {
+		return "", fmt.Errorf("link nonce: %w", err)
+	}
+	var buf bytes.Buffer
+	buf.WriteByte(version)
+	buf.WriteByte(byte(len(k.current)))
+	buf.WriteString(k.current)
+	buf.Write(binary.AppendUvarint(nil, uint64(c.ContractID)))
+	buf.WriteByte(byte(c.Scopes))
+	buf.Write(binary.AppendUvarint(nil, uint64(c.ExpiresAt.Unix())))
+	buf.Write(nonce[:])
+	payload := buf.Bytes()
+	return b64.EncodeToString(payload) + "." + b64.EncodeToString(sign(k.keys[k.current], payload)), nil
+}
+
+// Verify checks the signature and expiry and returns the claims.
+func (k *Keyset) Verify(token string, now time.Time) (*Claims, error) {
+	payload, mac, err := split(token)
+	if err != nil {
+		return nil, err
+	}
+	c, err := decode(payload)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := k.keys[c.KeyID]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	if !hmac.Equal(mac, sign(key, payload)) {
+		return nil, ErrSignature
+	}
+	if !now.Before(c.ExpiresAt) {
+		return nil, ErrExpired
+	}
+	return c, nil
+}
+
+// Parse decodes a token without verifying it; ok is false for anything that is not a signed token (e.g. a bare
+// nonce from an older link). Use only to locate the contract after Verify ran, or where the nonce match suffices.
+func Parse(token string) (*Claims, bool) {
+	payload, _, err := split(token)
+	if err != nil {
+		return nil, false
+	}
+	c, err := decode(payload)
+	if err != nil {
+		return nil, false
+	}
+	return c, true
+}
+
+func sign(key, payload []byte) []byte {
+	m := hmac.New(sha256.New, key)
+	m.Write(payload)
+	return m.Sum(nil)[:macLen]
+}
+
+func split(token string) (payload, mac []byte, err error) {
+	p, s, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, nil, ErrMalformed
+	}
+	if payload, err = b64.DecodeString(p); err != nil {
+		return nil, nil, ErrMalformed
+	}
+	if mac, err = b64.DecodeString(s); err != nil || len(mac) != macLen {
+		return nil, nil, ErrMalformed
+	}
+	return payload, mac, nil
+}
+
+func decode(payload []byte) (*Claims, error) {
+	r := bytes.NewReader(payload)
+	if v, err := r.ReadByte(); err != nil || v != version {
+		return nil, ErrMalformed
+	}
+	n, err := r.ReadByte()
+	if err != nil || n == 0 || int(n) > r.Len() {
+		return nil, ErrMalformed
+	}
+	kid := make([]byte, n)
+	_, _ = r.Read(kid)
+	id, err := binary.ReadUvarint(r)
+	if err != nil || id == 0 || id > uint64(^uint32(0)) {
+		return nil, ErrMalformed
+	}
+	scopes, err := r.ReadByte()
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	exp, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	var nonce uuid.UUID
+	if r.Len() != len(nonce) {
+		return nil, ErrMalformed
+	}
+	_, _ = r.Read(nonce[:])
+	return &Claims{
+		KeyID:      string(kid),
+		ContractID: uint(id),
+		Nonce:      nonce.String(),
+		Scopes:     Scope(scopes),
+		ExpiresAt:  time.Unix(int64(exp), 0),
+	}, nil
+}
@@ -0,0 +1,209 @@
+package linktoken
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testNonce  = "3f2c1a9e-8b7d-4c6e-9a1b-2d3e4f5a6b7c"
+	testSecret = "0123456789abcdef0123456789abcdef"
+)
+
+func mustKeyset(t *testing.T, spec string) *Keyset {
+	t.Helper()
+	ks, err := ParseKeyset(spec)
+	if err != nil {
+		t.Fatalf("ParseKeyset(%q): %v", spec, err)
+	}
+	return ks
+}
+
+func TestParseKeyset(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantNil bool
+		wantErr bool
+	}{
+		{"empty disables signing", "  ", true, false},
+		{"one key", "k1:" + testSecret, false, false},
+		{"two keys", "k2:" + testSecret + ", k1:" + testSecret, false, false},
+		{"missing secret", "k1", false, true},
+		{"empty kid", ":" + testSecret, false, true},
+		{"kid too long", "abcdefghijklmnopq:" + testSecret, false, true},
+		{"short secret", "k1:short", false, true},
+		{"duplicate kid", "k1:" + testSecret + ",k1:" + testSecret, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks, err := ParseKeyset(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (ks == nil) != tt.wantNil {
+				t.Fatalf("keyset = %v, wantNil %v", ks, tt.wantNil)
+			}
+		})
+	}
+}
+
+func TestIssueVerify(t *testing.T) {
+	now := time.Now()
+	ks := mustKeyset(t, "k1:"+testSecret)
+	token, err := ks.Issue(Claims{ContractID: 42, Nonce: testNonce, Scopes: ScopeView | ScopeSign, ExpiresAt: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	c, err := ks.Verify(token, now)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if c.KeyID != "k1" || c.ContractID != 42 || c.Nonce != testNonce || c.Scopes != ScopeView|ScopeSign {
+		t.Fatalf("claims = %+v", c)
+	}
+	if c.ExpiresAt.Unix() != now.Add(time.Hour).Unix() {
+		t.Fatalf("ExpiresAt = %v, want %v", c.ExpiresAt, now.Add(time.Hour))
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	now := time.Now()
+	ks := mustKeyset(t, "k1:"+testSecret)
+	valid, err := ks.Issue(Claims{ContractID: 7, Nonce: testNonce, Scopes: ScopeView, ExpiresAt: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	expired, err := ks.Issue(Claims{ContractID: 7, Nonce: testNonce, Scopes: ScopeView, ExpiresAt: now.Add(-time.Second)})
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	other := mustKeyset(t, "k2:"+strings.Repeat("x", 32))
+	foreign, err := other.Issue(Claims{ContractID: 7, Nonce: testNonce, Scopes: ScopeView, ExpiresAt: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	sameKid := mustKeyset(t, "k1:"+strings.Repeat("y", 32))
+	forged, err := sameKid.Issue(Claims{ContractID: 7, Nonce: testNonce, Scopes: ScopeAll, ExpiresAt: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	payload, mac, _ := strings.Cut(valid, ".")
+	otherPayload, _, _ := strings.Cut(forged, ".")
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"bare nonce", testNonce, ErrMalformed},
+		{"no mac", payload, ErrMalformed},
+		{"bad base64", "!!." + mac, ErrMalformed},
+		{"short mac", payload + "." + mac[:4], ErrMalformed},
+		{"expired", expired, ErrExpired},
+		{"unknown key", foreign, ErrUnknownKey},
+		{"wrong secret", forged, ErrSignature},
+		{"swapped payload", otherPayload + "." + mac, ErrSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ks.Verify(tt.token, now); !errors.Is(err, tt.want) {
+				t.Fatalf("Verify err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	now := time.Now()
+	old := mustKeyset(t, "old:"+testSecret)
+	token, err := old.Issue(Claims{ContractID: 1, Nonce: testNonce, Scopes: ScopeAll, ExpiresAt: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	rotated := mustKeyset(t, "new:"+strings.Repeat("n", 32)+",old:"+testSecret)
+	if _, err := rotated.Verify(token, now); err != nil {
+		t.Fatalf("old token after rotation: %v", err)
+	}
+	fresh, err := rotated.Issue(Claims{ContractID: 1, Nonce: testNonce, Scopes: ScopeAll, ExpiresAt: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if c, _ := Parse(fresh); c == nil || c.KeyID != "new" {
+		t.Fatalf("new tokens should be signed with the first key, got %+v", c)
+	}
+}
+
+func TestIssueRejectsNonUUIDNonce(t *testing.T) {
+	ks := mustKeyset(t, "k1:"+testSecret)
+	if _, err := ks.Issue(Claims{ContractID: 1, Nonce: "not-a-uuid", Scopes: ScopeView, ExpiresAt: time.Now()}); err == nil {
+		t.Fatal("Issue accepted a nonce that is not a UUID")
+	}
+}
+
+func TestParse(t *testing.T) {
+	ks := mustKeyset(t, "k1:"+testSecret)
+	token, err := ks.Issue(Claims{ContractID: 9, Nonce: testNonce, Scopes: ScopeView, ExpiresAt: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	tests := []struct {
+		name   string
+		token  string
+		wantOK bool
+	}{
+		{"signed token", token, true},
+		{"bare nonce", testNonce, false},
+		{"garbage", "a.b", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, ok := Parse(tt.token)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && (c.ContractID != 9 || c.Nonce != testNonce) {
+				t.Fatalf("claims = %+v", c)
+			}
+		})
+	}
+}
+
+func TestParseScopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      []string
+		want    Scope
+		wantErr bool
+	}{
+		{"none", nil, 0, false},
+		{"view", []string{"view"}, ScopeView, false},
+		{"sign implies view", []string{"sign"}, ScopeView | ScopeSign, false},
+		{"review implies view", []string{"review"}, ScopeView | ScopeReview, false},
+		{"all", []string{"view", "review", "sign"}, ScopeAll, false},
+		{"unknown", []string{"admin"}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseScopes(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("scopes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopeHasAndNames(t *testing.T) {
+	s := ScopeView | ScopeSign
+	if !s.Has(ScopeView) || !s.Has(ScopeView|ScopeSign) || s.Has(ScopeReview) || s.Has(ScopeAll) {
+		t.Fatalf("Has is wrong for %v", s)
+	}
+	if got := strings.Join(s.Names(), ","); got != "view,sign" {
+		t.Fatalf("Names = %q, want view,sign", got)
+	}
+}
@@ -17,7 +17,7 @@ type claims struct {
 	jwt.RegisteredClaims
 }
 
-// RequireAuth returns middleware that validates JWT and sets user_id, user_email in context.
+// RequireAuth returns middleware that validates JWT and sets user_id, user_email, user_role in context.
 // If jwtSecret is empty, any Bearer token is accepted and user_id=1, user_email=placeholder (dev-only).
 func RequireAuth(jwtSecret string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -59,11 +59,35 @@ func RequireAuth(jwtSecret string) func(http.Handler) http.Handler {
 			}
 			ctx := context.WithValue(r.Context(), "user_id", c.UserID)
 			ctx = context.WithValue(ctx, "user_email", c.Email)
+			ctx = context.WithValue(ctx, "user_role", c.Role)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// RequireRole must run after RequireAuth. It rejects tokens whose role is not one of roles with 403.
+// The dev placeholder (empty JWT secret) carries no role, so role-gated routes need a real token.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, _ := r.Context().Value("user_role").(string)
+			for _, allowed := range roles {
+				if role == allowed {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"error":   "Forbidden",
+				"message": "This endpoint requires role: " + strings.Join(roles, " or "),
+				"code":    "FORBIDDEN",
+			})
+		})
+	}
+}
+
 func respondAuthError(w http.ResponseWriter, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusUnauthorized)
@@ -73,3 +97,14 @@ func respondAuthError(w http.ResponseWriter, message string) {
 		"code":    "UNAUTHORIZED",
 	})
 }
+
+// respondStatusError writes the same error shape as handler.respondError for middleware that rejects a request.
+func respondStatusError(w http.ResponseWriter, status int, message, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error":   http.StatusText(status),
+		"message": message,
+		"code":    code,
+	})
+}
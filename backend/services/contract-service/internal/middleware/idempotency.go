@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+)
+
+const (
+	// IdempotencyKeyHeader is sent by clients that may retry a mutating request
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses served from a stored result
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLen = 255
+	maxIdempotentBody    = 1 << 20
+)
+
+// IdempotencyStore keeps one response per scope and key; see repository.IdempotencyRepository.
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, rec *domain.IdempotencyKey) (existing *domain.IdempotencyKey, reserved bool, err error)
+	Complete(ctx context.Context, id uint, statusCode int, contentType string, body []byte) error
+	Release(ctx context.Context, id uint) error
+}
+
+// Idempotency returns middleware that makes a route safe to retry with an Idempotency-Key header. The first request
+// with a key runs and its response is stored for ttl; repeats with the same body get the stored response (with
+// Idempotent-Replayed: true), a different body gets 422 and a repeat while the first is still running gets 409.
+// Keys are scoped to the caller: user_id after RequireAuth, else the {token} URL parameter, so it must be applied
+// per route (r.With) for token routes. Requests without the header, and 5xx or 429 responses, are not stored.
+func Idempotency(store IdempotencyStore, ttl time.Duration) func(http.Handler) http.Handler {
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLen {
+				respondStatusError(w, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters", "INVALID_IDEMPOTENCY_KEY")
+				return
+			}
+			scope := idempotencyScope(r)
+			if scope == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBody))
+			if err != nil {
+				var maxErr *http.MaxBytesError
+				if errors.As(err, &maxErr) {
+					respondStatusError(w, http.StatusRequestEntityTooLarge, "Request body too large", "REQUEST_TOO_LARGE")
+					return
+				}
+				respondStatusError(w, http.StatusBadRequest, "Failed to read request body", "BAD_REQUEST")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			h := sha256.New()
+			h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+			h.Write(body)
+			rec := &domain.IdempotencyKey{
+				Scope:       scope,
+				Key:         key,
+				RequestHash: hex.EncodeToString(h.Sum(nil)),
+				ExpiresAt:   time.Now().Add(ttl),
+			}
+			existing, reserved, err := store.Reserve(r.Context(), rec)
+			if err != nil {
+				respondStatusError(w, http.StatusInternalServerError, "Failed to check idempotency key", "INTERNAL_ERROR")
+				return
+			}
+			if !reserved {
+				switch {
+				case existing.RequestHash != rec.RequestHash:
+					respondStatusError(w, http.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request", "IDEMPOTENCY_KEY_MISMATCH")
+				case existing.StatusCode == 0:
+					respondStatusError(w, http.StatusConflict, "A request with this Idempotency-Key is still in progress", "IDEMPOTENCY_KEY_IN_PROGRESS")
+				default:
+					if existing.ContentType != "" {
+						w.Header().Set("Content-Type", existing.ContentType)
+					}
+					w.Header().Set(IdempotentReplayedHeader, "true")
+					w.WriteHeader(existing.StatusCode)
+					_, _ = w.Write(existing.ResponseBody)
+				}
+				return
+			}
+
+			rw := &recordingWriter{ResponseWriter: w}
+			defer func() {
+				// the request context may be gone by now; the outcome must still be recorded
+				ctx := context.Background()
+				if p := recover(); p != nil {
+					_ = store.Release(ctx, rec.ID)
+					panic(p)
+				}
+				status := rw.status
+				if status == 0 {
+					status = http.StatusOK
+				}
+				if status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+					if err := store.Release(ctx, rec.ID); err != nil {
+						log.Printf("[idempotency] release %d: %v", rec.ID, err)
+					}
+					return
+				}
+				if err := store.Complete(ctx, rec.ID, status, rw.Header().Get("Content-Type"), rw.body.Bytes()); err != nil {
+					log.Printf("[idempotency] store response %d: %v", rec.ID, err)
+				}
+			}()
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+// idempotencyScope identifies the caller; empty when the route has neither an authenticated user nor a link token.
+func idempotencyScope(r *http.Request) string {
+	if id, ok := r.Context().Value("user_id").(uint); ok {
+		return "user:" + strconv.FormatUint(uint64(id), 10)
+	}
+	if token := chi.URLParam(r, "token"); token != "" {
+		return "token:" + hashToken(token)
+	}
+	return ""
+}
+
+// recordingWriter passes the response through and keeps a copy for the idempotency store.
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
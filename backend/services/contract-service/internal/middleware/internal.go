@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// InternalTokenHeader carries the shared secret on service-to-service calls (same header as user-service).
+const InternalTokenHeader = "X-Internal-Token"
+
+// RequireInternalToken guards /internal/v1 routes with the shared internal API token. An empty token rejects
+// every request, so internal routes stay closed until INTERNAL_API_TOKEN is set.
+func RequireInternalToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got := r.Header.Get(InternalTokenHeader)
+			if token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				respondAuthError(w, "Invalid internal token")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/ratelimit"
+)
+
+// publicPrefix covers the unauthenticated link routes: /api/v1/public/{contracts|signers|calendar}/{token}/...
+const publicPrefix = "/api/v1/public/"
+
+// TokenLookup reports whether a link token exists; kind is "contracts" or "signers" (see
+// service.ContractService.PublicTokenExists) or "calendar" (service.CalendarService.PublicTokenExists).
+// A lookup answers false for kinds it does not own.
+type TokenLookup func(ctx context.Context, kind, token string) (bool, error)
+
+// AnyTokenLookup combines lookups: a token exists when one of them knows it.
+func AnyTokenLookup(lookups ...TokenLookup) TokenLookup {
+	return func(ctx context.Context, kind, token string) (bool, error) {
+		for _, lookup := range lookups {
+			ok, err := lookup(ctx, kind, token)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	}
+}
+
+// PublicTokenGuard throttles the public link routes per client IP and per token, and answers unknown tokens itself:
+// each one counts as a failed lookup for the IP, and an IP with too many is blocked for a while (429). Responses
+// carry X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset (seconds) for the tightest limit. Use it as
+// a top-level r.Use; other paths pass through. The client IP is RemoteAddr, so put chi's middleware.RealIP first
+// when running behind a proxy. If the store or lookup fails the request is let through.
+func PublicTokenGuard(limiter *ratelimit.Limiter, lookup TokenLookup) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			kind, token, ok := publicToken(r.URL.Path)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ip := clientIP(r)
+			write := r.Method != http.MethodGet && r.Method != http.MethodHead
+			d, err := limiter.Check(r.Context(), ip, hashToken(token), write)
+			if err != nil {
+				log.Printf("[rate-limit] check %s: %v", ip, err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if d.Blocked {
+				setRetryAfter(w, d.RetryAt)
+				respondStatusError(w, http.StatusTooManyRequests, "Too many requests for unknown links; try again later", "TOO_MANY_FAILED_LOOKUPS")
+				return
+			}
+			if d.Limit > 0 {
+				w.Header().Set("X-RateLimit-Limit", strconv.Itoa(d.Limit))
+				w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(d.Remaining))
+				w.Header().Set("X-RateLimit-Reset", strconv.Itoa(secondsUntil(d.ResetAt)))
+			}
+			if !d.Allowed {
+				setRetryAfter(w, d.RetryAt)
+				respondStatusError(w, http.StatusTooManyRequests, "Rate limit exceeded; try again later", "RATE_LIMITED")
+				return
+			}
+			exists, err := lookup(r.Context(), kind, token)
+			if err != nil {
+				log.Printf("[rate-limit] token lookup: %v", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !exists {
+				if _, err := limiter.RecordFailedLookup(r.Context(), ip); err != nil {
+					log.Printf("[rate-limit] record failed lookup %s: %v", ip, err)
+				}
+				respondStatusError(w, http.StatusNotFound, "Contract not found", "NOT_FOUND")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// publicToken splits /api/v1/public/{kind}/{token}/... into kind and token.
+func publicToken(path string) (kind, token string, ok bool) {
+	if !strings.HasPrefix(path, publicPrefix) {
+		return "", "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(path, publicPrefix), "/", 3)
+	if len(parts) < 2 || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// hashToken keys link tokens by hash so stores never hold usable credentials.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func secondsUntil(t time.Time) int {
+	s := int(time.Until(t).Round(time.Second) / time.Second)
+	if s < 0 {
+		return 0
+	}
+	return s
+}
+
+func setRetryAfter(w http.ResponseWriter, at time.Time) {
+	if s := secondsUntil(at); s > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(s))
+	}
+}
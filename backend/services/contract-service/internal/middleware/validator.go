@@ -21,6 +21,11 @@ func (v *Validator) ValidateJSON(r *http.Request, dst interface{}) error {
 	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
 		return fmt.Errorf("invalid JSON: %w", err)
 	}
+	return v.ValidateStruct(dst)
+}
+
+// ValidateStruct runs validation tags on an already-decoded value (e.g. a CSV row mapped to a DTO).
+func (v *Validator) ValidateStruct(dst interface{}) error {
 	if err := v.validate.Struct(dst); err != nil {
 		if ves, ok := err.(validator.ValidationErrors); ok {
 			var msg []string
@@ -1,6 +1,9 @@
 package notification
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // ContractNotifier is the interface for sending notifications when contract lifecycle events occur.
 // Implementations can be no-op (dev), log-only, or call a notification service (e.g. email on send).
@@ -8,9 +11,53 @@ type ContractNotifier interface {
 	// NotifyContractSent is called when a contract is sent to the client. Run off the hot path (e.g. async).
 	// contractID and shareableLink are for reference; clientEmail is the recipient for "email to client".
 	NotifyContractSent(ctx context.Context, contractID uint, clientEmail, shareableLink string)
+	// NotifySignCode delivers the one-time sign code to email. The code must not be logged.
+	NotifySignCode(ctx context.Context, contractID uint, email, code string, expiresAt time.Time)
+	// NotifyClientEmailCode delivers the code a client account uses to verify its email before claiming contracts.
+	NotifyClientEmailCode(ctx context.Context, email, code string, expiresAt time.Time)
+	// NotifyAmendmentProposed tells the client a change order waits for their decision at link.
+	NotifyAmendmentProposed(ctx context.Context, contractID uint, clientEmail, link string)
+	// NotifyContractViewed tells the freelancer (looked up by freelancerUserID) the client opened the contract link
+	// for the first time.
+	NotifyContractViewed(ctx context.Context, contractID, freelancerUserID uint)
+	// NotifyMilestoneSubmitted asks the client to review and approve a delivered milestone at link.
+	NotifyMilestoneSubmitted(ctx context.Context, contractID uint, clientEmail, milestoneTitle, link string)
+	// NotifyMilestoneChangesRequested tells the freelancer the client sent a submitted milestone back with comment.
+	NotifyMilestoneChangesRequested(ctx context.Context, contractID, freelancerUserID uint, milestoneTitle, comment string)
+	// NotifyTimesheetLogged asks the client to approve or reject hours the freelancer logged on an hourly contract at link.
+	NotifyTimesheetLogged(ctx context.Context, contractID uint, clientEmail string, hours float64, link string)
+	// NotifyTestimonialRequested invites the client to rate the completed contract and leave a testimonial at link.
+	NotifyTestimonialRequested(ctx context.Context, contractID uint, clientEmail, link string)
+	// NotifyCancellation reports a cancellation step (event: withdrawn | declined | requested | accepted | rejected) taken by
+	// "freelancer" or "client". Implementations tell the other party: clientEmail for freelancer actions, the freelancer
+	// (looked up by freelancerUserID) for client actions.
+	NotifyCancellation(ctx context.Context, contractID, freelancerUserID uint, clientEmail, event, by, reason string)
+	// NotifyDispute reports a dispute step (event: opened | message | escalated | withdrawn | resolved) taken by
+	// "freelancer", "client", "admin" or "deadline" (missed deadline). Implementations tell the parties other than by.
+	NotifyDispute(ctx context.Context, contractID, freelancerUserID uint, clientEmail string, disputeID uint, event, by string)
 }
 
 // NoopNotifier does nothing. Use in development or when notification service is not yet integrated.
 type NoopNotifier struct{}
 
 func (NoopNotifier) NotifyContractSent(context.Context, uint, string, string) {}
+
+func (NoopNotifier) NotifySignCode(context.Context, uint, string, string, time.Time) {}
+
+func (NoopNotifier) NotifyClientEmailCode(context.Context, string, string, time.Time) {}
+
+func (NoopNotifier) NotifyAmendmentProposed(context.Context, uint, string, string) {}
+
+func (NoopNotifier) NotifyContractViewed(context.Context, uint, uint) {}
+
+func (NoopNotifier) NotifyMilestoneSubmitted(context.Context, uint, string, string, string) {}
+
+func (NoopNotifier) NotifyMilestoneChangesRequested(context.Context, uint, uint, string, string) {}
+
+func (NoopNotifier) NotifyTimesheetLogged(context.Context, uint, string, float64, string) {}
+
+func (NoopNotifier) NotifyTestimonialRequested(context.Context, uint, string, string) {}
+
+func (NoopNotifier) NotifyCancellation(context.Context, uint, uint, string, string, string, string) {}
+
+func (NoopNotifier) NotifyDispute(context.Context, uint, uint, string, uint, string, string) {}
@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Rule allows Limit requests per Window; a zero Limit disables the rule.
+type Rule struct {
+	Limit  int
+	Window time.Duration
+}
+
+// Settings configures the public token route limits.
+type Settings struct {
+	PerIP            Rule // all public token requests from one IP
+	PerToken         Rule // all requests for one link token
+	PerTokenWrite    Rule // POST/PUT/DELETE for one link token (sign, review, disputes, ...)
+	MaxFailedLookups int  // unknown tokens from one IP within FailureWindow before it is blocked; 0 = never block
+	FailureWindow    time.Duration
+	BlockFor         time.Duration
+}
+
+// Decision is the outcome of Check. Limit, Remaining and ResetAt describe the tightest rule that applied.
+type Decision struct {
+	Allowed   bool
+	Blocked   bool // the IP is blocked for failed lookups; RetryAt says until when
+	Limit     int
+	Remaining int
+	ResetAt   time.Time
+	RetryAt   time.Time
+}
+
+// Limiter applies Settings on top of a Store.
+type Limiter struct {
+	store    Store
+	settings Settings
+}
+
+func NewLimiter(store Store, settings Settings) *Limiter {
+	return &Limiter{store: store, settings: settings}
+}
+
+// Check counts one request from ip for tokenKey (a hash of the link token) and reports whether it may proceed.
+func (l *Limiter) Check(ctx context.Context, ip, tokenKey string, write bool) (Decision, error) {
+	until, err := l.store.BlockedUntil(ctx, "block:"+ip)
+	if err != nil {
+		return Decision{}, err
+	}
+	if !until.IsZero() {
+		return Decision{Blocked: true, RetryAt: until}, nil
+	}
+	d := Decision{Allowed: true, Remaining: -1}
+	apply := func(key string, rule Rule) error {
+		if rule.Limit <= 0 {
+			return nil
+		}
+		count, resetAt, err := l.store.Hit(ctx, key, rule.Window)
+		if err != nil {
+			return err
+		}
+		remaining := rule.Limit - count
+		if remaining < 0 {
+			remaining = 0
+		}
+		if d.Remaining < 0 || remaining < d.Remaining {
+			d.Limit, d.Remaining, d.ResetAt = rule.Limit, remaining, resetAt
+		}
+		if count > rule.Limit {
+			d.Allowed = false
+			if resetAt.After(d.RetryAt) {
+				d.RetryAt = resetAt
+			}
+		}
+		return nil
+	}
+	if err := apply("ip:"+ip, l.settings.PerIP); err != nil {
+		return Decision{}, err
+	}
+	if err := apply("token:"+tokenKey, l.settings.PerToken); err != nil {
+		return Decision{}, err
+	}
+	if write {
+		if err := apply("token-write:"+tokenKey, l.settings.PerTokenWrite); err != nil {
+			return Decision{}, err
+		}
+	}
+	return d, nil
+}
+
+// RecordFailedLookup counts a request from ip for a token that does not exist and blocks the IP for BlockFor once
+// it reaches MaxFailedLookups within FailureWindow. Returns whether the IP is now blocked.
+func (l *Limiter) RecordFailedLookup(ctx context.Context, ip string) (bool, error) {
+	if l.settings.MaxFailedLookups <= 0 {
+		return false, nil
+	}
+	count, _, err := l.store.Hit(ctx, "fail:"+ip, l.settings.FailureWindow)
+	if err != nil {
+		return false, err
+	}
+	if count < l.settings.MaxFailedLookups {
+		return false, nil
+	}
+	return true, l.store.Block(ctx, "block:"+ip, time.Now().Add(l.settings.BlockFor))
+}
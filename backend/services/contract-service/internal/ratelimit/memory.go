@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepEvery bounds how often expired counters and blocks are dropped from memory.
+const sweepEvery = time.Minute
+
+type counter struct {
+	count   int
+	resetAt time.Time
+}
+
+// MemoryStore is an in-process Store. Limits are per instance, so run one instance or use a shared Store.
+type MemoryStore struct {
+	mu        sync.Mutex
+	counters  map[string]*counter
+	blocks    map[string]time.Time
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		counters:  make(map[string]*counter),
+		blocks:    make(map[string]time.Time),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *MemoryStore) Hit(ctx context.Context, key string, window time.Duration) (int, time.Time, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+	c, ok := s.counters[key]
+	if !ok || !now.Before(c.resetAt) {
+		c = &counter{resetAt: now.Add(window)}
+		s.counters[key] = c
+	}
+	c.count++
+	return c.count, c.resetAt, nil
+}
+
+func (s *MemoryStore) Block(ctx context.Context, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blocks[key] = until
+	return nil
+}
+
+func (s *MemoryStore) BlockedUntil(ctx context.Context, key string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	until, ok := s.blocks[key]
+	if !ok || !time.Now().Before(until) {
+		return time.Time{}, nil
+	}
+	return until, nil
+}
+
+// sweep drops expired entries; called with mu held.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepEvery {
+		return
+	}
+	s.lastSweep = now
+	for k, c := range s.counters {
+		if !now.Before(c.resetAt) {
+			delete(s.counters, k)
+		}
+	}
+	for k, until := range s.blocks {
+		if !now.Before(until) {
+			delete(s.blocks, k)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Store keeps fixed-window counters and temporary blocks by key. MemoryStore serves a single instance; to limit
+// across instances implement Store over a shared backend (e.g. Redis INCR with PEXPIRE for Hit, SET with PX for Block).
+type Store interface {
+	// Hit counts one event for key in the current window and returns the count so far and when the window ends.
+	Hit(ctx context.Context, key string, window time.Duration) (count int, resetAt time.Time, err error)
+	// Block blocks key until the given time.
+	Block(ctx context.Context, key string, until time.Time) error
+	// BlockedUntil returns when an active block on key ends, or the zero time when key is not blocked.
+	BlockedUntil(ctx context.Context, key string) (time.Time, error)
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAmendmentNotFound = errors.New("amendment not found")
+)
+
+type AmendmentRepository interface {
+	Create(ctx context.Context, a *domain.ContractAmendment) error
+	GetByID(ctx context.Context, id uint, contractID uint) (*domain.ContractAmendment, error)
+	ListByContract(ctx context.Context, contractID uint) ([]*domain.ContractAmendment, error)
+	// FindOpen returns the contract's proposed (not yet answered) amendment, if any.
+	FindOpen(ctx context.Context, contractID uint) (*domain.ContractAmendment, error)
+	// Close moves a proposed amendment to rejected or withdrawn.
+	Close(ctx context.Context, a *domain.ContractAmendment) error
+	// Apply accepts a proposed amendment in one transaction: the amendment (with evidence and snapshot),
+	// the contract terms and version, and the milestone upserts and removals.
+	Apply(ctx context.Context, a *domain.ContractAmendment, c *domain.Contract, upserts []domain.ContractMilestone, removeIDs []uint) error
+}
+
+type amendmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAmendmentRepository(db *gorm.DB) AmendmentRepository {
+	return &amendmentRepository{db: db}
+}
+
+func (r *amendmentRepository) Create(ctx context.Context, a *domain.ContractAmendment) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *amendmentRepository) GetByID(ctx context.Context, id uint, contractID uint) (*domain.ContractAmendment, error) {
+	var a domain.ContractAmendment
+	err := r.db.WithContext(ctx).Where("id = ? AND contract_id = ?", id, contractID).First(&a).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAmendmentNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *amendmentRepository) ListByContract(ctx context.Context, contractID uint) ([]*domain.ContractAmendment, error) {
+	var list []*domain.ContractAmendment
+	err := r.db.WithContext(ctx).Where("contract_id = ?", contractID).Order("created_at ASC").Find(&list).Error
+	return list, err
+}
+
+func (r *amendmentRepository) FindOpen(ctx context.Context, contractID uint) (*domain.ContractAmendment, error) {
+	var a domain.ContractAmendment
+	err := r.db.WithContext(ctx).Where("contract_id = ? AND status = ?", contractID, domain.AmendmentStatusProposed).First(&a).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAmendmentNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *amendmentRepository) Close(ctx context.Context, a *domain.ContractAmendment) error {
+	res := r.db.WithContext(ctx).Model(&domain.ContractAmendment{}).
+		Where("id = ? AND status = ?", a.ID, domain.AmendmentStatusProposed).
+		Updates(map[string]interface{}{
+			"status":         a.Status,
+			"client_comment": a.ClientComment,
+			"responded_at":   a.RespondedAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrAmendmentNotFound
+	}
+	return nil
+}
+
+func (r *amendmentRepository) Apply(ctx context.Context, a *domain.ContractAmendment, c *domain.Contract, upserts []domain.ContractMilestone, removeIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domain.ContractAmendment{}).
+			Where("id = ? AND status = ?", a.ID, domain.AmendmentStatusProposed).
+			Updates(map[string]interface{}{
+				"status":                   domain.AmendmentStatusAccepted,
+				"previous_terms":           a.PreviousTerms,
+				"responded_at":             a.RespondedAt,
+				"client_signed_name":       a.ClientSignedName,
+				"client_signer_ip":         a.ClientSignerIP,
+				"client_signer_user_agent": a.ClientSignerUserAgent,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrAmendmentNotFound
+		}
+		terms := map[string]interface{}{
+			"terms_version":        a.Version,
+			"due_date":             c.DueDate,
+			"submission_criteria":  c.SubmissionCriteria,
+			"terms_and_conditions": c.TermsAndConditions,
+			"hourly_rate":          c.HourlyRate,
+			"weekly_hour_cap":      c.WeeklyHourCap,
+			"retainer_amount":      c.RetainerAmount,
+			"version":              nextVersion,
+		}
+		// hourly and retainer totals accrue concurrently (timesheets, retainer periods); only a fixed total is a term
+		if c.ContractType == "" || c.ContractType == domain.ContractTypeFixed {
+			terms["total_amount"] = c.TotalAmount
+		}
+		// the version check guards against applying two amendments built on the same base
+		res = tx.Model(&domain.Contract{}).
+			Where("id = ? AND terms_version = ?", c.ID, a.Version-1).
+			Updates(terms)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrAmendmentNotFound
+		}
+		if len(removeIDs) > 0 {
+			if err := tx.Where("contract_id = ? AND id IN ?", c.ID, removeIDs).Delete(&domain.ContractMilestone{}).Error; err != nil {
+				return err
+			}
+		}
+		for i := range upserts {
+			upserts[i].ContractID = c.ID
+			if err := tx.Save(&upserts[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
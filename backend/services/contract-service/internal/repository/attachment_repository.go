@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAttachmentNotFound = errors.New("attachment not found")
+)
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, a *domain.ContractAttachment) error
+	GetByID(ctx context.Context, id uint, contractID uint) (*domain.ContractAttachment, error)
+	ListByContract(ctx context.Context, contractID uint) ([]*domain.ContractAttachment, error)
+	Delete(ctx context.Context, id uint, contractID uint) error
+}
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+func (r *attachmentRepository) Create(ctx context.Context, a *domain.ContractAttachment) error {
+	return r.db.WithContext(ctx).Create(a).Error
+}
+
+func (r *attachmentRepository) GetByID(ctx context.Context, id uint, contractID uint) (*domain.ContractAttachment, error) {
+	var a domain.ContractAttachment
+	err := r.db.WithContext(ctx).Where("id = ? AND contract_id = ?", id, contractID).First(&a).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *attachmentRepository) ListByContract(ctx context.Context, contractID uint) ([]*domain.ContractAttachment, error) {
+	var list []*domain.ContractAttachment
+	err := r.db.WithContext(ctx).Where("contract_id = ?", contractID).Order("created_at ASC").Find(&list).Error
+	return list, err
+}
+
+// Delete soft-deletes the attachment row. The blob is kept: other rows may share the same CID.
+func (r *attachmentRepository) Delete(ctx context.Context, id uint, contractID uint) error {
+	res := r.db.WithContext(ctx).Where("id = ? AND contract_id = ?", id, contractID).Delete(&domain.ContractAttachment{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrAttachmentNotFound
+	}
+	return nil
+}
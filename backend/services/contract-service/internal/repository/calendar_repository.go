@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+)
+
+// CalendarRepository holds freelancers' calendar subscription feeds and loads the contracts they export.
+type CalendarRepository interface {
+	// ListContracts returns the freelancer's contracts that are not cancelled, most recently updated first, with milestones.
+	ListContracts(ctx context.Context, freelancerUserID uint, limit int) ([]*domain.Contract, error)
+	// SaveFeed creates the freelancer's feed or replaces its token hash (rotation).
+	SaveFeed(ctx context.Context, feed *domain.CalendarFeed) error
+	GetFeed(ctx context.Context, freelancerUserID uint) (*domain.CalendarFeed, error)
+	FindFeedByTokenHash(ctx context.Context, tokenHash string) (*domain.CalendarFeed, error)
+	TouchFeed(ctx context.Context, id uint, at time.Time) error
+	DeleteFeed(ctx context.Context, freelancerUserID uint) error
+}
+
+type calendarRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarRepository(db *gorm.DB) CalendarRepository {
+	return &calendarRepository{db: db}
+}
+
+func (r *calendarRepository) ListContracts(ctx context.Context, freelancerUserID uint, limit int) ([]*domain.Contract, error) {
+	var list []*domain.Contract
+	err := r.db.WithContext(ctx).Preload("Milestones", func(db *gorm.DB) *gorm.DB {
+		return db.Order("order_index ASC")
+	}).Where("freelancer_user_id = ? AND status <> ?", freelancerUserID, domain.ContractStatusCancel).
+		Order("updated_at DESC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+func (r *calendarRepository) SaveFeed(ctx context.Context, feed *domain.CalendarFeed) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "freelancer_user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"token_hash": feed.TokenHash, "last_fetched_at": nil, "updated_at": time.Now()}),
+	}).Create(feed).Error
+}
+
+func (r *calendarRepository) GetFeed(ctx context.Context, freelancerUserID uint) (*domain.CalendarFeed, error) {
+	return r.findFeed(ctx, "freelancer_user_id = ?", freelancerUserID)
+}
+
+func (r *calendarRepository) FindFeedByTokenHash(ctx context.Context, tokenHash string) (*domain.CalendarFeed, error) {
+	return r.findFeed(ctx, "token_hash = ?", tokenHash)
+}
+
+func (r *calendarRepository) findFeed(ctx context.Context, query string, arg interface{}) (*domain.CalendarFeed, error) {
+	var f domain.CalendarFeed
+	err := r.db.WithContext(ctx).Where(query, arg).First(&f).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCalendarFeedNotFound
+		}
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *calendarRepository) TouchFeed(ctx context.Context, id uint, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&domain.CalendarFeed{}).Where("id = ?", id).UpdateColumn("last_fetched_at", at).Error
+}
+
+func (r *calendarRepository) DeleteFeed(ctx context.Context, freelancerUserID uint) error {
+	res := r.db.WithContext(ctx).Where("freelancer_user_id = ?", freelancerUserID).Delete(&domain.CalendarFeed{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrCalendarFeedNotFound
+	}
+	return nil
+}
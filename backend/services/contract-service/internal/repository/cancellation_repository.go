@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCancellationNotFound = errors.New("cancellation not found")
+)
+
+type CancellationRepository interface {
+	// Create stores a mutual cancellation request; the contract is unchanged.
+	Create(ctx context.Context, x *domain.ContractCancellation) error
+	// CreateAndCancel stores an immediate cancellation (withdraw/decline) and cancels the contract if its status is in fromStatuses.
+	CreateAndCancel(ctx context.Context, x *domain.ContractCancellation, fromStatuses []string, at time.Time) error
+	// AcceptAndCancel accepts a requested cancellation and cancels the contract if its status is in fromStatuses.
+	AcceptAndCancel(ctx context.Context, x *domain.ContractCancellation, fromStatuses []string, at time.Time) error
+	Reject(ctx context.Context, x *domain.ContractCancellation) error
+	FindOpen(ctx context.Context, contractID uint) (*domain.ContractCancellation, error)
+	FindLatest(ctx context.Context, contractID uint) (*domain.ContractCancellation, error)
+}
+
+type cancellationRepository struct {
+	db *gorm.DB
+}
+
+func NewCancellationRepository(db *gorm.DB) CancellationRepository {
+	return &cancellationRepository{db: db}
+}
+
+func (r *cancellationRepository) Create(ctx context.Context, x *domain.ContractCancellation) error {
+	return r.db.WithContext(ctx).Create(x).Error
+}
+
+func (r *cancellationRepository) CreateAndCancel(ctx context.Context, x *domain.ContractCancellation, fromStatuses []string, at time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := cancelContract(tx, x.ContractID, fromStatuses, at); err != nil {
+			return err
+		}
+		return tx.Create(x).Error
+	})
+}
+
+func (r *cancellationRepository) AcceptAndCancel(ctx context.Context, x *domain.ContractCancellation, fromStatuses []string, at time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domain.ContractCancellation{}).
+			Where("id = ? AND status = ?", x.ID, domain.CancellationStatusRequested).
+			Updates(map[string]interface{}{
+				"status":           domain.CancellationStatusAccepted,
+				"response_comment": x.ResponseComment,
+				"responded_at":     x.RespondedAt,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrCancellationNotFound
+		}
+		return cancelContract(tx, x.ContractID, fromStatuses, at)
+	})
+}
+
+func cancelContract(tx *gorm.DB, contractID uint, fromStatuses []string, at time.Time) error {
+	res := tx.Model(&domain.Contract{}).
+		Where("id = ? AND status IN ?", contractID, fromStatuses).
+		Updates(map[string]interface{}{"status": domain.ContractStatusCancel, "cancelled_at": at, "version": nextVersion})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrContractNotFound
+	}
+	return nil
+}
+
+func (r *cancellationRepository) Reject(ctx context.Context, x *domain.ContractCancellation) error {
+	res := r.db.WithContext(ctx).Model(&domain.ContractCancellation{}).
+		Where("id = ? AND status = ?", x.ID, domain.CancellationStatusRequested).
+		Updates(map[string]interface{}{
+			"status":           domain.CancellationStatusRejected,
+			"response_comment": x.ResponseComment,
+			"responded_at":     x.RespondedAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrCancellationNotFound
+	}
+	return nil
+}
+
+func (r *cancellationRepository) FindOpen(ctx context.Context, contractID uint) (*domain.ContractCancellation, error) {
+	return r.findOne(ctx, "contract_id = ? AND status = ?", contractID, domain.CancellationStatusRequested)
+}
+
+func (r *cancellationRepository) FindLatest(ctx context.Context, contractID uint) (*domain.ContractCancellation, error) {
+	return r.findOne(ctx, "contract_id = ?", contractID)
+}
+
+func (r *cancellationRepository) findOne(ctx context.Context, query string, args ...interface{}) (*domain.ContractCancellation, error) {
+	var x domain.ContractCancellation
+	err := r.db.WithContext(ctx).Where(query, args...).Order("created_at DESC").First(&x).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCancellationNotFound
+		}
+		return nil, err
+	}
+	return &x, nil
+}
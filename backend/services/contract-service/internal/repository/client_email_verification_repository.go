@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrEmailVerificationNotFound = errors.New("email verification code not found")
+)
+
+type ClientEmailVerificationRepository interface {
+	Create(ctx context.Context, v *domain.ClientEmailVerification) error
+	// FindLatestPending returns the most recent unverified code for the account and email (expired or not).
+	FindLatestPending(ctx context.Context, clientUserID uint, email string) (*domain.ClientEmailVerification, error)
+	IncrementAttempts(ctx context.Context, id uint) error
+	MarkVerified(ctx context.Context, id uint, at time.Time) error
+	IsVerified(ctx context.Context, clientUserID uint, email string) (bool, error)
+}
+
+type clientEmailVerificationRepository struct {
+	db *gorm.DB
+}
+
+func NewClientEmailVerificationRepository(db *gorm.DB) ClientEmailVerificationRepository {
+	return &clientEmailVerificationRepository{db: db}
+}
+
+func (r *clientEmailVerificationRepository) Create(ctx context.Context, v *domain.ClientEmailVerification) error {
+	return r.db.WithContext(ctx).Create(v).Error
+}
+
+func (r *clientEmailVerificationRepository) FindLatestPending(ctx context.Context, clientUserID uint, email string) (*domain.ClientEmailVerification, error) {
+	var v domain.ClientEmailVerification
+	err := r.db.WithContext(ctx).
+		Where("client_user_id = ? AND LOWER(email) = LOWER(?) AND verified_at IS NULL", clientUserID, email).
+		Order("created_at DESC").First(&v).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEmailVerificationNotFound
+		}
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *clientEmailVerificationRepository) IncrementAttempts(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&domain.ClientEmailVerification{}).Where("id = ?", id).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+func (r *clientEmailVerificationRepository) MarkVerified(ctx context.Context, id uint, at time.Time) error {
+	res := r.db.WithContext(ctx).Model(&domain.ClientEmailVerification{}).Where("id = ? AND verified_at IS NULL", id).
+		Update("verified_at", at)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrEmailVerificationNotFound
+	}
+	return nil
+}
+
+func (r *clientEmailVerificationRepository) IsVerified(ctx context.Context, clientUserID uint, email string) (bool, error) {
+	var n int64
+	err := r.db.WithContext(ctx).Model(&domain.ClientEmailVerification{}).
+		Where("client_user_id = ? AND LOWER(email) = LOWER(?) AND verified_at IS NOT NULL", clientUserID, email).
+		Count(&n).Error
+	return n > 0, err
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ContractEventsChannel is the Postgres NOTIFY channel for new contract events; the payload is the freelancer user ID.
+const ContractEventsChannel = "contract_events"
+
+// advisoryLockContractEvents namespaces the per-freelancer advisory lock taken while inserting events.
+const advisoryLockContractEvents int32 = 4501
+
+// ContractEventRepository stores the freelancer activity feed behind the live event stream.
+type ContractEventRepository interface {
+	// Create inserts the event and notifies ContractEventsChannel when the transaction commits.
+	Create(ctx context.Context, e *domain.ContractEvent) error
+	// ListAfter returns the freelancer's events with ID > afterID, oldest first. contractID 0 = all contracts.
+	ListAfter(ctx context.Context, freelancerUserID, contractID, afterID uint, limit int) ([]domain.ContractEvent, error)
+	// LatestID is the freelancer's newest event ID, 0 when there are none.
+	LatestID(ctx context.Context, freelancerUserID uint) (uint, error)
+	DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+type contractEventRepository struct {
+	db *gorm.DB
+}
+
+func NewContractEventRepository(db *gorm.DB) ContractEventRepository {
+	return &contractEventRepository{db: db}
+}
+
+func (r *contractEventRepository) Create(ctx context.Context, e *domain.ContractEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// one writer per freelancer at a time, so IDs commit in order and a reader resuming after an ID misses nothing
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", advisoryLockContractEvents, int32(e.FreelancerUserID)).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(e).Error; err != nil {
+			return err
+		}
+		return tx.Exec("SELECT pg_notify(?, ?)", ContractEventsChannel, strconv.FormatUint(uint64(e.FreelancerUserID), 10)).Error
+	})
+}
+
+func (r *contractEventRepository) ListAfter(ctx context.Context, freelancerUserID, contractID, afterID uint, limit int) ([]domain.ContractEvent, error) {
+	q := r.db.WithContext(ctx).Where("freelancer_user_id = ? AND id > ?", freelancerUserID, afterID)
+	if contractID != 0 {
+		q = q.Where("contract_id = ?", contractID)
+	}
+	var list []domain.ContractEvent
+	err := q.Order("id ASC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+func (r *contractEventRepository) LatestID(ctx context.Context, freelancerUserID uint) (uint, error) {
+	var id uint
+	err := r.db.WithContext(ctx).Model(&domain.ContractEvent{}).Where("freelancer_user_id = ?", freelancerUserID).
+		Select("COALESCE(MAX(id), 0)").Scan(&id).Error
+	return id, err
+}
+
+func (r *contractEventRepository) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).Where("created_at < ?", cutoff).Delete(&domain.ContractEvent{})
+	return res.RowsAffected, res.Error
+}
@@ -3,22 +3,87 @@ package repository
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/linktoken"
 	"gorm.io/gorm"
 )
 
 var (
 	ErrContractNotFound = errors.New("contract not found")
+	ErrVersionConflict  = errors.New("contract was changed by another request")
 )
 
+// nextVersion bumps contracts.version; every write to a contract sets it so the ETag changes.
+var nextVersion = gorm.Expr("version + 1")
+
+// viewColumns are maintained by RecordView only; saves of a loaded contract leave them alone so a concurrent
+// view is never overwritten with a stale count.
+var viewColumns = []string{"first_viewed_at", "last_viewed_at", "view_count"}
+
+// organizerColumns are the freelancer's private notes, archive flag and tags. They have their own endpoints, are not
+// terms and are not versioned, so saves of a loaded contract leave them alone too.
+var organizerColumns = []string{"private_notes", "archived_at", "Tags"}
+
+// unversionedColumns is what saveVersioned omits
+var unversionedColumns = append(append([]string{}, viewColumns...), organizerColumns...)
+
+// Archived filter values for ContractListFilter
+const (
+	ArchivedExclude = "false" // default: archived contracts are hidden
+	ArchivedOnly    = "true"
+	ArchivedInclude = "all"
+)
+
+// ContractListFilter narrows the freelancer's contract list. Empty fields do not filter; Archived defaults to ArchivedExclude.
+type ContractListFilter struct {
+	Status   string
+	Tag      string // lower-cased tag
+	Search   string // case-insensitive substring of project name, client name, client company or private notes
+	Archived string
+}
+
+// TagCount is one of a freelancer's tags with the number of contracts carrying it
+type TagCount struct {
+	Tag   string
+	Count int64
+}
+
+// pendingRequiredSignersSQL is true while the contract still has a required additional signer who has not signed.
+const pendingRequiredSignersSQL = "EXISTS (SELECT 1 FROM contract_signers s WHERE s.contract_id = contracts.id AND s.required AND s.signed_at IS NULL)"
+
+// whereClientToken narrows q to the contract a client link token points at. Signed tokens carry the contract ID
+// and its link nonce (client_view_token); older links are the bare nonce. Signatures are checked by
+// middleware.ClientLinkAuth; here a token only matches while the nonce does.
+func whereClientToken(q *gorm.DB, token string) *gorm.DB {
+	if c, ok := linktoken.Parse(token); ok {
+		return q.Where("id = ? AND client_view_token = ?", c.ContractID, c.Nonce)
+	}
+	return q.Where("client_view_token = ?", token)
+}
+
+func preloadSigners(db *gorm.DB) *gorm.DB {
+	return db.Order("order_index ASC")
+}
+
 type ContractRepository interface {
 	Create(ctx context.Context, c *domain.Contract, milestones []domain.ContractMilestone) error
 	GetByID(ctx context.Context, id uint, freelancerUserID uint) (*domain.Contract, error)
-	ListByFreelancer(ctx context.Context, freelancerUserID uint, status string, page, limit int) ([]*domain.Contract, int64, error)
-	Update(ctx context.Context, c *domain.Contract, milestones []domain.ContractMilestone) error
-	UpdateContractOnly(ctx context.Context, c *domain.Contract) error
+	// FindByID loads a contract without an owner check; for flows authorised by other means (signer tokens, events).
+	FindByID(ctx context.Context, id uint) (*domain.Contract, error)
+	ListByFreelancer(ctx context.Context, freelancerUserID uint, f ContractListFilter, page, limit int) ([]*domain.Contract, int64, error)
+	// SetTags replaces the contract's tags; ListTags returns the freelancer's tags with usage counts, most used first.
+	SetTags(ctx context.Context, id uint, freelancerUserID uint, tags []string) error
+	ListTags(ctx context.Context, freelancerUserID uint) ([]TagCount, error)
+	// UpdateNotes and SetArchived change the freelancer's own fields without a version bump. archivedAt nil restores.
+	UpdateNotes(ctx context.Context, id uint, freelancerUserID uint, notes string) error
+	SetArchived(ctx context.Context, id uint, freelancerUserID uint, archivedAt *time.Time) error
+	// Update and UpdateContractOnly save c (versioned); Update also replaces its milestones. signers, when not nil,
+	// replace the contract's signers in the same transaction.
+	Update(ctx context.Context, c *domain.Contract, milestones []domain.ContractMilestone, signers []domain.ContractSigner) error
+	UpdateContractOnly(ctx context.Context, c *domain.Contract, signers []domain.ContractSigner) error
 	UpdateStatus(ctx context.Context, id uint, freelancerUserID uint, status string) error
 	UpdateStatusAndSentAt(ctx context.Context, id uint, freelancerUserID uint, status string, sentAt *time.Time) error
 	UpdateStatusSentAtAndClientToken(ctx context.Context, id uint, freelancerUserID uint, status string, sentAt *time.Time, clientToken string) error
@@ -26,8 +91,24 @@ type ContractRepository interface {
 	ReplaceMilestones(ctx context.Context, contractID uint, milestones []domain.ContractMilestone) error
 	DeleteDraftsOlderThan(ctx context.Context, cutoff time.Time) (int64, error)
 	FindByClientViewToken(ctx context.Context, token string) (*domain.Contract, error)
+	// ClientTokenExists is a cheap check used to throttle token guessing before any full lookup.
+	ClientTokenExists(ctx context.Context, token string) (bool, error)
+	// RecordView stores an open of the client link and bumps the contract's view stats (without a version bump).
+	// viewCount is the count including this view, so 1 means the first.
+	RecordView(ctx context.Context, v *domain.ContractView) (viewCount int, err error)
+	// ListViews returns the contract's most recent link opens, newest first.
+	ListViews(ctx context.Context, contractID uint, limit int) ([]domain.ContractView, error)
 	UpdateToPendingByToken(ctx context.Context, token, comment string) error
-	UpdateToSignedByToken(ctx context.Context, token string, signedAt *time.Time, companyAddress, signMetadata string) error
+	// UpdateToSignedByToken records the client's sign. signature (optional) is the mark's attachment row, created in the
+	// same transaction; evidence is the signing evidence JSON and evidenceHash its sha256. signOTPID (0 = none) is the
+	// sign code checked for this sign; it is consumed in the same transaction (ErrSignOTPNotFound if already used).
+	UpdateToSignedByToken(ctx context.Context, token string, signedAt *time.Time, companyAddress, signMetadata, verification string, signerVerified, publicConsent bool, signature *domain.ContractAttachment, signatureType, evidence, evidenceHash string, signOTPID uint) error
+	// Countersign stores the freelancer's signing evidence and moves a signed contract to active.
+	Countersign(ctx context.Context, c *domain.Contract) error
+	// ClaimForClient links unclaimed, already-sent contracts addressed to email (case-insensitive) to the client account.
+	ClaimForClient(ctx context.Context, clientUserID uint, email string) (int64, error)
+	GetByIDForClient(ctx context.Context, id uint, clientUserID uint) (*domain.Contract, error)
+	ListByClient(ctx context.Context, clientUserID uint, status string, page, limit int) ([]*domain.Contract, int64, error)
 }
 
 type contractRepository struct {
@@ -58,7 +139,21 @@ func (r *contractRepository) GetByID(ctx context.Context, id uint, freelancerUse
 	var c domain.Contract
 	err := r.db.WithContext(ctx).Preload("Milestones", func(db *gorm.DB) *gorm.DB {
 		return db.Order("order_index ASC")
-	}).Where("id = ? AND freelancer_user_id = ?", id, freelancerUserID).First(&c).Error
+	}).Preload("Attachments").Preload("Signers", preloadSigners).Preload("Tags", preloadTags).Where("id = ? AND freelancer_user_id = ?", id, freelancerUserID).First(&c).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrContractNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *contractRepository) FindByID(ctx context.Context, id uint) (*domain.Contract, error) {
+	var c domain.Contract
+	err := r.db.WithContext(ctx).Preload("Milestones", func(db *gorm.DB) *gorm.DB {
+		return db.Order("order_index ASC")
+	}).Preload("Attachments").Preload("Signers", preloadSigners).Where("id = ?", id).First(&c).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrContractNotFound
@@ -68,10 +163,24 @@ func (r *contractRepository) GetByID(ctx context.Context, id uint, freelancerUse
 	return &c, nil
 }
 
-func (r *contractRepository) ListByFreelancer(ctx context.Context, freelancerUserID uint, status string, page, limit int) ([]*domain.Contract, int64, error) {
+func (r *contractRepository) ListByFreelancer(ctx context.Context, freelancerUserID uint, f ContractListFilter, page, limit int) ([]*domain.Contract, int64, error) {
 	q := r.db.WithContext(ctx).Model(&domain.Contract{}).Where("freelancer_user_id = ?", freelancerUserID)
-	if status != "" {
-		q = q.Where("status = ?", status)
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+	switch f.Archived {
+	case ArchivedOnly:
+		q = q.Where("archived_at IS NOT NULL")
+	case ArchivedInclude:
+	default:
+		q = q.Where("archived_at IS NULL")
+	}
+	if f.Tag != "" {
+		q = q.Where("EXISTS (SELECT 1 FROM contract_tags t WHERE t.contract_id = contracts.id AND t.tag = ?)", f.Tag)
+	}
+	if f.Search != "" {
+		like := "%" + escapeLike(f.Search) + "%"
+		q = q.Where("(project_name ILIKE ? OR client_name ILIKE ? OR client_company_name ILIKE ? OR private_notes ILIKE ?)", like, like, like, like)
 	}
 	var total int64
 	if err := q.Count(&total).Error; err != nil {
@@ -87,18 +196,87 @@ func (r *contractRepository) ListByFreelancer(ctx context.Context, freelancerUse
 	}
 	err := q.Preload("Milestones", func(db *gorm.DB) *gorm.DB {
 		return db.Order("order_index ASC")
-	}).Order("updated_at DESC").Offset(offset).Limit(limit).Find(&list).Error
+	}).Preload("Signers", preloadSigners).Preload("Tags", preloadTags).Order("updated_at DESC").Offset(offset).Limit(limit).Find(&list).Error
 	if err != nil {
 		return nil, 0, err
 	}
 	return list, total, nil
 }
 
-func (r *contractRepository) Update(ctx context.Context, c *domain.Contract, milestones []domain.ContractMilestone) error {
+func preloadTags(db *gorm.DB) *gorm.DB {
+	return db.Order("tag ASC")
+}
+
+// escapeLike escapes LIKE wildcards so s matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func (r *contractRepository) SetTags(ctx context.Context, id uint, freelancerUserID uint, tags []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var n int64
+		if err := tx.Model(&domain.Contract{}).Where("id = ? AND freelancer_user_id = ?", id, freelancerUserID).Count(&n).Error; err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrContractNotFound
+		}
+		if err := tx.Where("contract_id = ?", id).Delete(&domain.ContractTag{}).Error; err != nil {
+			return err
+		}
+		if len(tags) == 0 {
+			return nil
+		}
+		rows := make([]domain.ContractTag, len(tags))
+		for i, t := range tags {
+			rows[i] = domain.ContractTag{ContractID: id, FreelancerUserID: freelancerUserID, Tag: t}
+		}
+		return tx.Create(&rows).Error
+	})
+}
+
+func (r *contractRepository) ListTags(ctx context.Context, freelancerUserID uint) ([]TagCount, error) {
+	var out []TagCount
+	err := r.db.WithContext(ctx).Model(&domain.ContractTag{}).
+		Select("contract_tags.tag AS tag, COUNT(*) AS count").
+		Joins("JOIN contracts ON contracts.id = contract_tags.contract_id AND contracts.deleted_at IS NULL").
+		Where("contract_tags.freelancer_user_id = ?", freelancerUserID).
+		Group("contract_tags.tag").Order("count DESC, tag ASC").Scan(&out).Error
+	return out, err
+}
+
+func (r *contractRepository) UpdateNotes(ctx context.Context, id uint, freelancerUserID uint, notes string) error {
+	return r.updateOrganizer(ctx, id, freelancerUserID, "private_notes", notes)
+}
+
+func (r *contractRepository) SetArchived(ctx context.Context, id uint, freelancerUserID uint, archivedAt *time.Time) error {
+	return r.updateOrganizer(ctx, id, freelancerUserID, "archived_at", archivedAt)
+}
+
+// updateOrganizer sets one of organizerColumns. UpdateColumn skips updated_at too, so the list order is unchanged.
+func (r *contractRepository) updateOrganizer(ctx context.Context, id uint, freelancerUserID uint, column string, value interface{}) error {
+	res := r.db.WithContext(ctx).Model(&domain.Contract{}).
+		Where("id = ? AND freelancer_user_id = ?", id, freelancerUserID).
+		UpdateColumn(column, value)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrContractNotFound
+	}
+	return nil
+}
+
+func (r *contractRepository) Update(ctx context.Context, c *domain.Contract, milestones []domain.ContractMilestone, signers []domain.ContractSigner) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Save(c).Error; err != nil {
+		if err := saveVersioned(tx, c); err != nil {
 			return err
 		}
+		if signers != nil {
+			if err := replaceSigners(tx, c.ID, signers); err != nil {
+				return err
+			}
+		}
 		if err := tx.Where("contract_id = ?", c.ID).Delete(&domain.ContractMilestone{}).Error; err != nil {
 			return err
 		}
@@ -113,14 +291,40 @@ func (r *contractRepository) Update(ctx context.Context, c *domain.Contract, mil
 	})
 }
 
-func (r *contractRepository) UpdateContractOnly(ctx context.Context, c *domain.Contract) error {
-	return r.db.WithContext(ctx).Save(c).Error
+func (r *contractRepository) UpdateContractOnly(ctx context.Context, c *domain.Contract, signers []domain.ContractSigner) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := saveVersioned(tx, c); err != nil {
+			return err
+		}
+		if signers == nil {
+			return nil
+		}
+		return replaceSigners(tx, c.ID, signers)
+	})
+}
+
+// saveVersioned saves c only if the stored version is still c.Version, else ErrVersionConflict. The version bump
+// locks the row, so a concurrent save waits and then fails the check. On success c.Version is the new version.
+func saveVersioned(tx *gorm.DB, c *domain.Contract) error {
+	res := tx.Model(&domain.Contract{}).Where("id = ? AND version = ?", c.ID, c.Version).Update("version", nextVersion)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	c.Version++
+	if err := tx.Omit(unversionedColumns...).Save(c).Error; err != nil {
+		c.Version--
+		return err
+	}
+	return nil
 }
 
 func (r *contractRepository) UpdateStatus(ctx context.Context, id uint, freelancerUserID uint, status string) error {
 	res := r.db.WithContext(ctx).Model(&domain.Contract{}).
 		Where("id = ? AND freelancer_user_id = ?", id, freelancerUserID).
-		Update("status", status)
+		Updates(map[string]interface{}{"status": status, "version": nextVersion})
 	if res.Error != nil {
 		return res.Error
 	}
@@ -131,7 +335,7 @@ func (r *contractRepository) UpdateStatus(ctx context.Context, id uint, freelanc
 }
 
 func (r *contractRepository) UpdateStatusAndSentAt(ctx context.Context, id uint, freelancerUserID uint, status string, sentAt *time.Time) error {
-	updates := map[string]interface{}{"status": status}
+	updates := map[string]interface{}{"status": status, "version": nextVersion}
 	if sentAt != nil {
 		updates["sent_at"] = sentAt
 	}
@@ -148,7 +352,7 @@ func (r *contractRepository) UpdateStatusAndSentAt(ctx context.Context, id uint,
 }
 
 func (r *contractRepository) UpdateStatusSentAtAndClientToken(ctx context.Context, id uint, freelancerUserID uint, status string, sentAt *time.Time, clientToken string) error {
-	updates := map[string]interface{}{"status": status, "client_view_token": clientToken}
+	updates := map[string]interface{}{"status": status, "client_view_token": clientToken, "version": nextVersion}
 	if sentAt != nil {
 		updates["sent_at"] = sentAt
 	}
@@ -201,8 +405,12 @@ func (r *contractRepository) DeleteDraftsOlderThan(ctx context.Context, cutoff t
 		if len(ids) == 0 {
 			return nil
 		}
-		if err := tx.Where("contract_id IN ?", ids).Unscoped().Delete(&domain.ContractMilestone{}).Error; err != nil {
-			return err
+		// children first: each has a foreign key to contracts (attachments also to milestones). Attachment blobs
+		// are content-addressed and may be shared, so they are kept, as on attachment delete.
+		for _, child := range []interface{}{&domain.ContractSigner{}, &domain.ContractTag{}, &domain.ContractAttachment{}, &domain.ContractMilestone{}} {
+			if err := tx.Where("contract_id IN ?", ids).Unscoped().Delete(child).Error; err != nil {
+				return err
+			}
 		}
 		res := tx.Where("id IN ?", ids).Unscoped().Delete(&domain.Contract{})
 		if res.Error != nil {
@@ -219,9 +427,10 @@ func (r *contractRepository) FindByClientViewToken(ctx context.Context, token st
 		return nil, ErrContractNotFound
 	}
 	var c domain.Contract
-	err := r.db.WithContext(ctx).Preload("Milestones", func(db *gorm.DB) *gorm.DB {
+	q := r.db.WithContext(ctx).Preload("Milestones", func(db *gorm.DB) *gorm.DB {
 		return db.Order("order_index ASC")
-	}).Where("client_view_token = ?", token).First(&c).Error
+	}).Preload("Attachments").Preload("Signers", preloadSigners)
+	err := whereClientToken(q, token).First(&c).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrContractNotFound
@@ -231,10 +440,48 @@ func (r *contractRepository) FindByClientViewToken(ctx context.Context, token st
 	return &c, nil
 }
 
+func (r *contractRepository) ClientTokenExists(ctx context.Context, token string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+	var n int64
+	err := whereClientToken(r.db.WithContext(ctx).Model(&domain.Contract{}), token).Limit(1).Count(&n).Error
+	return n > 0, err
+}
+
+func (r *contractRepository) RecordView(ctx context.Context, v *domain.ContractView) (int, error) {
+	var count int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domain.Contract{}).Where("id = ?", v.ContractID).UpdateColumns(map[string]interface{}{
+			"first_viewed_at": gorm.Expr("COALESCE(first_viewed_at, ?)", v.ViewedAt),
+			"last_viewed_at":  v.ViewedAt,
+			"view_count":      gorm.Expr("view_count + 1"),
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrContractNotFound
+		}
+		if err := tx.Create(v).Error; err != nil {
+			return err
+		}
+		// the row stays locked by the update, so this is the count including this view
+		return tx.Model(&domain.Contract{}).Where("id = ?", v.ContractID).Select("view_count").Scan(&count).Error
+	})
+	return count, err
+}
+
+func (r *contractRepository) ListViews(ctx context.Context, contractID uint, limit int) ([]domain.ContractView, error) {
+	var list []domain.ContractView
+	err := r.db.WithContext(ctx).Where("contract_id = ?", contractID).Order("viewed_at DESC, id DESC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
 func (r *contractRepository) UpdateToPendingByToken(ctx context.Context, token, comment string) error {
-	res := r.db.WithContext(ctx).Model(&domain.Contract{}).
-		Where("client_view_token = ? AND status = ?", token, domain.ContractStatusSent).
-		Updates(map[string]interface{}{"status": domain.ContractStatusPending, "client_review_comment": comment})
+	res := whereClientToken(r.db.WithContext(ctx).Model(&domain.Contract{}), token).
+		Where("status = ?", domain.ContractStatusSent).
+		Updates(map[string]interface{}{"status": domain.ContractStatusPending, "client_review_comment": comment, "version": nextVersion})
 	if res.Error != nil {
 		return res.Error
 	}
@@ -244,14 +491,102 @@ func (r *contractRepository) UpdateToPendingByToken(ctx context.Context, token,
 	return nil
 }
 
-func (r *contractRepository) UpdateToSignedByToken(ctx context.Context, token string, signedAt *time.Time, companyAddress, signMetadata string) error {
-	updates := map[string]interface{}{"status": domain.ContractStatusSigned, "client_company_address": companyAddress, "client_sign_metadata": signMetadata}
+func (r *contractRepository) UpdateToSignedByToken(ctx context.Context, token string, signedAt *time.Time, companyAddress, signMetadata, verification string, signerVerified, publicConsent bool, signature *domain.ContractAttachment, signatureType, evidence, evidenceHash string, signOTPID uint) error {
+	// signed only when no required additional signer is still outstanding
+	status := gorm.Expr("CASE WHEN "+pendingRequiredSignersSQL+" THEN ? ELSE ? END", domain.ContractStatusPartial, domain.ContractStatusSigned)
+	updates := map[string]interface{}{"status": status, "client_company_address": companyAddress, "client_sign_metadata": signMetadata, "client_verification": verification, "client_signer_verified": signerVerified, "client_public_consent": publicConsent, "client_sign_evidence": evidence, "client_evidence_hash": evidenceHash, "version": nextVersion}
 	if signedAt != nil {
 		updates["client_signed_at"] = signedAt
+		if publicConsent {
+			updates["client_public_consent_at"] = signedAt
+		}
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if signOTPID != 0 {
+			if err := consumeSignOTP(tx, signOTPID, time.Now()); err != nil {
+				return err
+			}
+		}
+		if signature != nil {
+			if err := tx.Create(signature).Error; err != nil {
+				return err
+			}
+			updates["client_signature_id"] = signature.ID
+			updates["client_signature_type"] = signatureType
+		}
+		res := whereClientToken(tx.Model(&domain.Contract{}), token).
+			Where("status IN ? AND client_signed_at IS NULL", []string{domain.ContractStatusSent, domain.ContractStatusPartial}).
+			Updates(updates)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			// rolls back the signature row too
+			return ErrContractNotFound
+		}
+		return nil
+	})
+}
+
+func (r *contractRepository) ClaimForClient(ctx context.Context, clientUserID uint, email string) (int64, error) {
+	res := r.db.WithContext(ctx).Model(&domain.Contract{}).
+		Where("LOWER(client_email) = LOWER(?) AND client_user_id IS NULL AND status <> ?", email, domain.ContractStatusDraft).
+		Updates(map[string]interface{}{"client_user_id": clientUserID, "version": nextVersion})
+	return res.RowsAffected, res.Error
+}
+
+func (r *contractRepository) GetByIDForClient(ctx context.Context, id uint, clientUserID uint) (*domain.Contract, error) {
+	var c domain.Contract
+	err := r.db.WithContext(ctx).Preload("Milestones", func(db *gorm.DB) *gorm.DB {
+		return db.Order("order_index ASC")
+	}).Preload("Attachments").Preload("Signers", preloadSigners).Where("id = ? AND client_user_id = ?", id, clientUserID).First(&c).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrContractNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *contractRepository) ListByClient(ctx context.Context, clientUserID uint, status string, page, limit int) ([]*domain.Contract, int64, error) {
+	q := r.db.WithContext(ctx).Model(&domain.Contract{}).Where("client_user_id = ?", clientUserID)
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
 	}
+	var list []*domain.Contract
+	err := q.Preload("Milestones", func(db *gorm.DB) *gorm.DB {
+		return db.Order("order_index ASC")
+	}).Preload("Signers", preloadSigners).Order("updated_at DESC").Offset(offset).Limit(limit).Find(&list).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+func (r *contractRepository) Countersign(ctx context.Context, c *domain.Contract) error {
 	res := r.db.WithContext(ctx).Model(&domain.Contract{}).
-		Where("client_view_token = ? AND status = ?", token, domain.ContractStatusSent).
-		Updates(updates)
+		Where("id = ? AND freelancer_user_id = ? AND status = ?", c.ID, c.FreelancerUserID, domain.ContractStatusSigned).
+		Updates(map[string]interface{}{
+			"status":                       domain.ContractStatusActive,
+			"freelancer_signed_at":         c.FreelancerSignedAt,
+			"freelancer_signed_name":       c.FreelancerSignedName,
+			"freelancer_signer_email":      c.FreelancerSignerEmail,
+			"freelancer_signer_ip":         c.FreelancerSignerIP,
+			"freelancer_signer_user_agent": c.FreelancerSignerUserAgent,
+			"version":                      nextVersion,
+		})
 	if res.Error != nil {
 		return res.Error
 	}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrDisputeNotFound = errors.New("dispute not found")
+)
+
+type DisputeRepository interface {
+	// Create stores the dispute and its opening message.
+	Create(ctx context.Context, d *domain.ContractDispute, first *domain.ContractDisputeMessage) error
+	// GetByID loads a dispute of the contract with its thread.
+	GetByID(ctx context.Context, contractID, disputeID uint) (*domain.ContractDispute, error)
+	// FindByID loads any dispute with its thread (admin).
+	FindByID(ctx context.Context, disputeID uint) (*domain.ContractDispute, error)
+	ListByContract(ctx context.Context, contractID uint) ([]*domain.ContractDispute, error)
+	ListByStatus(ctx context.Context, status string, page, limit int) ([]*domain.ContractDispute, int64, error)
+	// FindActive returns the contract's unresolved dispute, if any.
+	FindActive(ctx context.Context, contractID uint) (*domain.ContractDispute, error)
+	// AddMessage appends msg and applies updates to the dispute while it is unresolved; ErrDisputeNotFound otherwise.
+	AddMessage(ctx context.Context, msg *domain.ContractDisputeMessage, updates map[string]interface{}) error
+	// UpdateState applies updates when the dispute is in one of fromStatuses.
+	UpdateState(ctx context.Context, disputeID uint, fromStatuses []string, updates map[string]interface{}) error
+	// ListOverdue returns open / awaiting_response disputes whose deadline passed.
+	ListOverdue(ctx context.Context, now time.Time, limit int) ([]*domain.ContractDispute, error)
+}
+
+type disputeRepository struct {
+	db *gorm.DB
+}
+
+func NewDisputeRepository(db *gorm.DB) DisputeRepository {
+	return &disputeRepository{db: db}
+}
+
+func preloadDisputeMessages(db *gorm.DB) *gorm.DB {
+	return db.Order("created_at ASC, id ASC")
+}
+
+func (r *disputeRepository) Create(ctx context.Context, d *domain.ContractDispute, first *domain.ContractDisputeMessage) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Messages").Create(d).Error; err != nil {
+			return err
+		}
+		first.DisputeID = d.ID
+		return tx.Create(first).Error
+	})
+}
+
+func (r *disputeRepository) GetByID(ctx context.Context, contractID, disputeID uint) (*domain.ContractDispute, error) {
+	return r.findOne(r.db.WithContext(ctx).Preload("Messages", preloadDisputeMessages).Where("id = ? AND contract_id = ?", disputeID, contractID))
+}
+
+func (r *disputeRepository) FindByID(ctx context.Context, disputeID uint) (*domain.ContractDispute, error) {
+	return r.findOne(r.db.WithContext(ctx).Preload("Messages", preloadDisputeMessages).Where("id = ?", disputeID))
+}
+
+func (r *disputeRepository) FindActive(ctx context.Context, contractID uint) (*domain.ContractDispute, error) {
+	return r.findOne(r.db.WithContext(ctx).Where("contract_id = ? AND status <> ?", contractID, domain.DisputeStatusResolved).Order("created_at DESC"))
+}
+
+func (r *disputeRepository) findOne(q *gorm.DB) (*domain.ContractDispute, error) {
+	var d domain.ContractDispute
+	if err := q.First(&d).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDisputeNotFound
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *disputeRepository) ListByContract(ctx context.Context, contractID uint) ([]*domain.ContractDispute, error) {
+	var list []*domain.ContractDispute
+	err := r.db.WithContext(ctx).Where("contract_id = ?", contractID).Order("created_at DESC").Find(&list).Error
+	return list, err
+}
+
+func (r *disputeRepository) ListByStatus(ctx context.Context, status string, page, limit int) ([]*domain.ContractDispute, int64, error) {
+	q := r.db.WithContext(ctx).Model(&domain.ContractDispute{})
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+	var list []*domain.ContractDispute
+	// oldest deadline first: what needs attention soonest
+	err := q.Order("due_at ASC NULLS LAST, created_at ASC").Offset(offset).Limit(limit).Find(&list).Error
+	return list, total, err
+}
+
+func (r *disputeRepository) AddMessage(ctx context.Context, msg *domain.ContractDisputeMessage, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domain.ContractDispute{}).
+			Where("id = ? AND status <> ?", msg.DisputeID, domain.DisputeStatusResolved).
+			Updates(updates)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrDisputeNotFound
+		}
+		return tx.Create(msg).Error
+	})
+}
+
+func (r *disputeRepository) UpdateState(ctx context.Context, disputeID uint, fromStatuses []string, updates map[string]interface{}) error {
+	res := r.db.WithContext(ctx).Model(&domain.ContractDispute{}).
+		Where("id = ? AND status IN ?", disputeID, fromStatuses).
+		Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrDisputeNotFound
+	}
+	return nil
+}
+
+func (r *disputeRepository) ListOverdue(ctx context.Context, now time.Time, limit int) ([]*domain.ContractDispute, error) {
+	var list []*domain.ContractDispute
+	err := r.db.WithContext(ctx).
+		Where("status IN ? AND due_at IS NOT NULL AND due_at < ?", []string{domain.DisputeStatusOpen, domain.DisputeStatusAwaitingResponse}, now).
+		Order("due_at ASC").Limit(limit).Find(&list).Error
+	return list, err
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+)
+
+// IdempotencyRepository stores responses by Idempotency-Key; it implements middleware.IdempotencyStore.
+type IdempotencyRepository interface {
+	// Reserve inserts rec unless its scope and key exist. When they do, the stored row is returned and reserved is
+	// false; an expired row is replaced instead.
+	Reserve(ctx context.Context, rec *domain.IdempotencyKey) (existing *domain.IdempotencyKey, reserved bool, err error)
+	Complete(ctx context.Context, id uint, statusCode int, contentType string, body []byte) error
+	// Release deletes an in-flight reservation so the request can be retried.
+	Release(ctx context.Context, id uint) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+func (r *idempotencyRepository) Reserve(ctx context.Context, rec *domain.IdempotencyKey) (*domain.IdempotencyKey, bool, error) {
+	// two tries: the second follows removing an expired row the cleanup job has not reached yet
+	for i := 0; i < 2; i++ {
+		res := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(rec)
+		if res.Error != nil {
+			return nil, false, res.Error
+		}
+		if res.RowsAffected == 1 {
+			return nil, true, nil
+		}
+		var existing domain.IdempotencyKey
+		err := r.db.WithContext(ctx).Where("scope = ? AND key = ?", rec.Scope, rec.Key).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		if existing.ExpiresAt.After(time.Now()) {
+			return &existing, false, nil
+		}
+		if err := r.db.WithContext(ctx).Where("id = ? AND expires_at <= ?", existing.ID, time.Now()).
+			Delete(&domain.IdempotencyKey{}).Error; err != nil {
+			return nil, false, err
+		}
+		rec.ID = 0
+	}
+	return nil, false, ErrIdempotencyKeyNotFound
+}
+
+func (r *idempotencyRepository) Complete(ctx context.Context, id uint, statusCode int, contentType string, body []byte) error {
+	return r.db.WithContext(ctx).Model(&domain.IdempotencyKey{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status_code":   statusCode,
+		"content_type":  contentType,
+		"response_body": body,
+	}).Error
+}
+
+func (r *idempotencyRepository) Release(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Where("id = ? AND status_code = 0", id).Delete(&domain.IdempotencyKey{}).Error
+}
+
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&domain.IdempotencyKey{})
+	return res.RowsAffected, res.Error
+}
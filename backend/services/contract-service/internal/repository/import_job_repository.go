@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrImportJobNotFound = errors.New("import job not found")
+)
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *domain.ContractImportJob) error
+	GetByID(ctx context.Context, id uint, freelancerUserID uint) (*domain.ContractImportJob, error)
+	// CommitDrafts creates all contracts (with their milestones) and marks the job completed in one transaction.
+	// If any insert fails nothing is created and the error is returned; the caller marks the job failed.
+	CommitDrafts(ctx context.Context, jobID uint, contracts []*domain.Contract, milestones [][]domain.ContractMilestone) error
+	MarkFailed(ctx context.Context, jobID uint, message string) error
+}
+
+type importJobRepository struct {
+	db *gorm.DB
+}
+
+func NewImportJobRepository(db *gorm.DB) ImportJobRepository {
+	return &importJobRepository{db: db}
+}
+
+func (r *importJobRepository) Create(ctx context.Context, job *domain.ContractImportJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *importJobRepository) GetByID(ctx context.Context, id uint, freelancerUserID uint) (*domain.ContractImportJob, error) {
+	var job domain.ContractImportJob
+	err := r.db.WithContext(ctx).Where("id = ? AND freelancer_user_id = ?", id, freelancerUserID).First(&job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrImportJobNotFound
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *importJobRepository) CommitDrafts(ctx context.Context, jobID uint, contracts []*domain.Contract, milestones [][]domain.ContractMilestone) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ids := make([]uint, 0, len(contracts))
+		for i, c := range contracts {
+			if err := tx.Create(c).Error; err != nil {
+				return err
+			}
+			for j := range milestones[i] {
+				milestones[i][j].ContractID = c.ID
+				milestones[i][j].OrderIndex = j
+				if err := tx.Create(&milestones[i][j]).Error; err != nil {
+					return err
+				}
+			}
+			ids = append(ids, c.ID)
+		}
+		idsJSON, _ := json.Marshal(ids)
+		now := time.Now()
+		return tx.Model(&domain.ContractImportJob{}).Where("id = ?", jobID).Updates(map[string]interface{}{
+			"status":         domain.ImportJobStatusCompleted,
+			"imported_count": len(ids),
+			"contract_ids":   string(idsJSON),
+			"completed_at":   &now,
+		}).Error
+	})
+}
+
+func (r *importJobRepository) MarkFailed(ctx context.Context, jobID uint, message string) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).Model(&domain.ContractImportJob{}).Where("id = ?", jobID).Updates(map[string]interface{}{
+		"status":        domain.ImportJobStatusFailed,
+		"error_message": message,
+		"completed_at":  &now,
+	}).Error
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrMilestoneNotFound = errors.New("milestone not found")
+)
+
+// openMilestonesSQL is true while the contract still has a milestone that is neither approved nor paid.
+const openMilestonesSQL = "EXISTS (SELECT 1 FROM contract_milestones m WHERE m.contract_id = contracts.id AND m.deleted_at IS NULL AND m.status NOT IN ('approved', 'paid'))"
+
+// autoCompletableSQL is true for contracts that complete with their last milestone: fixed contracts, and retainers
+// whose last period was generated. Hourly and open-ended retainer contracts are completed explicitly.
+const autoCompletableSQL = "(contracts.contract_type = 'fixed' OR (contracts.contract_type = 'retainer' AND contracts.retainer_cycles > 0 AND contracts.retainer_cycles_generated >= contracts.retainer_cycles))"
+
+// pendingTimesheetSQL is true while the contract has time the client has not approved or rejected.
+const pendingTimesheetSQL = "EXISTS (SELECT 1 FROM contract_timesheet_entries t WHERE t.contract_id = contracts.id AND t.status = 'pending')"
+
+// retainerDueSQL is true for retainers whose next period has started: the first one starts when the client signs.
+const retainerDueSQL = "contract_type = 'retainer' AND (retainer_cycles = 0 OR retainer_cycles_generated < retainer_cycles) AND COALESCE(next_cycle_at, client_signed_at) <= ?"
+
+type MilestoneRepository interface {
+	// Submit moves a milestone from fromStatus to submitted; ErrMilestoneNotFound when it is no longer in fromStatus.
+	Submit(ctx context.Context, m *domain.ContractMilestone, fromStatus string) error
+	// RequestChanges sends a submitted milestone back to pending and counts a revision round; ErrMilestoneNotFound
+	// when it is no longer submitted.
+	RequestChanges(ctx context.Context, m *domain.ContractMilestone) error
+	// Approve moves a submitted milestone to approved. In the same transaction the contract becomes completed when
+	// it is in one of activeStatuses and no open milestone is left; completed reports whether that happened.
+	// Only contracts that complete with their last milestone (fixed, fixed-length retainer) are completed here.
+	Approve(ctx context.Context, m *domain.ContractMilestone, activeStatuses []string) (completed bool, err error)
+	// Complete completes a contract in one of activeStatuses that has no open milestone and no pending timesheet
+	// entry; completed is false when any of that does not hold.
+	Complete(ctx context.Context, contractID uint, at time.Time, activeStatuses []string) (completed bool, err error)
+	// ListRetainersDue returns retainer contracts in one of activeStatuses whose next period started by now.
+	ListRetainersDue(ctx context.Context, now time.Time, activeStatuses []string, limit int) ([]*domain.Contract, error)
+	// AddRetainerPeriod adds m as the contract's next period milestone, counts the cycle, moves next_cycle_at to
+	// nextCycleAt and adds m.Amount to the total. added is false when another run already generated this cycle.
+	AddRetainerPeriod(ctx context.Context, c *domain.Contract, m *domain.ContractMilestone, nextCycleAt time.Time) (added bool, err error)
+	// ListCompletionUnpublished returns completed contracts (with milestones) whose completion event is not yet delivered.
+	ListCompletionUnpublished(ctx context.Context, completedAfter time.Time, limit int) ([]*domain.Contract, error)
+	MarkCompletionPublished(ctx context.Context, contractID uint, at time.Time) error
+}
+
+type milestoneRepository struct {
+	db *gorm.DB
+}
+
+func NewMilestoneRepository(db *gorm.DB) MilestoneRepository {
+	return &milestoneRepository{db: db}
+}
+
+func (r *milestoneRepository) Submit(ctx context.Context, m *domain.ContractMilestone, fromStatus string) error {
+	res := r.db.WithContext(ctx).Model(&domain.ContractMilestone{}).
+		Where("id = ? AND contract_id = ? AND status = ?", m.ID, m.ContractID, fromStatus).
+		Updates(map[string]interface{}{
+			"status":          domain.MilestoneStatusSubmitted,
+			"submitted_at":    m.SubmittedAt,
+			"submission_note": m.SubmissionNote,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrMilestoneNotFound
+	}
+	return nil
+}
+
+func (r *milestoneRepository) RequestChanges(ctx context.Context, m *domain.ContractMilestone) error {
+	res := r.db.WithContext(ctx).Model(&domain.ContractMilestone{}).
+		Where("id = ? AND contract_id = ? AND status = ?", m.ID, m.ContractID, domain.MilestoneStatusSubmitted).
+		Updates(map[string]interface{}{
+			"status":         domain.MilestoneStatusPending,
+			"revision_count": gorm.Expr("revision_count + 1"),
+			"changes_note":   m.ChangesNote,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrMilestoneNotFound
+	}
+	return nil
+}
+
+func (r *milestoneRepository) Approve(ctx context.Context, m *domain.ContractMilestone, activeStatuses []string) (bool, error) {
+	completed := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domain.ContractMilestone{}).
+			Where("id = ? AND contract_id = ? AND status = ?", m.ID, m.ContractID, domain.MilestoneStatusSubmitted).
+			Updates(map[string]interface{}{"status": domain.MilestoneStatusApproved, "approved_at": m.ApprovedAt})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrMilestoneNotFound
+		}
+		res = tx.Model(&domain.Contract{}).
+			Where("id = ? AND status IN ? AND "+autoCompletableSQL+" AND NOT "+openMilestonesSQL, m.ContractID, activeStatuses).
+			Updates(map[string]interface{}{"status": domain.ContractStatusDone, "completed_at": m.ApprovedAt, "version": nextVersion})
+		if res.Error != nil {
+			return res.Error
+		}
+		completed = res.RowsAffected > 0
+		return nil
+	})
+	return completed, err
+}
+
+func (r *milestoneRepository) Complete(ctx context.Context, contractID uint, at time.Time, activeStatuses []string) (bool, error) {
+	res := r.db.WithContext(ctx).Model(&domain.Contract{}).
+		Where("id = ? AND status IN ? AND NOT "+openMilestonesSQL+" AND NOT "+pendingTimesheetSQL, contractID, activeStatuses).
+		Updates(map[string]interface{}{"status": domain.ContractStatusDone, "completed_at": at, "version": nextVersion})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *milestoneRepository) ListRetainersDue(ctx context.Context, now time.Time, activeStatuses []string, limit int) ([]*domain.Contract, error) {
+	var list []*domain.Contract
+	err := r.db.WithContext(ctx).Where("status IN ? AND "+retainerDueSQL, activeStatuses, now).
+		Order("id ASC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+func (r *milestoneRepository) AddRetainerPeriod(ctx context.Context, c *domain.Contract, m *domain.ContractMilestone, nextCycleAt time.Time) (bool, error) {
+	added := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the generated count doubles as the claim: a concurrent run for the same cycle, or a contract cancelled
+		// meanwhile, updates nothing
+		res := tx.Model(&domain.Contract{}).
+			Where("id = ? AND status = ? AND retainer_cycles_generated = ?", c.ID, c.Status, c.RetainerCyclesGenerated).
+			Updates(map[string]interface{}{
+				"retainer_cycles_generated": gorm.Expr("retainer_cycles_generated + 1"),
+				"next_cycle_at":             nextCycleAt,
+				"total_amount":              gorm.Expr("total_amount + ?", m.Amount),
+				"version":                   nextVersion,
+			})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		var next int
+		if err := tx.Model(&domain.ContractMilestone{}).Select("COALESCE(MAX(order_index), -1) + 1").
+			Where("contract_id = ?", c.ID).Scan(&next).Error; err != nil {
+			return err
+		}
+		m.ContractID = c.ID
+		m.OrderIndex = next
+		if err := tx.Create(m).Error; err != nil {
+			return err
+		}
+		added = true
+		return nil
+	})
+	return added, err
+}
+
+func (r *milestoneRepository) ListCompletionUnpublished(ctx context.Context, completedAfter time.Time, limit int) ([]*domain.Contract, error) {
+	var list []*domain.Contract
+	err := r.db.WithContext(ctx).Preload("Milestones", func(db *gorm.DB) *gorm.DB {
+		return db.Order("order_index ASC")
+	}).Where("status = ? AND completion_published_at IS NULL AND completed_at > ?", domain.ContractStatusDone, completedAfter).
+		Order("completed_at ASC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+func (r *milestoneRepository) MarkCompletionPublished(ctx context.Context, contractID uint, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&domain.Contract{}).Where("id = ?", contractID).Update("completion_published_at", at).Error
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrReputationNotFound = errors.New("reputation score not found")
+)
+
+type ReputationRepository interface {
+	// SaveContractScore inserts or replaces the score of r.ContractID.
+	SaveContractScore(ctx context.Context, r *domain.ContractReputation) error
+	GetContractScore(ctx context.Context, contractID uint) (*domain.ContractReputation, error)
+	ListContractScores(ctx context.Context, freelancerUserID uint) ([]*domain.ContractReputation, error)
+	// ListUnscoredContracts returns IDs of completed contracts without a score from formulaVersion, oldest first.
+	ListUnscoredContracts(ctx context.Context, formulaVersion int, limit int) ([]uint, error)
+	// SaveAggregate inserts or replaces the freelancer's aggregate and marks it unpublished.
+	SaveAggregate(ctx context.Context, a *domain.FreelancerReputation) error
+	GetAggregate(ctx context.Context, freelancerUserID uint) (*domain.FreelancerReputation, error)
+	ListUnpublishedAggregates(ctx context.Context, limit int) ([]*domain.FreelancerReputation, error)
+	// MarkAggregatePublished sets published_at unless the aggregate changed after updatedAt.
+	MarkAggregatePublished(ctx context.Context, freelancerUserID uint, updatedAt, at time.Time) error
+}
+
+type reputationRepository struct {
+	db *gorm.DB
+}
+
+func NewReputationRepository(db *gorm.DB) ReputationRepository {
+	return &reputationRepository{db: db}
+}
+
+func (r *reputationRepository) SaveContractScore(ctx context.Context, s *domain.ContractReputation) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "contract_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"freelancer_user_id", "formula_version", "score", "inputs", "components", "computed_at"}),
+	}).Create(s).Error
+}
+
+func (r *reputationRepository) GetContractScore(ctx context.Context, contractID uint) (*domain.ContractReputation, error) {
+	var s domain.ContractReputation
+	err := r.db.WithContext(ctx).Where("contract_id = ?", contractID).First(&s).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReputationNotFound
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *reputationRepository) ListContractScores(ctx context.Context, freelancerUserID uint) ([]*domain.ContractReputation, error) {
+	var list []*domain.ContractReputation
+	err := r.db.WithContext(ctx).Where("freelancer_user_id = ?", freelancerUserID).Order("computed_at DESC").Find(&list).Error
+	return list, err
+}
+
+func (r *reputationRepository) ListUnscoredContracts(ctx context.Context, formulaVersion int, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).Model(&domain.Contract{}).
+		Joins("LEFT JOIN contract_reputations cr ON cr.contract_id = contracts.id").
+		Where("contracts.status = ? AND (cr.id IS NULL OR cr.formula_version <> ?)", domain.ContractStatusDone, formulaVersion).
+		Order("contracts.completed_at ASC").Limit(limit).Pluck("contracts.id", &ids).Error
+	return ids, err
+}
+
+func (r *reputationRepository) SaveAggregate(ctx context.Context, a *domain.FreelancerReputation) error {
+	a.PublishedAt = nil
+	// Postgres keeps microseconds; truncate so MarkAggregatePublished can match updated_at exactly
+	a.UpdatedAt = a.UpdatedAt.Truncate(time.Microsecond)
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "freelancer_user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"formula_version", "score", "contracts_scored", "updated_at", "published_at"}),
+	}).Create(a).Error
+}
+
+func (r *reputationRepository) GetAggregate(ctx context.Context, freelancerUserID uint) (*domain.FreelancerReputation, error) {
+	var a domain.FreelancerReputation
+	err := r.db.WithContext(ctx).Where("freelancer_user_id = ?", freelancerUserID).First(&a).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReputationNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *reputationRepository) ListUnpublishedAggregates(ctx context.Context, limit int) ([]*domain.FreelancerReputation, error) {
+	var list []*domain.FreelancerReputation
+	err := r.db.WithContext(ctx).Where("published_at IS NULL").Order("updated_at ASC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+func (r *reputationRepository) MarkAggregatePublished(ctx context.Context, freelancerUserID uint, updatedAt, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&domain.FreelancerReputation{}).
+		Where("freelancer_user_id = ? AND updated_at = ?", freelancerUserID, updatedAt).
+		UpdateColumn("published_at", at).Error
+}
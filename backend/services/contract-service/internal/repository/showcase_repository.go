@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+type ShowcaseRepository interface {
+	// ListShowcased returns the freelancer's completed contracts whose visibility is not hidden, newest first, with milestones.
+	ListShowcased(ctx context.Context, freelancerUserID uint, limit int) ([]*domain.Contract, error)
+	// SetProfileVisibility updates the freelancer's contract; ErrContractNotFound when it is not theirs.
+	SetProfileVisibility(ctx context.Context, contractID, freelancerUserID uint, visibility string) error
+}
+
+type showcaseRepository struct {
+	db *gorm.DB
+}
+
+func NewShowcaseRepository(db *gorm.DB) ShowcaseRepository {
+	return &showcaseRepository{db: db}
+}
+
+func (r *showcaseRepository) ListShowcased(ctx context.Context, freelancerUserID uint, limit int) ([]*domain.Contract, error) {
+	var list []*domain.Contract
+	err := r.db.WithContext(ctx).Preload("Milestones", func(db *gorm.DB) *gorm.DB {
+		return db.Order("order_index ASC")
+	}).Where("freelancer_user_id = ? AND status = ? AND profile_visibility <> ?", freelancerUserID, domain.ContractStatusDone, domain.ProfileVisibilityHidden).
+		Order("completed_at DESC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+func (r *showcaseRepository) SetProfileVisibility(ctx context.Context, contractID, freelancerUserID uint, visibility string) error {
+	res := r.db.WithContext(ctx).Model(&domain.Contract{}).
+		Where("id = ? AND freelancer_user_id = ?", contractID, freelancerUserID).
+		Updates(map[string]interface{}{"profile_visibility": visibility, "version": nextVersion})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrContractNotFound
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSignOTPNotFound = errors.New("sign code not found")
+)
+
+type SignOTPRepository interface {
+	Create(ctx context.Context, otp *domain.ContractSignOTP) error
+	// FindLatest returns the most recent unconsumed code for the contract's client (signerID 0) or for one
+	// additional signer (expired or not).
+	FindLatest(ctx context.Context, contractID, signerID uint) (*domain.ContractSignOTP, error)
+	IncrementAttempts(ctx context.Context, id uint) error
+}
+
+type signOTPRepository struct {
+	db *gorm.DB
+}
+
+func NewSignOTPRepository(db *gorm.DB) SignOTPRepository {
+	return &signOTPRepository{db: db}
+}
+
+func (r *signOTPRepository) Create(ctx context.Context, otp *domain.ContractSignOTP) error {
+	return r.db.WithContext(ctx).Create(otp).Error
+}
+
+func (r *signOTPRepository) FindLatest(ctx context.Context, contractID, signerID uint) (*domain.ContractSignOTP, error) {
+	q := r.db.WithContext(ctx).Where("contract_id = ? AND consumed_at IS NULL", contractID)
+	if signerID == 0 {
+		q = q.Where("signer_id IS NULL")
+	} else {
+		q = q.Where("signer_id = ?", signerID)
+	}
+	var otp domain.ContractSignOTP
+	err := q.Order("created_at DESC").First(&otp).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSignOTPNotFound
+		}
+		return nil, err
+	}
+	return &otp, nil
+}
+
+func (r *signOTPRepository) IncrementAttempts(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&domain.ContractSignOTP{}).Where("id = ?", id).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+// consumeSignOTP marks a sign code used inside the sign transaction, so the code is only spent when the sign lands.
+// Only succeeds once (ErrSignOTPNotFound after that), so a code cannot sign twice.
+func consumeSignOTP(tx *gorm.DB, id uint, at time.Time) error {
+	res := tx.Model(&domain.ContractSignOTP{}).Where("id = ? AND consumed_at IS NULL", id).
+		Update("consumed_at", at)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrSignOTPNotFound
+	}
+	return nil
+}
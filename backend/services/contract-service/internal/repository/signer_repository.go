@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/linktoken"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSignerNotFound = errors.New("signer not found")
+)
+
+type SignerRepository interface {
+	ListByContract(ctx context.Context, contractID uint) ([]domain.ContractSigner, error)
+	FindByToken(ctx context.Context, token string) (*domain.ContractSigner, error)
+	SetToken(ctx context.Context, id uint, token string) error
+	MarkNotified(ctx context.Context, ids []uint, at time.Time) error
+	// RecordSign stores the signer's evidence (once) and moves the contract to partially_signed or signed.
+	// signOTPID (0 = none) is the sign code checked for this sign; it is consumed in the same transaction.
+	RecordSign(ctx context.Context, signer *domain.ContractSigner, signOTPID uint) error
+}
+
+type signerRepository struct {
+	db *gorm.DB
+}
+
+func NewSignerRepository(db *gorm.DB) SignerRepository {
+	return &signerRepository{db: db}
+}
+
+// replaceSigners deletes the contract's signers and inserts signers in order, inside the caller's transaction
+// (the versioned contract save, so a failed or conflicting save leaves the signers unchanged).
+func replaceSigners(tx *gorm.DB, contractID uint, signers []domain.ContractSigner) error {
+	if err := tx.Where("contract_id = ?", contractID).Delete(&domain.ContractSigner{}).Error; err != nil {
+		return err
+	}
+	for i := range signers {
+		signers[i].ContractID = contractID
+		signers[i].OrderIndex = i
+		if err := tx.Create(&signers[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *signerRepository) ListByContract(ctx context.Context, contractID uint) ([]domain.ContractSigner, error) {
+	var list []domain.ContractSigner
+	err := r.db.WithContext(ctx).Where("contract_id = ?", contractID).Order("order_index ASC").Find(&list).Error
+	return list, err
+}
+
+func (r *signerRepository) FindByToken(ctx context.Context, token string) (*domain.ContractSigner, error) {
+	if token == "" {
+		return nil, ErrSignerNotFound
+	}
+	var s domain.ContractSigner
+	if err := whereSignerToken(r.db.WithContext(ctx), token).First(&s).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSignerNotFound
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// whereSignerToken narrows q to the signer a sign link token points at, as whereClientToken does for clients:
+// signed tokens carry the contract ID and the signer's token as nonce, older links are the bare token.
+func whereSignerToken(q *gorm.DB, token string) *gorm.DB {
+	if c, ok := linktoken.Parse(token); ok {
+		return q.Where("contract_id = ? AND token = ?", c.ContractID, c.Nonce)
+	}
+	return q.Where("token = ?", token)
+}
+
+func (r *signerRepository) SetToken(ctx context.Context, id uint, token string) error {
+	return r.db.WithContext(ctx).Model(&domain.ContractSigner{}).Where("id = ?", id).Update("token", token).Error
+}
+
+func (r *signerRepository) MarkNotified(ctx context.Context, ids []uint, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&domain.ContractSigner{}).Where("id IN ?", ids).Update("notified_at", at).Error
+}
+
+func (r *signerRepository) RecordSign(ctx context.Context, signer *domain.ContractSigner, signOTPID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if signOTPID != 0 {
+			if err := consumeSignOTP(tx, signOTPID, time.Now()); err != nil {
+				return err
+			}
+		}
+		res := tx.Model(&domain.ContractSigner{}).Where("id = ? AND signed_at IS NULL", signer.ID).Updates(map[string]interface{}{
+			"signed_at":         signer.SignedAt,
+			"signed_name":       signer.SignedName,
+			"designation":       signer.Designation,
+			"signer_ip":         signer.SignerIP,
+			"signer_user_agent": signer.SignerUserAgent,
+			"verified":          signer.Verified,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrSignerNotFound
+		}
+		status := gorm.Expr("CASE WHEN client_signed_at IS NOT NULL AND NOT "+pendingRequiredSignersSQL+" THEN ? ELSE ? END",
+			domain.ContractStatusSigned, domain.ContractStatusPartial)
+		res = tx.Model(&domain.Contract{}).
+			Where("id = ? AND status IN ?", signer.ContractID, []string{domain.ContractStatusSent, domain.ContractStatusPartial}).
+			Updates(map[string]interface{}{"status": status, "version": nextVersion})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrContractNotFound
+		}
+		return nil
+	})
+}
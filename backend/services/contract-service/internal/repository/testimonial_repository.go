@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+type TestimonialRepository interface {
+	// Save stores the client's rating and comment on a completed contract that has no testimonial yet;
+	// ErrContractNotFound otherwise.
+	Save(ctx context.Context, contractID uint, rating int, comment string, at time.Time) error
+	// ListUnpublished returns contracts whose testimonial is not yet delivered to user-service.
+	ListUnpublished(ctx context.Context, ratedAfter time.Time, limit int) ([]*domain.Contract, error)
+	MarkPublished(ctx context.Context, contractID uint, at time.Time) error
+}
+
+type testimonialRepository struct {
+	db *gorm.DB
+}
+
+func NewTestimonialRepository(db *gorm.DB) TestimonialRepository {
+	return &testimonialRepository{db: db}
+}
+
+func (r *testimonialRepository) Save(ctx context.Context, contractID uint, rating int, comment string, at time.Time) error {
+	res := r.db.WithContext(ctx).Model(&domain.Contract{}).
+		Where("id = ? AND status = ? AND client_rating IS NULL", contractID, domain.ContractStatusDone).
+		Updates(map[string]interface{}{"client_rating": rating, "client_testimonial": comment, "client_rated_at": at, "version": nextVersion})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrContractNotFound
+	}
+	return nil
+}
+
+func (r *testimonialRepository) ListUnpublished(ctx context.Context, ratedAfter time.Time, limit int) ([]*domain.Contract, error) {
+	var list []*domain.Contract
+	err := r.db.WithContext(ctx).
+		Where("client_rated_at > ? AND testimonial_published_at IS NULL", ratedAfter).
+		Order("client_rated_at ASC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+func (r *testimonialRepository) MarkPublished(ctx context.Context, contractID uint, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&domain.Contract{}).Where("id = ?", contractID).Update("testimonial_published_at", at).Error
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrTimesheetEntryNotFound = errors.New("timesheet entry not found")
+	ErrWeeklyCapExceeded      = errors.New("entry would exceed the contract's weekly hour cap")
+)
+
+type TimesheetRepository interface {
+	// Create adds a pending entry. With weeklyCap > 0 it fails with ErrWeeklyCapExceeded when the entry would take the
+	// week starting weekStart over the cap (pending and approved entries count); the contract row is locked meanwhile
+	// so concurrent entries cannot both slip under it.
+	Create(ctx context.Context, e *domain.TimesheetEntry, weekStart time.Time, weeklyCap float64) error
+	// ListByContract returns the entries, most recent work date first.
+	ListByContract(ctx context.Context, contractID uint) ([]domain.TimesheetEntry, error)
+	GetByID(ctx context.Context, id uint, contractID uint) (*domain.TimesheetEntry, error)
+	// Approve moves a pending entry to approved and adds its amount to the contract total in one transaction;
+	// ErrTimesheetEntryNotFound when it is no longer pending.
+	Approve(ctx context.Context, e *domain.TimesheetEntry) error
+	// Reject moves a pending entry to rejected; ErrTimesheetEntryNotFound when it is no longer pending.
+	Reject(ctx context.Context, e *domain.TimesheetEntry) error
+	// Delete removes a pending entry; ErrTimesheetEntryNotFound when it is no longer pending.
+	Delete(ctx context.Context, id uint, contractID uint) error
+}
+
+type timesheetRepository struct {
+	db *gorm.DB
+}
+
+func NewTimesheetRepository(db *gorm.DB) TimesheetRepository {
+	return &timesheetRepository{db: db}
+}
+
+func (r *timesheetRepository) Create(ctx context.Context, e *domain.TimesheetEntry, weekStart time.Time, weeklyCap float64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var c domain.Contract
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", e.ContractID).First(&c).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrContractNotFound
+			}
+			return err
+		}
+		if weeklyCap > 0 {
+			var logged float64
+			if err := tx.Model(&domain.TimesheetEntry{}).Select("COALESCE(SUM(hours), 0)").
+				Where("contract_id = ? AND status <> ? AND work_date >= ? AND work_date < ?", e.ContractID, domain.TimesheetStatusRejected, weekStart, weekStart.AddDate(0, 0, 7)).
+				Scan(&logged).Error; err != nil {
+				return err
+			}
+			if logged+e.Hours > weeklyCap {
+				return ErrWeeklyCapExceeded
+			}
+		}
+		return tx.Create(e).Error
+	})
+}
+
+func (r *timesheetRepository) ListByContract(ctx context.Context, contractID uint) ([]domain.TimesheetEntry, error) {
+	var list []domain.TimesheetEntry
+	err := r.db.WithContext(ctx).Where("contract_id = ?", contractID).Order("work_date DESC, id DESC").Find(&list).Error
+	return list, err
+}
+
+func (r *timesheetRepository) GetByID(ctx context.Context, id uint, contractID uint) (*domain.TimesheetEntry, error) {
+	var e domain.TimesheetEntry
+	if err := r.db.WithContext(ctx).Where("id = ? AND contract_id = ?", id, contractID).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTimesheetEntryNotFound
+		}
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *timesheetRepository) Approve(ctx context.Context, e *domain.TimesheetEntry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domain.TimesheetEntry{}).
+			Where("id = ? AND contract_id = ? AND status = ?", e.ID, e.ContractID, domain.TimesheetStatusPending).
+			Updates(map[string]interface{}{"status": domain.TimesheetStatusApproved, "reviewed_at": e.ReviewedAt})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrTimesheetEntryNotFound
+		}
+		return tx.Model(&domain.Contract{}).Where("id = ?", e.ContractID).
+			Updates(map[string]interface{}{"total_amount": gorm.Expr("total_amount + ?", e.Amount), "version": nextVersion}).Error
+	})
+}
+
+func (r *timesheetRepository) Reject(ctx context.Context, e *domain.TimesheetEntry) error {
+	res := r.db.WithContext(ctx).Model(&domain.TimesheetEntry{}).
+		Where("id = ? AND contract_id = ? AND status = ?", e.ID, e.ContractID, domain.TimesheetStatusPending).
+		Updates(map[string]interface{}{"status": domain.TimesheetStatusRejected, "reviewed_at": e.ReviewedAt, "rejection_note": e.RejectionNote})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrTimesheetEntryNotFound
+	}
+	return nil
+}
+
+func (r *timesheetRepository) Delete(ctx context.Context, id uint, contractID uint) error {
+	res := r.db.WithContext(ctx).Where("id = ? AND contract_id = ? AND status = ?", id, contractID, domain.TimesheetStatusPending).
+		Delete(&domain.TimesheetEntry{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrTimesheetEntryNotFound
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+// Package reputation scores a freelancer's delivery on a completed contract. The formula is pure: every input is
+// captured in Inputs so a stored score can be explained and recomputed. Bump FormulaVersion whenever Compute changes;
+// scores stored with an older version are recomputed in the background.
+package reputation
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// FormulaVersion identifies the current Compute implementation.
+const FormulaVersion = 1
+
+// Component names and weights (version 1). The rating weight is scaled by how well the client is verified,
+// and dropped when the client has not rated the contract.
+const (
+	ComponentTimeliness   = "timeliness"
+	ComponentRevisions    = "revisions"
+	ComponentClientRating = "client_rating"
+
+	weightTimeliness   = 0.4
+	weightRevisions    = 0.2
+	weightClientRating = 0.4
+
+	// latePenaltyDays is how late an approval can be before a milestone scores zero for timeliness.
+	latePenaltyDays = 14.0
+)
+
+// Verification levels, as stored on the contract for each client detail.
+const (
+	levelSelfDeclared = "self_declared"
+	levelFormatValid  = "format_valid"
+	levelVerified     = "verified"
+)
+
+// Milestone is one milestone as scored.
+type Milestone struct {
+	ID            uint       `json:"id"`
+	DueDate       *time.Time `json:"due_date,omitempty"`
+	ApprovedAt    *time.Time `json:"approved_at,omitempty"`
+	RevisionCount int        `json:"revision_count"`
+}
+
+// Inputs is everything Compute reads. It is stored next to the score.
+type Inputs struct {
+	Milestones           []Milestone       `json:"milestones"`
+	ContractDueDate      *time.Time        `json:"contract_due_date,omitempty"`
+	CompletedAt          time.Time         `json:"completed_at"`
+	ClientRating         *int              `json:"client_rating,omitempty"` // 1-10
+	ClientSignerVerified bool              `json:"client_signer_verified"`
+	ClientVerification   map[string]string `json:"client_verification,omitempty"` // detail -> level
+}
+
+// Component is one weighted part of the score. Value is 0-1; Weight is the effective weight after scaling.
+type Component struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"`
+	Value  float64 `json:"value"`
+	Detail string  `json:"detail"`
+}
+
+// Result is the score (0-100, one decimal) and the components it was built from.
+type Result struct {
+	Score      float64     `json:"score"`
+	Components []Component `json:"components"`
+}
+
+// Compute scores a completed contract with the current formula.
+func Compute(in Inputs) Result {
+	var comps []Component
+	if c, ok := timeliness(in); ok {
+		comps = append(comps, c)
+	}
+	comps = append(comps, revisions(in))
+	if c, ok := clientRating(in); ok {
+		comps = append(comps, c)
+	}
+	var sum, weights float64
+	for _, c := range comps {
+		sum += c.Weight * c.Value
+		weights += c.Weight
+	}
+	score := 0.0
+	if weights > 0 {
+		score = math.Round(sum/weights*1000) / 10
+	}
+	return Result{Score: score, Components: comps}
+}
+
+// timeliness averages, over milestones with a due date, 1 for approval by the due date falling linearly to 0 at
+// latePenaltyDays late. Without milestone due dates the contract due date and completion time are used.
+func timeliness(in Inputs) (Component, bool) {
+	var total float64
+	var n, late int
+	for _, m := range in.Milestones {
+		if m.DueDate == nil || m.ApprovedAt == nil {
+			continue
+		}
+		v := onTimeValue(*m.DueDate, *m.ApprovedAt)
+		if v < 1 {
+			late++
+		}
+		total += v
+		n++
+	}
+	if n == 0 {
+		if in.ContractDueDate == nil {
+			return Component{}, false
+		}
+		v := onTimeValue(*in.ContractDueDate, in.CompletedAt)
+		detail := "completed by the contract due date"
+		if v < 1 {
+			detail = "completed after the contract due date"
+		}
+		return Component{Name: ComponentTimeliness, Weight: weightTimeliness, Value: round3(v), Detail: detail}, true
+	}
+	return Component{
+		Name:   ComponentTimeliness,
+		Weight: weightTimeliness,
+		Value:  round3(total / float64(n)),
+		Detail: fmt.Sprintf("%d of %d milestones with a due date approved on time", n-late, n),
+	}, true
+}
+
+func onTimeValue(due, at time.Time) float64 {
+	daysLate := at.Sub(dueBy(due)).Hours() / 24
+	if daysLate <= 0 {
+		return 1
+	}
+	return math.Max(0, 1-daysLate/latePenaltyDays)
+}
+
+// revisions is 1 / (1 + average revision rounds per milestone): no rounds scores 1, one round per milestone 0.5.
+func revisions(in Inputs) Component {
+	rounds := 0
+	for _, m := range in.Milestones {
+		rounds += m.RevisionCount
+	}
+	avg := 0.0
+	if len(in.Milestones) > 0 {
+		avg = float64(rounds) / float64(len(in.Milestones))
+	}
+	return Component{
+		Name:   ComponentRevisions,
+		Weight: weightRevisions,
+		Value:  round3(1 / (1 + avg)),
+		Detail: fmt.Sprintf("%d revision rounds across %d milestones", rounds, len(in.Milestones)),
+	}
+}
+
+// clientRating maps the 1-10 rating to 0-1. Its weight is scaled by ClientTrust, so an anonymous rating moves
+// the score half as much as one from a fully verified client.
+func clientRating(in Inputs) (Component, bool) {
+	if in.ClientRating == nil {
+		return Component{}, false
+	}
+	r := math.Min(10, math.Max(1, float64(*in.ClientRating)))
+	trust := ClientTrust(in)
+	return Component{
+		Name:   ComponentClientRating,
+		Weight: round3(weightClientRating * trust),
+		Value:  round3((r - 1) / 9),
+		Detail: fmt.Sprintf("rated %d/10 by a client with trust %g", int(r), trust),
+	}, true
+}
+
+// ClientTrust is 0.5-1: 0.5 base, 0.2 when the signer confirmed their email, and up to 0.1 per checked detail
+// (verified 0.1, format valid 0.05, self-declared 0.02).
+func ClientTrust(in Inputs) float64 {
+	t := 0.5
+	if in.ClientSignerVerified {
+		t += 0.2
+	}
+	var details float64
+	for _, level := range in.ClientVerification {
+		switch level {
+		case levelVerified:
+			details += 0.1
+		case levelFormatValid:
+			details += 0.05
+		case levelSelfDeclared:
+			details += 0.02
+		}
+	}
+	return round3(math.Min(1, t+math.Min(0.3, details)))
+}
+
+// dueBy treats a date without a time of day as due by the end of that day.
+func dueBy(t time.Time) time.Time {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Add(24 * time.Hour)
+	}
+	return t
+}
+
+func round3(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
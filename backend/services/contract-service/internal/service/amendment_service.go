@@ -0,0 +1,401 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/notification"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+)
+
+var (
+	ErrAmendmentNotAllowed = errors.New("change orders are only possible on signed or active contracts")
+	ErrAmendmentPending    = errors.New("another change order is waiting for the client")
+	ErrEmptyAmendment      = errors.New("change order has no changes")
+	ErrAmendmentClosed     = errors.New("change order is no longer open")
+	ErrAmendmentStale      = errors.New("contract terms changed since this change order was proposed")
+	ErrMilestoneLocked     = errors.New("only pending milestones can be changed or removed")
+	ErrIncompleteMilestone = errors.New("new milestones need title and amount")
+	ErrAmendmentType       = errors.New("change does not apply to this contract type: total_amount and milestones are for fixed contracts, hourly_rate and weekly_hour_cap for hourly, retainer_amount for retainer")
+)
+
+// AmendmentService handles change orders: the freelancer proposes, the client accepts or rejects via the contract token.
+// Like milestone submit and approve, change orders that touch milestones are frozen during a dispute.
+type AmendmentService struct {
+	contracts  repository.ContractRepository
+	amendments repository.AmendmentRepository
+	disputes   repository.DisputeRepository
+	notifier   notification.ContractNotifier
+	links      ClientLinks
+}
+
+// NewAmendmentService creates the change order service. links builds the link in the client email.
+func NewAmendmentService(contracts repository.ContractRepository, amendments repository.AmendmentRepository, disputes repository.DisputeRepository, notifier notification.ContractNotifier, links ClientLinks) *AmendmentService {
+	return &AmendmentService{
+		contracts:  contracts,
+		amendments: amendments,
+		disputes:   disputes,
+		notifier:   notifier,
+		links:      links.withDefaults(),
+	}
+}
+
+// Propose creates a change order on a signed or active contract. One open change order per contract.
+func (s *AmendmentService) Propose(ctx context.Context, contractID uint, freelancerUserID uint, req *dto.ProposeAmendmentRequest) (*dto.AmendmentResponse, error) {
+	c, err := s.contracts.GetByID(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !amendable(c) {
+		return nil, ErrAmendmentNotAllowed
+	}
+	if _, err := s.amendments.FindOpen(ctx, c.ID); err == nil {
+		return nil, ErrAmendmentPending
+	} else if !errors.Is(err, repository.ErrAmendmentNotFound) {
+		return nil, err
+	}
+	ch := req.Changes
+	if ch.DueDate == nil && ch.TotalAmount == nil && ch.SubmissionCriteria == nil && ch.TermsAndConditions == nil && len(ch.Milestones) == 0 &&
+		ch.HourlyRate == nil && ch.WeeklyHourCap == nil && ch.RetainerAmount == nil {
+		return nil, ErrEmptyAmendment
+	}
+	if err := s.checkFrozen(ctx, c.ID, &ch); err != nil {
+		return nil, err
+	}
+	// dry run against the current terms so invalid milestone edits fail now, not when the client accepts
+	if _, _, err := applyAmendment(c, &ch); err != nil {
+		return nil, err
+	}
+	changesJSON, err := json.Marshal(ch)
+	if err != nil {
+		return nil, err
+	}
+	a := &domain.ContractAmendment{
+		ContractID: c.ID,
+		Version:    termsVersion(c) + 1,
+		Status:     domain.AmendmentStatusProposed,
+		Summary:    strings.TrimSpace(req.Summary),
+		Changes:    string(changesJSON),
+	}
+	if err := s.amendments.Create(ctx, a); err != nil {
+		return nil, err
+	}
+	go s.notifier.NotifyAmendmentProposed(context.Background(), c.ID, c.ClientEmail, s.clientLink(c))
+	return amendmentToResponse(a), nil
+}
+
+func (s *AmendmentService) List(ctx context.Context, contractID uint, freelancerUserID uint) ([]*dto.AmendmentResponse, error) {
+	if _, err := s.contracts.GetByID(ctx, contractID, freelancerUserID); err != nil {
+		return nil, err
+	}
+	return s.list(ctx, contractID)
+}
+
+// Withdraw lets the freelancer cancel a change order the client has not answered yet.
+func (s *AmendmentService) Withdraw(ctx context.Context, contractID uint, freelancerUserID uint, amendmentID uint) (*dto.AmendmentResponse, error) {
+	if _, err := s.contracts.GetByID(ctx, contractID, freelancerUserID); err != nil {
+		return nil, err
+	}
+	a, err := s.amendments.GetByID(ctx, amendmentID, contractID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	a.Status = domain.AmendmentStatusWithdrawn
+	a.RespondedAt = &now
+	if err := s.amendments.Close(ctx, a); err != nil {
+		if errors.Is(err, repository.ErrAmendmentNotFound) {
+			return nil, ErrAmendmentClosed
+		}
+		return nil, err
+	}
+	return amendmentToResponse(a), nil
+}
+
+// ListByClientToken returns the contract's change orders for the client view (no auth).
+func (s *AmendmentService) ListByClientToken(ctx context.Context, token string) ([]*dto.AmendmentResponse, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.list(ctx, c.ID)
+}
+
+// Accept applies the change order as a new terms version. The replaced terms are stored on the amendment together
+// with the client's evidence (typed name, IP, user agent).
+func (s *AmendmentService) Accept(ctx context.Context, token string, amendmentID uint, req *dto.AcceptAmendmentRequest, ip, userAgent string) (*dto.AmendmentResponse, error) {
+	c, a, err := s.openByToken(ctx, token, amendmentID)
+	if err != nil {
+		return nil, err
+	}
+	if termsVersion(c) != a.Version-1 {
+		return nil, ErrAmendmentStale
+	}
+	var ch dto.AmendmentChanges
+	if err := json.Unmarshal([]byte(a.Changes), &ch); err != nil {
+		return nil, err
+	}
+	if err := s.checkFrozen(ctx, c.ID, &ch); err != nil {
+		return nil, err
+	}
+	previous, err := json.Marshal(termsSnapshot(c))
+	if err != nil {
+		return nil, err
+	}
+	upserts, removeIDs, err := applyAmendment(c, &ch)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	a.PreviousTerms = string(previous)
+	a.RespondedAt = &now
+	a.ClientSignedName = strings.TrimSpace(req.FullName)
+	a.ClientSignerIP = ip
+	a.ClientSignerUserAgent = truncate(userAgent, 300)
+	if err := s.amendments.Apply(ctx, a, c, upserts, removeIDs); err != nil {
+		if errors.Is(err, repository.ErrAmendmentNotFound) {
+			return nil, ErrAmendmentStale
+		}
+		return nil, err
+	}
+	a.Status = domain.AmendmentStatusAccepted
+	return amendmentToResponse(a), nil
+}
+
+// Reject closes the change order with the client's reason; the contract terms stay unchanged.
+func (s *AmendmentService) Reject(ctx context.Context, token string, amendmentID uint, req *dto.RejectAmendmentRequest) (*dto.AmendmentResponse, error) {
+	_, a, err := s.openByToken(ctx, token, amendmentID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	a.Status = domain.AmendmentStatusRejected
+	a.ClientComment = strings.TrimSpace(req.Comment)
+	a.RespondedAt = &now
+	if err := s.amendments.Close(ctx, a); err != nil {
+		if errors.Is(err, repository.ErrAmendmentNotFound) {
+			return nil, ErrAmendmentClosed
+		}
+		return nil, err
+	}
+	return amendmentToResponse(a), nil
+}
+
+func (s *AmendmentService) openByToken(ctx context.Context, token string, amendmentID uint) (*domain.Contract, *domain.ContractAmendment, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	a, err := s.amendments.GetByID(ctx, amendmentID, c.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if a.Status != domain.AmendmentStatusProposed {
+		return nil, nil, ErrAmendmentClosed
+	}
+	if !amendable(c) {
+		return nil, nil, ErrAmendmentNotAllowed
+	}
+	return c, a, nil
+}
+
+// checkFrozen reports ErrMilestonesFrozen when ch touches milestones while the contract has an unresolved dispute.
+func (s *AmendmentService) checkFrozen(ctx context.Context, contractID uint, ch *dto.AmendmentChanges) error {
+	if len(ch.Milestones) == 0 {
+		return nil
+	}
+	return milestonesFrozen(ctx, s.disputes, contractID)
+}
+
+func (s *AmendmentService) list(ctx context.Context, contractID uint) ([]*dto.AmendmentResponse, error) {
+	list, err := s.amendments.ListByContract(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*dto.AmendmentResponse, len(list))
+	for i, a := range list {
+		out[i] = amendmentToResponse(a)
+	}
+	return out, nil
+}
+
+func (s *AmendmentService) clientLink(c *domain.Contract) string {
+	return s.links.URL(c)
+}
+
+func amendable(c *domain.Contract) bool {
+	return c.Status == domain.ContractStatusSigned || c.Status == domain.ContractStatusActive
+}
+
+// termsVersion treats contracts created before versioning as version 1.
+func termsVersion(c *domain.Contract) int {
+	if c.TermsVersion < 1 {
+		return 1
+	}
+	return c.TermsVersion
+}
+
+func termsSnapshot(c *domain.Contract) dto.ContractTerms {
+	return dto.ContractTerms{
+		Version:            termsVersion(c),
+		DueDate:            c.DueDate,
+		TotalAmount:        c.TotalAmount,
+		SubmissionCriteria: c.SubmissionCriteria,
+		TermsAndConditions: c.TermsAndConditions,
+		Milestones:         milestonesToResponse(c.Milestones),
+		ContractType:       contractType(c),
+		HourlyRate:         c.HourlyRate,
+		WeeklyHourCap:      c.WeeklyHourCap,
+		RetainerAmount:     c.RetainerAmount,
+		BillingCycle:       c.BillingCycle,
+		RetainerCycles:     c.RetainerCycles,
+	}
+}
+
+// applyAmendment applies ch to c in memory and returns the milestones to save and the IDs to remove.
+// The resulting terms must pass checkPricing, so Propose (dry run) and Accept both reject unworkable terms.
+func applyAmendment(c *domain.Contract, ch *dto.AmendmentChanges) ([]domain.ContractMilestone, []uint, error) {
+	if err := checkAmendmentType(contractType(c), ch); err != nil {
+		return nil, nil, err
+	}
+	if ch.DueDate != nil {
+		c.DueDate = ch.DueDate
+	}
+	if ch.TotalAmount != nil {
+		c.TotalAmount = *ch.TotalAmount
+	}
+	if ch.SubmissionCriteria != nil {
+		c.SubmissionCriteria = *ch.SubmissionCriteria
+	}
+	if ch.TermsAndConditions != nil {
+		c.TermsAndConditions = *ch.TermsAndConditions
+	}
+	if ch.HourlyRate != nil {
+		c.HourlyRate = *ch.HourlyRate
+	}
+	if ch.WeeklyHourCap != nil {
+		c.WeeklyHourCap = *ch.WeeklyHourCap
+	}
+	if ch.RetainerAmount != nil {
+		c.RetainerAmount = *ch.RetainerAmount
+	}
+	byID := make(map[uint]*domain.ContractMilestone, len(c.Milestones))
+	nextIndex := 0
+	for i := range c.Milestones {
+		byID[c.Milestones[i].ID] = &c.Milestones[i]
+		if c.Milestones[i].OrderIndex >= nextIndex {
+			nextIndex = c.Milestones[i].OrderIndex + 1
+		}
+	}
+	var upserts []domain.ContractMilestone
+	var removeIDs []uint
+	for _, mc := range ch.Milestones {
+		if mc.ID == nil {
+			if mc.Title == nil || strings.TrimSpace(*mc.Title) == "" || mc.Amount == nil {
+				return nil, nil, ErrIncompleteMilestone
+			}
+			m := domain.ContractMilestone{ContractID: c.ID, OrderIndex: nextIndex, Title: *mc.Title, Amount: *mc.Amount, DueDate: mc.DueDate, Status: domain.MilestoneStatusPending}
+			if mc.Description != nil {
+				m.Description = *mc.Description
+			}
+			nextIndex++
+			upserts = append(upserts, m)
+			continue
+		}
+		m, ok := byID[*mc.ID]
+		if !ok {
+			return nil, nil, ErrInvalidMilestone
+		}
+		if m.Status != domain.MilestoneStatusPending {
+			return nil, nil, ErrMilestoneLocked
+		}
+		if mc.Remove {
+			removeIDs = append(removeIDs, m.ID)
+			continue
+		}
+		if mc.Title != nil {
+			m.Title = *mc.Title
+		}
+		if mc.Description != nil {
+			m.Description = *mc.Description
+		}
+		if mc.Amount != nil {
+			m.Amount = *mc.Amount
+		}
+		if mc.DueDate != nil {
+			m.DueDate = mc.DueDate
+		}
+		upserts = append(upserts, *m)
+	}
+	if err := checkPricing(c, openMilestonesAfter(c, upserts, removeIDs)); err != nil {
+		return nil, nil, err
+	}
+	return upserts, removeIDs, nil
+}
+
+// openMilestonesAfter counts the milestones of a fixed contract that are not yet approved or paid once upserts and
+// removeIDs are applied. A fixed contract completes when its last open milestone is approved, so a change order
+// must leave at least one. Hourly and retainer contracts have no agreed milestones and count as none.
+func openMilestonesAfter(c *domain.Contract, upserts []domain.ContractMilestone, removeIDs []uint) int {
+	if contractType(c) != domain.ContractTypeFixed {
+		return 0
+	}
+	removed := make(map[uint]bool, len(removeIDs))
+	for _, id := range removeIDs {
+		removed[id] = true
+	}
+	n := 0
+	for _, m := range c.Milestones {
+		if !removed[m.ID] && m.Status != domain.MilestoneStatusApproved && m.Status != domain.MilestoneStatusPaid {
+			n++
+		}
+	}
+	for _, m := range upserts {
+		if m.ID == 0 {
+			n++ // added, pending
+		}
+	}
+	return n
+}
+
+// checkAmendmentType rejects changes to terms the contract type does not have. The total of hourly and retainer
+// contracts is accrued, so it is never amended directly.
+func checkAmendmentType(typ string, ch *dto.AmendmentChanges) error {
+	fixedOnly := ch.TotalAmount != nil || len(ch.Milestones) > 0
+	hourlyOnly := ch.HourlyRate != nil || ch.WeeklyHourCap != nil
+	retainerOnly := ch.RetainerAmount != nil
+	if (fixedOnly && typ != domain.ContractTypeFixed) || (hourlyOnly && typ != domain.ContractTypeHourly) || (retainerOnly && typ != domain.ContractTypeRetainer) {
+		return ErrAmendmentType
+	}
+	return nil
+}
+
+func amendmentToResponse(a *domain.ContractAmendment) *dto.AmendmentResponse {
+	out := &dto.AmendmentResponse{
+		ID:                    a.ID,
+		ContractID:            a.ContractID,
+		Version:               a.Version,
+		Status:                a.Status,
+		Summary:               a.Summary,
+		ClientComment:         a.ClientComment,
+		RespondedAt:           a.RespondedAt,
+		ClientSignedName:      a.ClientSignedName,
+		ClientSignerIP:        a.ClientSignerIP,
+		ClientSignerUserAgent: a.ClientSignerUserAgent,
+		CreatedAt:             a.CreatedAt,
+		UpdatedAt:             a.UpdatedAt,
+	}
+	_ = json.Unmarshal([]byte(a.Changes), &out.Changes)
+	if a.PreviousTerms != "" {
+		var prev dto.ContractTerms
+		if json.Unmarshal([]byte(a.PreviousTerms), &prev) == nil {
+			out.PreviousTerms = &prev
+		}
+	}
+	return out
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+)
+
+func TestOpenMilestonesAfter(t *testing.T) {
+	// 1 approved, 2 paid, 3 submitted, 4 pending
+	milestones := []domain.ContractMilestone{
+		{ID: 1, Status: domain.MilestoneStatusApproved},
+		{ID: 2, Status: domain.MilestoneStatusPaid},
+		{ID: 3, Status: domain.MilestoneStatusSubmitted},
+		{ID: 4, Status: domain.MilestoneStatusPending},
+	}
+	tests := []struct {
+		name      string
+		typ       string
+		upserts   []domain.ContractMilestone
+		removeIDs []uint
+		want      int
+	}{
+		{"no changes", domain.ContractTypeFixed, nil, nil, 2},
+		{"empty type is fixed", "", nil, nil, 2},
+		{"added milestone", domain.ContractTypeFixed, []domain.ContractMilestone{{Title: "Extra"}}, nil, 3},
+		{"edited milestone is not added", domain.ContractTypeFixed, []domain.ContractMilestone{{ID: 4, Title: "Renamed"}}, nil, 2},
+		{"removed open milestone", domain.ContractTypeFixed, nil, []uint{4}, 1},
+		{"removing closed milestones changes nothing", domain.ContractTypeFixed, nil, []uint{1, 2}, 2},
+		{"all open removed", domain.ContractTypeFixed, nil, []uint{3, 4}, 0},
+		{"all open removed, one added", domain.ContractTypeFixed, []domain.ContractMilestone{{Title: "Replacement"}}, []uint{3, 4}, 1},
+		{"hourly has none", domain.ContractTypeHourly, []domain.ContractMilestone{{Title: "Extra"}}, nil, 0},
+		{"retainer has none", domain.ContractTypeRetainer, nil, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &domain.Contract{ContractType: tt.typ, Milestones: milestones}
+			if got := openMilestonesAfter(c, tt.upserts, tt.removeIDs); got != tt.want {
+				t.Fatalf("openMilestonesAfter = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
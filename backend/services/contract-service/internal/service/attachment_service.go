@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/storage"
+)
+
+var (
+	ErrAttachmentTooLarge       = errors.New("attachment exceeds the maximum size")
+	ErrAttachmentEmpty          = errors.New("attachment is empty")
+	ErrAttachmentTypeNotAllowed = errors.New("attachment type is not allowed")
+	ErrAttachmentNotAllowed     = errors.New("attachment kind is not allowed in the current contract status")
+	ErrInvalidMilestone         = errors.New("milestone does not belong to this contract")
+	ErrMilestoneRequired        = errors.New("milestone_id is required for deliverables")
+)
+
+// DefaultAttachmentTypes is used when no allow-list is configured
+var DefaultAttachmentTypes = []string{
+	"application/pdf",
+	"image/png",
+	"image/jpeg",
+	"image/webp",
+	"text/plain",
+	"text/csv",
+	"application/zip",
+	"application/msword",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+}
+
+// AttachmentService stores PRDs and deliverables by content hash and links them to contracts and milestones.
+type AttachmentService struct {
+	contracts    repository.ContractRepository
+	attachments  repository.AttachmentRepository
+	store        storage.BlobStore
+	maxBytes     int64
+	allowedTypes map[string]bool
+}
+
+// NewAttachmentService creates the attachment service. maxBytes <= 0 means 20 MB; empty allowedTypes means DefaultAttachmentTypes.
+// MIME type is detected from content, not trusted from the client.
+func NewAttachmentService(contracts repository.ContractRepository, attachments repository.AttachmentRepository, store storage.BlobStore, maxBytes int64, allowedTypes []string) *AttachmentService {
+	if maxBytes <= 0 {
+		maxBytes = 20 << 20
+	}
+	if len(allowedTypes) == 0 {
+		allowedTypes = DefaultAttachmentTypes
+	}
+	allowed := make(map[string]bool, len(allowedTypes))
+	for _, t := range allowedTypes {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			allowed[t] = true
+		}
+	}
+	return &AttachmentService{
+		contracts:    contracts,
+		attachments:  attachments,
+		store:        store,
+		maxBytes:     maxBytes,
+		allowedTypes: allowed,
+	}
+}
+
+// MaxBytes is the per-file size limit; handlers use it to cap the request body.
+func (s *AttachmentService) MaxBytes() int64 {
+	return s.maxBytes
+}
+
+// Upload stores the file (deduplicated by CID) and links it to the freelancer's contract.
+// PRDs can only change while the contract is draft or pending; deliverables need a milestone on a signed/active contract.
+func (s *AttachmentService) Upload(ctx context.Context, contractID uint, freelancerUserID uint, in *dto.UploadAttachmentInput) (*dto.AttachmentResponse, error) {
+	if len(in.Content) == 0 {
+		return nil, ErrAttachmentEmpty
+	}
+	if int64(len(in.Content)) > s.maxBytes {
+		return nil, ErrAttachmentTooLarge
+	}
+	c, err := s.contracts.GetByID(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.save(ctx, c, freelancerUserID, in)
+}
+
+// UploadByClientToken lets the client add dispute evidence through the contract link. Only kind evidence is accepted.
+func (s *AttachmentService) UploadByClientToken(ctx context.Context, token string, in *dto.UploadAttachmentInput) (*dto.AttachmentResponse, error) {
+	if len(in.Content) == 0 {
+		return nil, ErrAttachmentEmpty
+	}
+	if int64(len(in.Content)) > s.maxBytes {
+		return nil, ErrAttachmentTooLarge
+	}
+	if in.Kind != domain.AttachmentKindEvidence {
+		return nil, ErrAttachmentNotAllowed
+	}
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.save(ctx, c, 0, in)
+}
+
+func (s *AttachmentService) save(ctx context.Context, c *domain.Contract, uploadedByUserID uint, in *dto.UploadAttachmentInput) (*dto.AttachmentResponse, error) {
+	if err := checkAttachmentKindAllowed(c, in); err != nil {
+		return nil, err
+	}
+	contentType := strings.ToLower(strings.SplitN(mimetype.Detect(in.Content).String(), ";", 2)[0])
+	if !s.allowedTypes[contentType] {
+		return nil, ErrAttachmentTypeNotAllowed
+	}
+
+	cid, digest := storage.ComputeCID(in.Content)
+	if err := s.store.Put(ctx, cid, in.Content, contentType); err != nil {
+		return nil, err
+	}
+	a := &domain.ContractAttachment{
+		ContractID:       c.ID,
+		MilestoneID:      in.MilestoneID,
+		UploadedByUserID: uploadedByUserID,
+		Kind:             in.Kind,
+		FileName:         sanitiseFileName(in.FileName),
+		ContentType:      contentType,
+		Size:             int64(len(in.Content)),
+		CID:              cid,
+		SHA256:           hex.EncodeToString(digest[:]),
+	}
+	if err := s.attachments.Create(ctx, a); err != nil {
+		return nil, err
+	}
+	out := attachmentToResponse(a)
+	return &out, nil
+}
+
+func checkAttachmentKindAllowed(c *domain.Contract, in *dto.UploadAttachmentInput) error {
+	if in.MilestoneID != nil && !contractHasMilestone(c, *in.MilestoneID) {
+		return ErrInvalidMilestone
+	}
+	switch in.Kind {
+	case domain.AttachmentKindPRD:
+		if c.Status != domain.ContractStatusDraft && c.Status != domain.ContractStatusPending {
+			return ErrAttachmentNotAllowed
+		}
+	case domain.AttachmentKindDeliverable:
+		if in.MilestoneID == nil {
+			return ErrMilestoneRequired
+		}
+		if c.Status != domain.ContractStatusSigned && c.Status != domain.ContractStatusActive {
+			return ErrAttachmentNotAllowed
+		}
+	case domain.AttachmentKindEvidence:
+		if !statusIn(c.Status, disputableStatuses) {
+			return ErrAttachmentNotAllowed
+		}
+	default:
+		if c.Status == domain.ContractStatusCancel {
+			return ErrAttachmentNotAllowed
+		}
+	}
+	return nil
+}
+
+func contractHasMilestone(c *domain.Contract, milestoneID uint) bool {
+	for i := range c.Milestones {
+		if c.Milestones[i].ID == milestoneID {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns the attachments of the freelancer's contract.
+func (s *AttachmentService) List(ctx context.Context, contractID uint, freelancerUserID uint) ([]dto.AttachmentResponse, error) {
+	c, err := s.contracts.GetByID(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	return attachmentsToResponse(c.Attachments), nil
+}
+
+// Open returns the attachment metadata and its content for the freelancer who owns the contract. Caller closes the reader.
+func (s *AttachmentService) Open(ctx context.Context, contractID uint, freelancerUserID uint, attachmentID uint) (*dto.AttachmentResponse, io.ReadCloser, error) {
+	if _, err := s.contracts.GetByID(ctx, contractID, freelancerUserID); err != nil {
+		return nil, nil, err
+	}
+	return s.open(ctx, contractID, attachmentID)
+}
+
+// OpenByClientToken returns the attachment for the client view (no auth). Caller closes the reader.
+func (s *AttachmentService) OpenByClientToken(ctx context.Context, token string, attachmentID uint) (*dto.AttachmentResponse, io.ReadCloser, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.open(ctx, c.ID, attachmentID)
+}
+
+func (s *AttachmentService) open(ctx context.Context, contractID uint, attachmentID uint) (*dto.AttachmentResponse, io.ReadCloser, error) {
+	a, err := s.attachments.GetByID(ctx, attachmentID, contractID)
+	if err != nil {
+		return nil, nil, err
+	}
+	rc, err := s.store.Get(ctx, a.CID)
+	if err != nil {
+		if errors.Is(err, storage.ErrBlobNotFound) {
+			return nil, nil, repository.ErrAttachmentNotFound
+		}
+		return nil, nil, err
+	}
+	out := attachmentToResponse(a)
+	return &out, rc, nil
+}
+
+// Delete unlinks an attachment. Allowed only while the contract is draft or pending, so signed evidence stays intact.
+func (s *AttachmentService) Delete(ctx context.Context, contractID uint, freelancerUserID uint, attachmentID uint) error {
+	c, err := s.contracts.GetByID(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return err
+	}
+	if c.Status != domain.ContractStatusDraft && c.Status != domain.ContractStatusPending {
+		return ErrNotDraft
+	}
+	return s.attachments.Delete(ctx, attachmentID, contractID)
+}
+
+// sanitiseFileName keeps only the base name and drops characters that break Content-Disposition.
+func sanitiseFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(strings.TrimSpace(name), "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == '"' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	if len(name) > 255 {
+		// keep the tail (and extension); move the cut forward to a character boundary so the name stays valid UTF-8
+		cut := len(name) - 255
+		for cut < len(name) && !utf8.RuneStart(name[cut]) {
+			cut++
+		}
+		name = name[cut:]
+	}
+	return name
+}
+
+func attachmentToResponse(a *domain.ContractAttachment) dto.AttachmentResponse {
+	return dto.AttachmentResponse{
+		ID:          a.ID,
+		ContractID:  a.ContractID,
+		MilestoneID: a.MilestoneID,
+		Kind:        a.Kind,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		CID:         a.CID,
+		SHA256:      a.SHA256,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+func attachmentsToResponse(list []domain.ContractAttachment) []dto.AttachmentResponse {
+	if len(list) == 0 {
+		return nil
+	}
+	out := make([]dto.AttachmentResponse, len(list))
+	for i := range list {
+		out[i] = attachmentToResponse(&list[i])
+	}
+	return out
+}
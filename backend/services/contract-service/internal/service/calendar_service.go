@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/ical"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+)
+
+const (
+	calendarProdID = "-//Defellix//Contract deadlines//EN"
+	calendarName   = "Defellix contract deadlines"
+	// calendarUIDDomain makes event UIDs globally unique; UIDs never change, so re-imports and feed refreshes
+	// update events in place.
+	calendarUIDDomain = "contracts.defellix"
+	// maxCalendarContracts caps how many contracts the subscription feed exports.
+	maxCalendarContracts = 500
+)
+
+// offerStatuses are statuses in which the client still has to sign, so the link they were sent can run out
+var offerStatuses = []string{domain.ContractStatusSent, domain.ContractStatusPending, domain.ContractStatusPartial}
+
+// CalendarService exports contract due dates, milestone due dates and offer expiries as iCalendar (RFC 5545):
+// per contract for download, and for all of a freelancer's contracts behind a secret subscription URL.
+type CalendarService struct {
+	contracts   repository.ContractRepository
+	calendar    repository.CalendarRepository
+	links       ClientLinks
+	feedBaseURL string
+}
+
+// NewCalendarService creates the service. links gives offer expiries (only with signed links; see ClientLinks).
+// feedBaseURL is this API's public base URL (e.g. https://api.ourdomain.com), used to build subscription URLs.
+func NewCalendarService(contracts repository.ContractRepository, calendar repository.CalendarRepository, links ClientLinks, feedBaseURL string) *CalendarService {
+	return &CalendarService{
+		contracts:   contracts,
+		calendar:    calendar,
+		links:       links.withDefaults(),
+		feedBaseURL: strings.TrimSuffix(feedBaseURL, "/"),
+	}
+}
+
+// ContractCalendar returns the freelancer's contract as an .ics body. A cancelled contract's events are marked cancelled.
+func (s *CalendarService) ContractCalendar(ctx context.Context, id, freelancerUserID uint) ([]byte, error) {
+	c, err := s.contracts.GetByID(ctx, id, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	cal := &ical.Calendar{ProdID: calendarProdID, Name: c.ProjectName, Events: s.contractEvents(c)}
+	return cal.Bytes(time.Now()), nil
+}
+
+// FeedCalendar returns the .ics subscription body for a feed token: every contract of its owner that is not
+// cancelled. repository.ErrCalendarFeedNotFound when the token is unknown or was rotated.
+func (s *CalendarService) FeedCalendar(ctx context.Context, token string) ([]byte, error) {
+	feed, err := s.calendar.FindFeedByTokenHash(ctx, hashFeedToken(token))
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.calendar.ListContracts(ctx, feed.FreelancerUserID, maxCalendarContracts)
+	if err != nil {
+		return nil, err
+	}
+	cal := &ical.Calendar{ProdID: calendarProdID, Name: calendarName}
+	for _, c := range list {
+		cal.Events = append(cal.Events, s.contractEvents(c)...)
+	}
+	now := time.Now()
+	if err := s.calendar.TouchFeed(ctx, feed.ID, now); err != nil {
+		log.Printf("[calendar] touch feed %d: %v", feed.ID, err)
+	}
+	return cal.Bytes(now), nil
+}
+
+// PublicTokenExists is the middleware.TokenLookup for /api/v1/public/calendar/{token}/... (kind "calendar").
+func (s *CalendarService) PublicTokenExists(ctx context.Context, kind, token string) (bool, error) {
+	if kind != "calendar" {
+		return false, nil
+	}
+	_, err := s.calendar.FindFeedByTokenHash(ctx, hashFeedToken(token))
+	if errors.Is(err, repository.ErrCalendarFeedNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// CreateFeed issues a new secret subscription URL for the freelancer; an existing one stops working.
+func (s *CalendarService) CreateFeed(ctx context.Context, freelancerUserID uint) (*dto.CalendarFeedResponse, error) {
+	token, err := newFeedToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.calendar.SaveFeed(ctx, &domain.CalendarFeed{FreelancerUserID: freelancerUserID, TokenHash: hashFeedToken(token)}); err != nil {
+		return nil, err
+	}
+	feed, err := s.calendar.GetFeed(ctx, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	out := feedResponse(feed)
+	out.URL = s.feedBaseURL + "/api/v1/public/calendar/" + token + "/deadlines.ics"
+	return out, nil
+}
+
+// GetFeed reports whether the freelancer has a subscription URL and when it was last fetched (not the URL itself).
+func (s *CalendarService) GetFeed(ctx context.Context, freelancerUserID uint) (*dto.CalendarFeedResponse, error) {
+	feed, err := s.calendar.GetFeed(ctx, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	return feedResponse(feed), nil
+}
+
+// DeleteFeed revokes the freelancer's subscription URL.
+func (s *CalendarService) DeleteFeed(ctx context.Context, freelancerUserID uint) error {
+	return s.calendar.DeleteFeed(ctx, freelancerUserID)
+}
+
+// contractEvents lists c's deadlines: the contract due date, each milestone's due date and, while the client has
+// not signed, when the last link they were sent expires. SEQUENCE is the contract version, which every write bumps.
+func (s *CalendarService) contractEvents(c *domain.Contract) []ical.Event {
+	cancelled := c.Status == domain.ContractStatusCancel
+	var out []ical.Event
+	if c.DueDate != nil {
+		out = append(out, ical.Event{
+			UID:          fmt.Sprintf("contract-%d-due@%s", c.ID, calendarUIDDomain),
+			Sequence:     c.Version,
+			Summary:      "Contract due: " + c.ProjectName,
+			Description:  contractEventDescription(c),
+			Start:        *c.DueDate,
+			AllDay:       true,
+			Cancelled:    cancelled,
+			LastModified: c.UpdatedAt,
+		})
+	}
+	for _, m := range c.Milestones {
+		if m.DueDate == nil {
+			continue
+		}
+		out = append(out, ical.Event{
+			UID:          fmt.Sprintf("milestone-%d@%s", m.ID, calendarUIDDomain),
+			Sequence:     c.Version,
+			Summary:      fmt.Sprintf("Milestone due: %s (%s)", m.Title, c.ProjectName),
+			Description:  fmt.Sprintf("Milestone %d of %s. Milestone status: %s.", m.OrderIndex+1, contractEventDescription(c), m.Status),
+			Start:        *m.DueDate,
+			AllDay:       true,
+			Cancelled:    cancelled,
+			LastModified: m.UpdatedAt,
+		})
+	}
+	if s.links.Keys != nil && c.SentAt != nil && statusIn(c.Status, offerStatuses) {
+		out = append(out, ical.Event{
+			UID:          fmt.Sprintf("contract-%d-offer-expiry@%s", c.ID, calendarUIDDomain),
+			Sequence:     c.Version,
+			Summary:      "Offer link expires: " + c.ProjectName,
+			Description:  "The link last sent to the client stops working; re-send the contract to extend it. " + contractEventDescription(c),
+			Start:        c.SentAt.Add(s.links.TTL),
+			LastModified: c.UpdatedAt,
+		})
+	}
+	return out
+}
+
+func contractEventDescription(c *domain.Contract) string {
+	client := c.ClientName
+	if c.ClientCompanyName != "" {
+		client += ", " + c.ClientCompanyName
+	}
+	return fmt.Sprintf("Contract #%d with %s (status: %s)", c.ID, client, c.Status)
+}
+
+func feedResponse(f *domain.CalendarFeed) *dto.CalendarFeedResponse {
+	return &dto.CalendarFeedResponse{CreatedAt: f.UpdatedAt, LastFetchedAt: f.LastFetchedAt}
+}
+
+// newFeedToken returns 32 random bytes, URL-safe.
+func newFeedToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/notification"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+)
+
+var (
+	ErrCancelNotAllowed       = errors.New("this cancellation is not possible in the contract's current status")
+	ErrCancellationPending    = errors.New("a cancellation request is already waiting for an answer")
+	ErrNoCancellationRequest  = errors.New("there is no open cancellation request")
+	ErrOwnCancellationRequest = errors.New("the other party must answer this cancellation request")
+)
+
+// Notification events for NotifyCancellation
+const (
+	cancelEventWithdrawn = "withdrawn"
+	cancelEventDeclined  = "declined"
+	cancelEventRequested = "requested"
+	cancelEventAccepted  = "accepted"
+	cancelEventRejected  = "rejected"
+)
+
+// preSignStatuses can be withdrawn by the freelancer or declined by the client; postSignStatuses need mutual consent.
+var (
+	preSignStatuses  = []string{domain.ContractStatusSent, domain.ContractStatusPending, domain.ContractStatusPartial}
+	postSignStatuses = []string{domain.ContractStatusSigned, domain.ContractStatusActive}
+)
+
+// CancellationService handles withdraw (freelancer) and decline (client) before signing, and mutual cancellation after.
+type CancellationService struct {
+	contracts     repository.ContractRepository
+	cancellations repository.CancellationRepository
+	notifier      notification.ContractNotifier
+}
+
+func NewCancellationService(contracts repository.ContractRepository, cancellations repository.CancellationRepository, notifier notification.ContractNotifier) *CancellationService {
+	return &CancellationService{
+		contracts:     contracts,
+		cancellations: cancellations,
+		notifier:      notifier,
+	}
+}
+
+// Withdraw cancels a sent contract before the client signed. Drafts are deleted instead.
+func (s *CancellationService) Withdraw(ctx context.Context, contractID uint, freelancerUserID uint, reason string) (*dto.CancellationResponse, error) {
+	c, err := s.contracts.GetByID(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !statusIn(c.Status, preSignStatuses) || c.ClientSignedAt != nil {
+		return nil, ErrCancelNotAllowed
+	}
+	return s.cancelNow(ctx, c, domain.CancellationKindWithdraw, domain.PartyFreelancer, reason, cancelEventWithdrawn)
+}
+
+// Decline lets the client refuse the contract via the token before signing it.
+func (s *CancellationService) Decline(ctx context.Context, token string, reason string) (*dto.CancellationResponse, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !statusIn(c.Status, preSignStatuses) || c.ClientSignedAt != nil {
+		return nil, ErrCancelNotAllowed
+	}
+	return s.cancelNow(ctx, c, domain.CancellationKindDecline, domain.PartyClient, reason, cancelEventDeclined)
+}
+
+func (s *CancellationService) RequestByFreelancer(ctx context.Context, contractID uint, freelancerUserID uint, reason string) (*dto.CancellationResponse, error) {
+	c, err := s.contracts.GetByID(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.request(ctx, c, domain.PartyFreelancer, reason)
+}
+
+func (s *CancellationService) RequestByClient(ctx context.Context, token string, reason string) (*dto.CancellationResponse, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.request(ctx, c, domain.PartyClient, reason)
+}
+
+func (s *CancellationService) RespondByFreelancer(ctx context.Context, contractID uint, freelancerUserID uint, accept bool, comment string) (*dto.CancellationResponse, error) {
+	c, err := s.contracts.GetByID(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.respond(ctx, c, domain.PartyFreelancer, accept, comment)
+}
+
+func (s *CancellationService) RespondByClient(ctx context.Context, token string, accept bool, comment string) (*dto.CancellationResponse, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.respond(ctx, c, domain.PartyClient, accept, comment)
+}
+
+func (s *CancellationService) GetByFreelancer(ctx context.Context, contractID uint, freelancerUserID uint) (*dto.CancellationStateResponse, error) {
+	c, err := s.contracts.GetByID(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.state(ctx, c)
+}
+
+func (s *CancellationService) GetByClient(ctx context.Context, token string) (*dto.CancellationStateResponse, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.state(ctx, c)
+}
+
+func (s *CancellationService) cancelNow(ctx context.Context, c *domain.Contract, kind, by, reason, event string) (*dto.CancellationResponse, error) {
+	now := time.Now()
+	x := &domain.ContractCancellation{
+		ContractID:  c.ID,
+		Kind:        kind,
+		RequestedBy: by,
+		Reason:      strings.TrimSpace(reason),
+		Status:      domain.CancellationStatusAccepted,
+		RespondedAt: &now,
+	}
+	if err := s.cancellations.CreateAndCancel(ctx, x, preSignStatuses, now); err != nil {
+		if errors.Is(err, repository.ErrContractNotFound) {
+			return nil, ErrCancelNotAllowed
+		}
+		return nil, err
+	}
+	go s.notifier.NotifyCancellation(context.Background(), c.ID, c.FreelancerUserID, c.ClientEmail, event, by, x.Reason)
+	return cancellationToResponse(x), nil
+}
+
+// request opens a mutual cancellation on a signed/active contract with the settlement as of now.
+func (s *CancellationService) request(ctx context.Context, c *domain.Contract, by, reason string) (*dto.CancellationResponse, error) {
+	if !statusIn(c.Status, postSignStatuses) {
+		return nil, ErrCancelNotAllowed
+	}
+	if _, err := s.cancellations.FindOpen(ctx, c.ID); err == nil {
+		return nil, ErrCancellationPending
+	} else if !errors.Is(err, repository.ErrCancellationNotFound) {
+		return nil, err
+	}
+	settlement, err := json.Marshal(settlementFor(c))
+	if err != nil {
+		return nil, err
+	}
+	x := &domain.ContractCancellation{
+		ContractID:  c.ID,
+		Kind:        domain.CancellationKindMutual,
+		RequestedBy: by,
+		Reason:      strings.TrimSpace(reason),
+		Status:      domain.CancellationStatusRequested,
+		Settlement:  string(settlement),
+	}
+	if err := s.cancellations.Create(ctx, x); err != nil {
+		return nil, err
+	}
+	go s.notifier.NotifyCancellation(context.Background(), c.ID, c.FreelancerUserID, c.ClientEmail, cancelEventRequested, by, x.Reason)
+	return cancellationToResponse(x), nil
+}
+
+// respond answers the other party's open request; accepting cancels the contract.
+func (s *CancellationService) respond(ctx context.Context, c *domain.Contract, by string, accept bool, comment string) (*dto.CancellationResponse, error) {
+	x, err := s.cancellations.FindOpen(ctx, c.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrCancellationNotFound) {
+			return nil, ErrNoCancellationRequest
+		}
+		return nil, err
+	}
+	if x.RequestedBy == by {
+		return nil, ErrOwnCancellationRequest
+	}
+	now := time.Now()
+	x.ResponseComment = strings.TrimSpace(comment)
+	x.RespondedAt = &now
+	event := cancelEventRejected
+	if accept {
+		x.Status = domain.CancellationStatusAccepted
+		event = cancelEventAccepted
+		err = s.cancellations.AcceptAndCancel(ctx, x, postSignStatuses, now)
+	} else {
+		x.Status = domain.CancellationStatusRejected
+		err = s.cancellations.Reject(ctx, x)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrCancellationNotFound) {
+			return nil, ErrNoCancellationRequest
+		}
+		if errors.Is(err, repository.ErrContractNotFound) {
+			return nil, ErrCancelNotAllowed
+		}
+		return nil, err
+	}
+	go s.notifier.NotifyCancellation(context.Background(), c.ID, c.FreelancerUserID, c.ClientEmail, event, by, x.ResponseComment)
+	return cancellationToResponse(x), nil
+}
+
+func (s *CancellationService) state(ctx context.Context, c *domain.Contract) (*dto.CancellationStateResponse, error) {
+	out := &dto.CancellationStateResponse{ContractStatus: c.Status, Settlement: settlementFor(c)}
+	x, err := s.cancellations.FindLatest(ctx, c.ID)
+	if err == nil {
+		out.Latest = cancellationToResponse(x)
+	} else if !errors.Is(err, repository.ErrCancellationNotFound) {
+		return nil, err
+	}
+	return out, nil
+}
+
+// settlementFor classifies milestones: paid stays paid, submitted/approved work is owed, pending is void. On hourly
+// contracts approved time is owed too; its amount is the accrued total.
+func settlementFor(c *domain.Contract) dto.Settlement {
+	out := dto.Settlement{Currency: c.Currency, Milestones: make([]dto.SettlementMilestone, len(c.Milestones))}
+	for i, m := range c.Milestones {
+		outcome := "void"
+		switch m.Status {
+		case domain.MilestoneStatusPaid:
+			outcome = "paid"
+			out.PaidAmount += m.Amount
+		case domain.MilestoneStatusSubmitted, domain.MilestoneStatusApproved:
+			outcome = "owed"
+			out.OwedAmount += m.Amount
+		default:
+			out.VoidAmount += m.Amount
+		}
+		out.Milestones[i] = dto.SettlementMilestone{ID: m.ID, Title: m.Title, Amount: m.Amount, Status: m.Status, Outcome: outcome}
+	}
+	if contractType(c) == domain.ContractTypeHourly {
+		out.ApprovedTimeAmount = c.TotalAmount
+		out.OwedAmount += c.TotalAmount
+	}
+	return out
+}
+
+func cancellationToResponse(x *domain.ContractCancellation) *dto.CancellationResponse {
+	out := &dto.CancellationResponse{
+		ID:              x.ID,
+		ContractID:      x.ContractID,
+		Kind:            x.Kind,
+		RequestedBy:     x.RequestedBy,
+		Reason:          x.Reason,
+		Status:          x.Status,
+		ResponseComment: x.ResponseComment,
+		RespondedAt:     x.RespondedAt,
+		CreatedAt:       x.CreatedAt,
+	}
+	if x.Settlement != "" {
+		var st dto.Settlement
+		if json.Unmarshal([]byte(x.Settlement), &st) == nil {
+			out.Settlement = &st
+		}
+	}
+	return out
+}
+
+func statusIn(status string, list []string) bool {
+	for _, s := range list {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/linktoken"
+)
+
+var (
+	ErrSignedLinksDisabled = errors.New("signed client links are not configured")
+	ErrNoClientLink        = errors.New("contract has not been sent to the client")
+	ErrLinkScopeExceeded   = errors.New("a link cannot grant more than the link it was issued from")
+)
+
+const (
+	defaultClientLinkTTL = 180 * 24 * time.Hour
+	defaultSharedLinkTTL = 30 * 24 * time.Hour
+)
+
+// ClientLinks builds the links clients receive. With Keys set a link carries a signed token (contract ID, scopes,
+// expiry; see linktoken); without, the bare client_view_token as before.
+type ClientLinks struct {
+	BaseURL string            // e.g. https://app.ourdomain.com/contract
+	Keys    *linktoken.Keyset // nil = signed links disabled
+	TTL     time.Duration     // lifetime of the full-scope links sent by email (default 180 days)
+}
+
+func (l ClientLinks) withDefaults() ClientLinks {
+	l.BaseURL = strings.TrimSuffix(l.BaseURL, "/")
+	if l.TTL <= 0 {
+		l.TTL = defaultClientLinkTTL
+	}
+	return l
+}
+
+// URL returns the full-scope client link for c, or "" when no base URL is set or the contract was never sent.
+func (l ClientLinks) URL(c *domain.Contract) string {
+	if l.BaseURL == "" || c.ClientViewToken == "" {
+		return ""
+	}
+	if l.Keys == nil {
+		return l.BaseURL + "/" + c.ClientViewToken
+	}
+	token, err := l.Keys.Issue(linktoken.Claims{ContractID: c.ID, Nonce: c.ClientViewToken, Scopes: linktoken.ScopeAll, ExpiresAt: time.Now().Add(l.TTL)})
+	if err != nil {
+		log.Printf("client link for contract %d: %v", c.ID, err)
+		return ""
+	}
+	return l.BaseURL + "/" + token
+}
+
+// signerURL returns an additional signer's sign link: with Keys a signed token (contract ID, the signer's token as
+// nonce, view and sign scopes, TTL), else the bare token. "" when no base URL is set.
+func (l ClientLinks) signerURL(contractID uint, signerToken string) string {
+	if l.BaseURL == "" {
+		return ""
+	}
+	if l.Keys == nil {
+		return l.BaseURL + "/signers/" + signerToken
+	}
+	token, err := l.Keys.Issue(linktoken.Claims{ContractID: contractID, Nonce: signerToken, Scopes: linktoken.ScopeView | linktoken.ScopeSign, ExpiresAt: time.Now().Add(l.TTL)})
+	if err != nil {
+		log.Printf("signer link for contract %d: %v", contractID, err)
+		return ""
+	}
+	return l.BaseURL + "/signers/" + token
+}
+
+// issue signs a link for c with scopes until expiresAt.
+func (l ClientLinks) issue(c *domain.Contract, scopes linktoken.Scope, expiresAt time.Time) (*dto.ClientLinkResponse, error) {
+	if l.Keys == nil {
+		return nil, ErrSignedLinksDisabled
+	}
+	if c.ClientViewToken == "" {
+		return nil, ErrNoClientLink
+	}
+	token, err := l.Keys.Issue(linktoken.Claims{ContractID: c.ID, Nonce: c.ClientViewToken, Scopes: scopes, ExpiresAt: expiresAt})
+	if err != nil {
+		return nil, err
+	}
+	out := &dto.ClientLinkResponse{Token: token, Scopes: scopes.Names(), ExpiresAt: expiresAt.UTC()}
+	if l.BaseURL != "" {
+		out.Link = l.BaseURL + "/" + token
+	}
+	return out, nil
+}
+
+// IssueClientLink lets the freelancer create an extra link for a sent contract, e.g. view-only for the client's colleagues.
+func (s *ContractService) IssueClientLink(ctx context.Context, id, freelancerUserID uint, req *dto.IssueLinkRequest) (*dto.ClientLinkResponse, error) {
+	scopes, err := linktoken.ParseScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.repo.GetByID(ctx, id, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.links.issue(c, scopes, time.Now().Add(sharedLinkTTL(req)))
+}
+
+// IssueClientLinkByToken lets a link holder share the contract with a link of the same or narrower scopes that
+// expires no later than their own. caller is nil for older unsigned links, which allow everything.
+func (s *ContractService) IssueClientLinkByToken(ctx context.Context, token string, caller *linktoken.Claims, req *dto.IssueLinkRequest) (*dto.ClientLinkResponse, error) {
+	scopes, err := linktoken.ParseScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(sharedLinkTTL(req))
+	if caller != nil {
+		if !caller.Scopes.Has(scopes) {
+			return nil, ErrLinkScopeExceeded
+		}
+		if caller.ExpiresAt.Before(expiresAt) {
+			expiresAt = caller.ExpiresAt
+		}
+	}
+	c, err := s.repo.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.links.issue(c, scopes, expiresAt)
+}
+
+func sharedLinkTTL(req *dto.IssueLinkRequest) time.Duration {
+	if req.ExpiresInDays > 0 {
+		return time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	}
+	return defaultSharedLinkTTL
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/notification"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrClientEmailRequired    = errors.New("account has no email to verify")
+	ErrClientEmailNotVerified = errors.New("the account has not verified this contract's client_email")
+)
+
+// ClientService backs the client dashboard: a client account verifies its email once, claims the contracts
+// addressed to it, then reviews and signs them through the same logic as the public token routes.
+type ClientService struct {
+	repo        repository.ContractRepository
+	emailRepo   repository.ClientEmailVerificationRepository
+	contractSvc *ContractService
+	notifier    notification.ContractNotifier
+	codes       SignOTPSettings
+}
+
+// NewClientService creates the client dashboard service. codes sets TTL, attempts and cooldown of email codes (Required is ignored).
+func NewClientService(repo repository.ContractRepository, emailRepo repository.ClientEmailVerificationRepository, contractSvc *ContractService, notifier notification.ContractNotifier, codes SignOTPSettings) *ClientService {
+	return &ClientService{
+		repo:        repo,
+		emailRepo:   emailRepo,
+		contractSvc: contractSvc,
+		notifier:    notifier,
+		codes:       codes.withDefaults(),
+	}
+}
+
+// RequestClaimCode emails a 6-digit code to the account email (from the access token).
+func (s *ClientService) RequestClaimCode(ctx context.Context, clientUserID uint, email string) (*dto.ClientEmailCodeResponse, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return nil, ErrClientEmailRequired
+	}
+	now := time.Now()
+	if last, err := s.emailRepo.FindLatestPending(ctx, clientUserID, email); err == nil {
+		if now.Sub(last.CreatedAt) < s.codes.Cooldown {
+			return nil, ErrSignCodeTooSoon
+		}
+	} else if !errors.Is(err, repository.ErrEmailVerificationNotFound) {
+		return nil, err
+	}
+	code, err := generateSignCode()
+	if err != nil {
+		return nil, err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	v := &domain.ClientEmailVerification{
+		ClientUserID: clientUserID,
+		Email:        email,
+		CodeHash:     string(hash),
+		ExpiresAt:    now.Add(s.codes.TTL),
+	}
+	if err := s.emailRepo.Create(ctx, v); err != nil {
+		return nil, err
+	}
+	go s.notifier.NotifyClientEmailCode(context.Background(), email, code, v.ExpiresAt)
+	return &dto.ClientEmailCodeResponse{SentTo: maskEmail(email), ExpiresAt: v.ExpiresAt}, nil
+}
+
+// Claim checks the emailed code, marks the account email verified and links every sent contract addressed to it.
+func (s *ClientService) Claim(ctx context.Context, clientUserID uint, email, code string) (*dto.ClaimContractsResponse, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return nil, ErrClientEmailRequired
+	}
+	v, err := s.emailRepo.FindLatestPending(ctx, clientUserID, email)
+	if err != nil {
+		if errors.Is(err, repository.ErrEmailVerificationNotFound) {
+			return nil, ErrSignCodeRequired
+		}
+		return nil, err
+	}
+	now := time.Now()
+	if now.After(v.ExpiresAt) {
+		return nil, ErrSignCodeExpired
+	}
+	if v.Attempts >= s.codes.MaxAttempts {
+		return nil, ErrSignCodeAttemptsExceeded
+	}
+	if bcrypt.CompareHashAndPassword([]byte(v.CodeHash), []byte(code)) != nil {
+		if err := s.emailRepo.IncrementAttempts(ctx, v.ID); err != nil {
+			return nil, err
+		}
+		if v.Attempts+1 >= s.codes.MaxAttempts {
+			return nil, ErrSignCodeAttemptsExceeded
+		}
+		return nil, ErrInvalidSignCode
+	}
+	if err := s.emailRepo.MarkVerified(ctx, v.ID, now); err != nil {
+		if errors.Is(err, repository.ErrEmailVerificationNotFound) {
+			return nil, ErrInvalidSignCode
+		}
+		return nil, err
+	}
+	n, err := s.repo.ClaimForClient(ctx, clientUserID, email)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ClaimContractsResponse{Email: email, Claimed: n}, nil
+}
+
+// List returns the account's claimed contracts. Once the email is verified, contracts sent to it since the last
+// call are claimed first, so new contracts show up without claiming again.
+func (s *ClientService) List(ctx context.Context, clientUserID uint, email, status string, page, limit int) ([]*dto.PublicContractViewResponse, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	if email = strings.TrimSpace(email); email != "" {
+		verified, err := s.emailRepo.IsVerified(ctx, clientUserID, email)
+		if err != nil {
+			return nil, 0, err
+		}
+		if verified {
+			if _, err := s.repo.ClaimForClient(ctx, clientUserID, email); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	list, total, err := s.repo.ListByClient(ctx, clientUserID, status, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	out := make([]*dto.PublicContractViewResponse, len(list))
+	for i, c := range list {
+		out[i] = toPublicViewResponse(c)
+	}
+	return out, total, nil
+}
+
+func (s *ClientService) Get(ctx context.Context, id uint, clientUserID uint) (*dto.PublicContractViewResponse, error) {
+	c, err := s.repo.GetByIDForClient(ctx, id, clientUserID)
+	if err != nil {
+		return nil, err
+	}
+	return toPublicViewResponse(c), nil
+}
+
+// SendForReview is the dashboard equivalent of POST /public/contracts/:token/send-for-review.
+func (s *ClientService) SendForReview(ctx context.Context, id uint, clientUserID uint, req *dto.SendForReviewRequest) error {
+	c, err := s.repo.GetByIDForClient(ctx, id, clientUserID)
+	if err != nil {
+		return err
+	}
+	return s.contractSvc.SendForReview(ctx, c.ClientViewToken, req)
+}
+
+// Sign is the dashboard equivalent of POST /public/contracts/:token/sign. The account's verified email stands in
+// for the one-time sign code, so it must be the contract's client_email: a claim made before client_email changed
+// does not let the account sign.
+func (s *ClientService) Sign(ctx context.Context, id uint, clientUserID uint, email string, req *dto.SignRequest) (*dto.PublicContractViewResponse, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return nil, ErrClientEmailRequired
+	}
+	c, err := s.repo.GetByIDForClient(ctx, id, clientUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(email, c.ClientEmail) {
+		return nil, ErrClientEmailNotVerified
+	}
+	verified, err := s.emailRepo.IsVerified(ctx, clientUserID, email)
+	if err != nil {
+		return nil, err
+	}
+	if !verified {
+		return nil, ErrClientEmailNotVerified
+	}
+	return s.contractSvc.signAsAccount(ctx, c.ClientViewToken, req, email)
+}
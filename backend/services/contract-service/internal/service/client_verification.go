@@ -0,0 +1,79 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/pkg/gstin"
+)
+
+var (
+	ErrInvalidGSTNumber = errors.New("gst_number is not a valid GSTIN")
+)
+
+// freeEmailDomains are consumer mailboxes; a business email on these stays self-declared
+var freeEmailDomains = map[string]bool{
+	"gmail.com": true, "googlemail.com": true, "yahoo.com": true, "yahoo.co.in": true, "outlook.com": true,
+	"hotmail.com": true, "live.com": true, "icloud.com": true, "me.com": true, "aol.com": true,
+	"proton.me": true, "protonmail.com": true, "rediffmail.com": true, "zoho.com": true, "yandex.com": true,
+}
+
+// verifyClientDetails validates the optional sign details and returns the verification level per detail.
+// An invalid GSTIN rejects the sign; other details only lower their level. GSTNumber is normalised in place.
+func verifyClientDetails(req *dto.SignRequest) (map[string]string, error) {
+	levels := map[string]string{
+		domain.ClientDetailGST:           domain.VerificationLevelNone,
+		domain.ClientDetailBusinessEmail: domain.VerificationLevelNone,
+		domain.ClientDetailLinkedIn:      domain.VerificationLevelNone,
+	}
+	if strings.TrimSpace(req.GSTNumber) != "" {
+		g, err := gstin.Parse(req.GSTNumber)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidGSTNumber, err)
+		}
+		req.GSTNumber = g.Number
+		levels[domain.ClientDetailGST] = domain.VerificationLevelFormatValid
+	}
+	if email := strings.TrimSpace(req.BusinessEmail); email != "" {
+		levels[domain.ClientDetailBusinessEmail] = domain.VerificationLevelSelfDeclared
+		if at := strings.LastIndex(email, "@"); at > 0 && !freeEmailDomains[strings.ToLower(email[at+1:])] {
+			levels[domain.ClientDetailBusinessEmail] = domain.VerificationLevelFormatValid
+		}
+	}
+	if link := strings.TrimSpace(req.LinkedIn); link != "" {
+		levels[domain.ClientDetailLinkedIn] = domain.VerificationLevelSelfDeclared
+		if isLinkedInProfileURL(link) {
+			levels[domain.ClientDetailLinkedIn] = domain.VerificationLevelFormatValid
+		}
+	}
+	return levels, nil
+}
+
+func isLinkedInProfileURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if host != "linkedin.com" && !strings.HasSuffix(host, ".linkedin.com") {
+		return false
+	}
+	return strings.HasPrefix(u.Path, "/in/") || strings.HasPrefix(u.Path, "/company/")
+}
+
+// clientVerificationFromJSON decodes the stored levels; nil when the contract is not signed yet.
+func clientVerificationFromJSON(s string) map[string]string {
+	if s == "" {
+		return nil
+	}
+	var levels map[string]string
+	if err := json.Unmarshal([]byte(s), &levels); err != nil {
+		return nil
+	}
+	return levels
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/stream"
+)
+
+// contractEventBatch caps how many stored events one read returns; a stream catching up reads again.
+const contractEventBatch = 100
+
+// EventRecorder records activity on a freelancer's contract for their live event stream. Record never fails the
+// caller: errors are logged.
+type EventRecorder interface {
+	Record(ctx context.Context, c *domain.Contract, eventType string, data map[string]interface{})
+}
+
+// NoopEventRecorder drops events; use it when the event stream is not wired.
+type NoopEventRecorder struct{}
+
+func (NoopEventRecorder) Record(context.Context, *domain.Contract, string, map[string]interface{}) {}
+
+// ContractEventService stores contract events (viewed, sent for review, signed, comment added, milestone
+// submitted) and feeds them to the freelancer's SSE stream. Stored events let a stream resume after a reconnect
+// and are kept for the retention period.
+type ContractEventService struct {
+	events    repository.ContractEventRepository
+	hub       *stream.Hub
+	retention time.Duration
+}
+
+// NewContractEventService creates the service. hub wakes streams on new events; retention <= 0 means 7 days.
+func NewContractEventService(events repository.ContractEventRepository, hub *stream.Hub, retention time.Duration) *ContractEventService {
+	if retention <= 0 {
+		retention = 7 * 24 * time.Hour
+	}
+	return &ContractEventService{events: events, hub: hub, retention: retention}
+}
+
+func (s *ContractEventService) Record(ctx context.Context, c *domain.Contract, eventType string, data map[string]interface{}) {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	data["project_name"] = c.ProjectName
+	b, _ := json.Marshal(data)
+	e := &domain.ContractEvent{ContractID: c.ID, FreelancerUserID: c.FreelancerUserID, Type: eventType, Data: string(b)}
+	if err := s.events.Create(ctx, e); err != nil {
+		log.Printf("[contract-events] record %s for contract %d: %v", eventType, c.ID, err)
+	}
+}
+
+// Subscribe returns a channel that receives a value when the freelancer may have new events, and a func to stop.
+func (s *ContractEventService) Subscribe(freelancerUserID uint) (<-chan struct{}, func()) {
+	return s.hub.Subscribe(freelancerUserID)
+}
+
+// LatestID is the freelancer's newest event ID; a new stream without Last-Event-ID starts after it.
+func (s *ContractEventService) LatestID(ctx context.Context, freelancerUserID uint) (uint, error) {
+	return s.events.LatestID(ctx, freelancerUserID)
+}
+
+// ListAfter returns up to 100 of the freelancer's events after afterID, oldest first. contractID 0 = all contracts.
+func (s *ContractEventService) ListAfter(ctx context.Context, freelancerUserID, contractID, afterID uint) ([]dto.ContractEventResponse, error) {
+	list, err := s.events.ListAfter(ctx, freelancerUserID, contractID, afterID, contractEventBatch)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]dto.ContractEventResponse, len(list))
+	for i, e := range list {
+		out[i] = dto.ContractEventResponse{ID: e.ID, ContractID: e.ContractID, Type: e.Type, Data: json.RawMessage(e.Data), CreatedAt: e.CreatedAt}
+	}
+	return out, nil
+}
+
+// DeleteExpired removes events older than the retention period. Used by the cleanup job.
+func (s *ContractEventService) DeleteExpired(ctx context.Context) (int64, error) {
+	return s.events.DeleteOlderThan(ctx, time.Now().Add(-s.retention))
+}
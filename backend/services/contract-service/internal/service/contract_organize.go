@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+)
+
+// SetTags replaces the tags on the freelancer's contract. Tags are trimmed, lower-cased and de-duplicated; an empty
+// list removes them all. Tags are the freelancer's own and allowed in any status.
+func (s *ContractService) SetTags(ctx context.Context, id, freelancerUserID uint, req *dto.SetContractTagsRequest) (*dto.ContractResponse, error) {
+	seen := make(map[string]bool, len(req.Tags))
+	tags := make([]string, 0, len(req.Tags))
+	for _, t := range req.Tags {
+		if t = normalizeTag(t); t != "" && !seen[t] {
+			seen[t] = true
+			tags = append(tags, t)
+		}
+	}
+	if err := s.repo.SetTags(ctx, id, freelancerUserID, tags); err != nil {
+		return nil, err
+	}
+	return s.GetByID(ctx, id, freelancerUserID)
+}
+
+// ListTags returns the freelancer's tags with how many contracts carry each, most used first.
+func (s *ContractService) ListTags(ctx context.Context, freelancerUserID uint) ([]dto.TagCountResponse, error) {
+	list, err := s.repo.ListTags(ctx, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]dto.TagCountResponse, len(list))
+	for i, t := range list {
+		out[i] = dto.TagCountResponse{Tag: t.Tag, Count: t.Count}
+	}
+	return out, nil
+}
+
+// UpdateNotes sets the freelancer's private notes on the contract. They are never shown to the client.
+func (s *ContractService) UpdateNotes(ctx context.Context, id, freelancerUserID uint, req *dto.UpdateContractNotesRequest) (*dto.ContractResponse, error) {
+	if err := s.repo.UpdateNotes(ctx, id, freelancerUserID, strings.TrimSpace(req.Notes)); err != nil {
+		return nil, err
+	}
+	return s.GetByID(ctx, id, freelancerUserID)
+}
+
+// Archive hides the contract from the default list; it keeps its status and stays reachable by ID, search and
+// ?archived=true. Archiving again keeps the first archived_at.
+func (s *ContractService) Archive(ctx context.Context, id, freelancerUserID uint) (*dto.ContractResponse, error) {
+	c, err := s.repo.GetByID(ctx, id, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	if c.ArchivedAt == nil {
+		now := time.Now()
+		if err := s.repo.SetArchived(ctx, id, freelancerUserID, &now); err != nil {
+			return nil, err
+		}
+		c.ArchivedAt = &now
+	}
+	return s.contractToResponse(c), nil
+}
+
+// Unarchive restores an archived contract to the default list.
+func (s *ContractService) Unarchive(ctx context.Context, id, freelancerUserID uint) (*dto.ContractResponse, error) {
+	if err := s.repo.SetArchived(ctx, id, freelancerUserID, nil); err != nil {
+		return nil, err
+	}
+	return s.GetByID(ctx, id, freelancerUserID)
+}
+
+// normalizeTag lower-cases t and collapses its whitespace, so "Web  Design" and "web design" are one tag.
+func normalizeTag(t string) string {
+	return strings.ToLower(strings.Join(strings.Fields(t), " "))
+}
+
+func tagNames(tags []domain.ContractTag) []string {
+	out := make([]string, len(tags))
+	for i := range tags {
+		out[i] = tags[i].Tag
+	}
+	return out
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"errors"
+	"math"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+)
+
+var ErrInvalidContractTerms = errors.New("fixed contracts need milestones; hourly contracts need hourly_rate; retainer contracts need retainer_amount and billing_cycle; only fixed contracts take milestones")
+
+// contractType treats contracts created before contract types as fixed.
+func contractType(c *domain.Contract) string {
+	if c.ContractType == "" {
+		return domain.ContractTypeFixed
+	}
+	return c.ContractType
+}
+
+// applyCreatePricing copies the pricing fields of req onto a new draft.
+func applyCreatePricing(c *domain.Contract, req *dto.CreateContractRequest) {
+	c.ContractType = req.ContractType
+	c.HourlyRate = req.HourlyRate
+	c.WeeklyHourCap = req.WeeklyHourCap
+	c.RetainerAmount = req.RetainerAmount
+	c.BillingCycle = req.BillingCycle
+	c.RetainerCycles = req.RetainerCycles
+	normalizePricing(c)
+}
+
+// applyPricingUpdate applies the pricing fields of a draft update.
+func applyPricingUpdate(c *domain.Contract, req *dto.UpdateContractRequest) {
+	if req.ContractType != nil {
+		c.ContractType = *req.ContractType
+	}
+	if req.HourlyRate != nil {
+		c.HourlyRate = *req.HourlyRate
+	}
+	if req.WeeklyHourCap != nil {
+		c.WeeklyHourCap = *req.WeeklyHourCap
+	}
+	if req.RetainerAmount != nil {
+		c.RetainerAmount = *req.RetainerAmount
+	}
+	if req.BillingCycle != nil {
+		c.BillingCycle = *req.BillingCycle
+	}
+	if req.RetainerCycles != nil {
+		c.RetainerCycles = *req.RetainerCycles
+	}
+	normalizePricing(c)
+}
+
+// normalizePricing clears the fields another contract type uses. Hourly and retainer drafts start with a zero total;
+// it accrues once they are signed.
+func normalizePricing(c *domain.Contract) {
+	c.ContractType = contractType(c)
+	if c.ContractType != domain.ContractTypeHourly {
+		c.HourlyRate, c.WeeklyHourCap = 0, 0
+	}
+	if c.ContractType != domain.ContractTypeRetainer {
+		c.RetainerAmount, c.BillingCycle, c.RetainerCycles = 0, "", 0
+	}
+	if c.ContractType != domain.ContractTypeFixed {
+		c.TotalAmount = 0
+	}
+}
+
+// checkPricing reports ErrInvalidContractTerms when c's terms do not fit its type; milestones is how many
+// milestones the draft will have.
+func checkPricing(c *domain.Contract, milestones int) error {
+	switch contractType(c) {
+	case domain.ContractTypeFixed:
+		if milestones > 0 {
+			return nil
+		}
+	case domain.ContractTypeHourly:
+		if c.HourlyRate > 0 && milestones == 0 {
+			return nil
+		}
+	case domain.ContractTypeRetainer:
+		if c.RetainerAmount > 0 && (c.BillingCycle == domain.BillingCycleWeekly || c.BillingCycle == domain.BillingCycleMonthly) && milestones == 0 {
+			return nil
+		}
+	}
+	return ErrInvalidContractTerms
+}
+
+// roundAmount rounds to cents, as amounts are stored.
+func roundAmount(v float64) float64 {
+	return math.Round(v*100) / 100
+}
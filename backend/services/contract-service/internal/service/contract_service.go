@@ -26,30 +26,72 @@ var (
 
 type ContractService struct {
 	repo                 repository.ContractRepository
+	otpRepo              repository.SignOTPRepository
+	signerRepo           repository.SignerRepository
 	shareableLinkBaseURL string
+	links                ClientLinks
 	notifier             notification.ContractNotifier
 	draftExpiryDays      int
+	signOTP              SignOTPSettings
+	signatures           SignatureCapture
+	notifyFirstView      bool
+	events               EventRecorder
 }
 
-// NewContractService creates the contract service. shareableLinkBaseURL is used for shareable_link when status is sent (e.g. https://app.ourdomain.com/contract).
-// draftExpiryDays is used by DeleteExpiredDrafts; if <= 0, 14 is used.
-func NewContractService(repo repository.ContractRepository, shareableLinkBaseURL string, notifier notification.ContractNotifier, draftExpiryDays int) *ContractService {
+// NewContractService creates the contract service. links.BaseURL is used for shareable_link when status is sent (e.g. https://app.ourdomain.com/contract).
+// draftExpiryDays is used by DeleteExpiredDrafts; if <= 0, 14 is used. signOTP controls email codes on client sign;
+// signatures controls the typed or drawn mark stored with it.
+// notifyFirstView tells the freelancer when the client first opens the link. events receives client activity
+// (viewed, sent for review, signed) for the freelancer's live stream.
+func NewContractService(repo repository.ContractRepository, otpRepo repository.SignOTPRepository, signerRepo repository.SignerRepository, links ClientLinks, notifier notification.ContractNotifier, draftExpiryDays int, signOTP SignOTPSettings, signatures SignatureCapture, notifyFirstView bool, events EventRecorder) *ContractService {
 	if draftExpiryDays <= 0 {
 		draftExpiryDays = 14
 	}
+	links = links.withDefaults()
 	return &ContractService{
 		repo:                 repo,
-		shareableLinkBaseURL: strings.TrimSuffix(shareableLinkBaseURL, "/"),
+		otpRepo:              otpRepo,
+		signerRepo:           signerRepo,
+		shareableLinkBaseURL: links.BaseURL,
+		links:                links,
 		notifier:             notifier,
 		draftExpiryDays:      draftExpiryDays,
+		signOTP:              signOTP.withDefaults(),
+		signatures:           signatures.withDefaults(),
+		notifyFirstView:      notifyFirstView,
+		events:               events,
 	}
 }
 
 func (s *ContractService) Create(ctx context.Context, freelancerUserID uint, req *dto.CreateContractRequest) (*dto.ContractResponse, error) {
+	c := contractFromCreateRequest(freelancerUserID, req)
+	if err := validateSigners(c.ClientEmail, c.Signers); err != nil {
+		return nil, err
+	}
+	ms := milestonesFromInput(req.Milestones)
+	if err := checkPricing(c, len(ms)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, c, ms); err != nil {
+		return nil, err
+	}
+	return s.toResponse(c, ms), nil
+}
+
+// contractFromCreateRequest builds a draft contract (without milestones, with additional signers) from a validated create payload.
+func contractFromCreateRequest(freelancerUserID uint, req *dto.CreateContractRequest) *domain.Contract {
 	currency := req.Currency
 	if currency == "" {
 		currency = "INR"
 	}
+	signingOrder := req.SigningOrder
+	if signingOrder == "" {
+		signingOrder = domain.SigningOrderParallel
+	}
+	visibility := req.ProfileVisibility
+	if visibility == "" {
+		visibility = domain.ProfileVisibilityFull
+	}
 	c := &domain.Contract{
 		FreelancerUserID:   freelancerUserID,
 		ProjectCategory:    req.ProjectCategory,
@@ -66,12 +108,12 @@ func (s *ContractService) Create(ctx context.Context, freelancerUserID uint, req
 		ClientPhone:        req.ClientPhone,
 		TermsAndConditions: req.TermsAndConditions,
 		Status:             domain.ContractStatusDraft,
+		SigningOrder:       signingOrder,
+		Signers:            signersFromInput(req.Signers),
+		ProfileVisibility:  visibility,
 	}
-	ms := milestonesFromInput(req.Milestones)
-	if err := s.repo.Create(ctx, c, ms); err != nil {
-		return nil, err
-	}
-	return s.toResponse(c, ms), nil
+	applyCreatePricing(c, req)
+	return c
 }
 
 func (s *ContractService) GetByID(ctx context.Context, id uint, freelancerUserID uint) (*dto.ContractResponse, error) {
@@ -82,14 +124,21 @@ func (s *ContractService) GetByID(ctx context.Context, id uint, freelancerUserID
 	return s.contractToResponse(c), nil
 }
 
-func (s *ContractService) List(ctx context.Context, freelancerUserID uint, status string, page, limit int) ([]*dto.ContractResponse, int64, error) {
+// List returns the freelancer's contracts, newest activity first. Archived contracts are left out unless q.Archived
+// asks for them; a search (q.Search) includes them by default so they stay findable.
+func (s *ContractService) List(ctx context.Context, freelancerUserID uint, q *dto.ListContractsQuery) ([]*dto.ContractResponse, int64, error) {
+	page, limit := q.Page, q.Limit
 	if page < 1 {
 		page = 1
 	}
 	if limit < 1 || limit > 100 {
 		limit = 20
 	}
-	list, total, err := s.repo.ListByFreelancer(ctx, freelancerUserID, status, page, limit)
+	f := repository.ContractListFilter{Status: q.Status, Tag: normalizeTag(q.Tag), Search: strings.TrimSpace(q.Search), Archived: q.Archived}
+	if f.Archived == "" && f.Search != "" {
+		f.Archived = repository.ArchivedInclude
+	}
+	list, total, err := s.repo.ListByFreelancer(ctx, freelancerUserID, f, page, limit)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -100,26 +149,52 @@ func (s *ContractService) List(ctx context.Context, freelancerUserID uint, statu
 	return out, total, nil
 }
 
-func (s *ContractService) Update(ctx context.Context, id uint, freelancerUserID uint, req *dto.UpdateContractRequest) (*dto.ContractResponse, error) {
+// Update applies req to a draft or pending contract. expectedVersion is the If-Match version (0 = no precondition);
+// repository.ErrVersionConflict when it is stale or another write lands first.
+func (s *ContractService) Update(ctx context.Context, id uint, freelancerUserID uint, expectedVersion int, req *dto.UpdateContractRequest) (*dto.ContractResponse, error) {
 	c, err := s.repo.GetByID(ctx, id, freelancerUserID)
 	if err != nil {
 		return nil, err
 	}
+	if expectedVersion > 0 && c.Version != expectedVersion {
+		return nil, repository.ErrVersionConflict
+	}
 	if c.Status != domain.ContractStatusDraft && c.Status != domain.ContractStatusPending {
 		return nil, ErrNotDraft
 	}
 	applyUpdate(c, req)
+	applyPricingUpdate(c, req)
+	ms := c.Milestones
 	if len(req.Milestones) > 0 {
-		ms := milestonesFromInput(req.Milestones)
-		if err := s.repo.Update(ctx, c, ms); err != nil {
+		ms = milestonesFromInput(req.Milestones)
+	} else if c.ContractType != domain.ContractTypeFixed {
+		ms = nil // hourly and retainer drafts carry no milestones
+	}
+	if err := checkPricing(c, len(ms)); err != nil {
+		return nil, err
+	}
+	var signers []domain.ContractSigner // nil = unchanged; replaced in the same transaction as the contract
+	if req.Signers != nil {
+		signers = signersFromInput(req.Signers)
+		if err := validateSigners(c.ClientEmail, signers); err != nil {
+			return nil, err
+		}
+	} else if err := validateSigners(c.ClientEmail, c.Signers); err != nil {
+		return nil, err
+	}
+	if len(req.Milestones) > 0 || len(ms) != len(c.Milestones) {
+		if err := s.repo.Update(ctx, c, ms, signers); err != nil {
 			return nil, err
 		}
 		c.Milestones = ms
 	} else {
-		if err := s.repo.UpdateContractOnly(ctx, c); err != nil {
+		if err := s.repo.UpdateContractOnly(ctx, c, signers); err != nil {
 			return nil, err
 		}
 	}
+	if signers != nil {
+		c.Signers = signers
+	}
 	return s.contractToResponse(c), nil
 }
 
@@ -142,6 +217,9 @@ func (s *ContractService) Send(ctx context.Context, id uint, freelancerUserID ui
 		c.ClientViewToken = clientToken
 		shareableLink := s.buildShareableLinkForContract(c)
 		go s.notifier.NotifyContractSent(context.Background(), id, c.ClientEmail, shareableLink)
+		if err := s.sendToSigners(ctx, c); err != nil {
+			return nil, err
+		}
 		return s.contractToResponse(c), nil
 	case domain.ContractStatusPending:
 		if err := s.repo.UpdateStatusAndSentAt(ctx, id, freelancerUserID, domain.ContractStatusSent, &now); err != nil {
@@ -149,19 +227,42 @@ func (s *ContractService) Send(ctx context.Context, id uint, freelancerUserID ui
 		}
 		c.Status = domain.ContractStatusSent
 		c.SentAt = &now
+		if err := s.sendToSigners(ctx, c); err != nil {
+			return nil, err
+		}
 		return s.contractToResponse(c), nil
 	default:
 		return nil, ErrNotDraft
 	}
 }
 
+// PublicTokenExists reports whether a link token is known; kind is the public path segment, "contracts" (client
+// link) or "signers". Used by middleware.PublicTokenGuard to count failed lookups.
+func (s *ContractService) PublicTokenExists(ctx context.Context, kind, token string) (bool, error) {
+	switch kind {
+	case "contracts":
+		return s.repo.ClientTokenExists(ctx, token)
+	case "signers":
+		_, err := s.signerRepo.FindByToken(ctx, token)
+		if errors.Is(err, repository.ErrSignerNotFound) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+	return false, nil
+}
+
 // GetByClientToken returns the contract for the client view (no auth). Token is the client_view_token from the link.
-func (s *ContractService) GetByClientToken(ctx context.Context, token string) (*dto.PublicContractViewResponse, error) {
+// Each call is recorded as a view with the caller's ip and userAgent in coarse, anonymized form.
+func (s *ContractService) GetByClientToken(ctx context.Context, token, ip, userAgent string) (*dto.PublicContractViewResponse, error) {
 	c, err := s.repo.FindByClientViewToken(ctx, token)
 	if err != nil {
 		return nil, err
 	}
-	return toPublicViewResponse(c), nil
+	out := toPublicViewResponse(c)
+	out.SignRequiresCode = s.signOTP.Required
+	go s.recordView(c, ip, userAgent)
+	return out, nil
 }
 
 // SendForReview sets status to pending and stores the client's comment. Allowed only when status is sent.
@@ -176,11 +277,28 @@ func (s *ContractService) SendForReview(ctx context.Context, token string, req *
 	if c.Status != domain.ContractStatusSent {
 		return repository.ErrContractNotFound
 	}
-	return s.repo.UpdateToPendingByToken(ctx, token, strings.TrimSpace(req.Comment))
+	comment := strings.TrimSpace(req.Comment)
+	if err := s.repo.UpdateToPendingByToken(ctx, token, comment); err != nil {
+		return err
+	}
+	go s.events.Record(context.Background(), c, domain.ContractEventSentForReview, map[string]interface{}{"comment": comment})
+	return nil
 }
 
-// Sign records client sign with required company_address and optional metadata. Allowed only when status is sent. No blockchain here (3.4).
+// Sign records client sign with required company_address and optional metadata. Allowed while the client has not signed
+// (sent, or partially_signed when other signers went first). The contract is signed once all required parties have signed. No blockchain here (3.4).
+// When sign codes are required (or a code is given) the emailed one-time code is checked first and the signer is marked verified.
 func (s *ContractService) Sign(ctx context.Context, token string, req *dto.SignRequest) (*dto.PublicContractViewResponse, error) {
+	return s.sign(ctx, token, req, "")
+}
+
+// signAsAccount signs on behalf of a client account that verified accountEmail; no one-time code is needed.
+// accountEmail must still be the contract's client_email when the sign lands.
+func (s *ContractService) signAsAccount(ctx context.Context, token string, req *dto.SignRequest, accountEmail string) (*dto.PublicContractViewResponse, error) {
+	return s.sign(ctx, token, req, accountEmail)
+}
+
+func (s *ContractService) sign(ctx context.Context, token string, req *dto.SignRequest, accountEmail string) (*dto.PublicContractViewResponse, error) {
 	if err := validateCompanyAddress(req.CompanyAddress); err != nil {
 		return nil, err
 	}
@@ -188,24 +306,82 @@ func (s *ContractService) Sign(ctx context.Context, token string, req *dto.SignR
 	if err != nil {
 		return nil, err
 	}
-	if c.Status == domain.ContractStatusSigned {
-		return nil, ErrAlreadySigned
+	if err := clientCanSign(c); err != nil {
+		return nil, err
+	}
+	levels, err := verifyClientDetails(req)
+	if err != nil {
+		return nil, err
 	}
-	if c.Status != domain.ContractStatusSent {
-		return nil, repository.ErrContractNotFound
+	// validate the mark before a sign code is spent on it
+	sig, err := s.captureSignature(req.Signature)
+	if err != nil {
+		return nil, err
 	}
 	meta := signMetadataFromRequest(req)
+	signerVerified := false
+	var otpID uint
+	if accountEmail != "" {
+		if !strings.EqualFold(accountEmail, c.ClientEmail) {
+			return nil, ErrClientEmailNotVerified
+		}
+		signerVerified = true
+		meta["verified_email"] = accountEmail
+		meta["signed_via"] = "client_account"
+		if strings.EqualFold(accountEmail, strings.TrimSpace(req.BusinessEmail)) {
+			levels[domain.ClientDetailBusinessEmail] = domain.VerificationLevelVerified
+		}
+	} else if s.signOTP.Required || req.OTPCode != "" {
+		otp, err := s.verifySignCode(ctx, c.ID, 0, c.ClientEmail, req.OTPCode)
+		if err != nil {
+			return nil, err
+		}
+		signerVerified, otpID = true, otp.ID
+		meta["verified_email"] = otp.Email
+		if strings.EqualFold(otp.Email, strings.TrimSpace(req.BusinessEmail)) {
+			levels[domain.ClientDetailBusinessEmail] = domain.VerificationLevelVerified
+		}
+	}
+	var attachment *domain.ContractAttachment
+	if sig != nil {
+		if attachment, err = s.storeSignature(ctx, c.ID, sig); err != nil {
+			return nil, err
+		}
+	}
 	metaJSON, _ := json.Marshal(meta)
+	levelsJSON, _ := json.Marshal(levels)
 	now := time.Now()
-	if err := s.repo.UpdateToSignedByToken(ctx, token, &now, strings.TrimSpace(req.CompanyAddress), string(metaJSON)); err != nil {
+	companyAddress := strings.TrimSpace(req.CompanyAddress)
+	evidence, evidenceHash := buildSigningEvidence(c, now, companyAddress, meta, signerVerified, sig, attachment)
+	sigType := ""
+	if sig != nil {
+		sigType = sig.Type
+	}
+	if err := s.repo.UpdateToSignedByToken(ctx, token, &now, companyAddress, string(metaJSON), string(levelsJSON), signerVerified, req.AllowPublicProfile, attachment, sigType, evidence, evidenceHash, otpID); err != nil {
+		if errors.Is(err, repository.ErrSignOTPNotFound) {
+			return nil, ErrInvalidSignCode // used by a concurrent sign
+		}
+		return nil, err
+	}
+	// reload: status is signed or partially_signed depending on the other parties
+	if c, err = s.repo.FindByClientViewToken(ctx, token); err != nil {
+		return nil, err
+	}
+	go s.events.Record(context.Background(), c, domain.ContractEventSigned, map[string]interface{}{"signed_by": "client", "status": c.Status})
+	if err := s.notifyEligibleSigners(ctx, c); err != nil {
 		return nil, err
 	}
-	c.Status = domain.ContractStatusSigned
-	c.ClientSignedAt = &now
-	c.ClientCompanyAddress = strings.TrimSpace(req.CompanyAddress)
 	return toPublicViewResponse(c), nil
 }
 
+// sendToSigners issues sign links to additional signers and emails those whose turn it is.
+func (s *ContractService) sendToSigners(ctx context.Context, c *domain.Contract) error {
+	if err := s.issueSignerTokens(ctx, c); err != nil {
+		return err
+	}
+	return s.notifyEligibleSigners(ctx, c)
+}
+
 func validateCompanyAddress(s string) error {
 	s = strings.TrimSpace(s)
 	if s == "" {
@@ -254,26 +430,47 @@ func signMetadataFromRequest(req *dto.SignRequest) map[string]string {
 
 func toPublicViewResponse(c *domain.Contract) *dto.PublicContractViewResponse {
 	return &dto.PublicContractViewResponse{
-		ID:                  c.ID,
-		ProjectCategory:     c.ProjectCategory,
-		ProjectName:         c.ProjectName,
-		Description:         c.Description,
-		DueDate:             c.DueDate,
-		TotalAmount:         c.TotalAmount,
-		Currency:            c.Currency,
-		PRDFileURL:          c.PRDFileURL,
-		SubmissionCriteria:  c.SubmissionCriteria,
-		ClientName:          c.ClientName,
-		ClientCompanyName:   c.ClientCompanyName,
-		ClientEmail:         c.ClientEmail,
-		ClientPhone:         c.ClientPhone,
-		TermsAndConditions:  c.TermsAndConditions,
-		Status:              c.Status,
-		SentAt:              c.SentAt,
-		ClientReviewComment: c.ClientReviewComment,
-		Milestones:          milestonesToResponse(c.Milestones),
-		CreatedAt:           c.CreatedAt,
-		UpdatedAt:           c.UpdatedAt,
+		ID:                      c.ID,
+		ProjectCategory:         c.ProjectCategory,
+		ProjectName:             c.ProjectName,
+		Description:             c.Description,
+		DueDate:                 c.DueDate,
+		TotalAmount:             c.TotalAmount,
+		Currency:                c.Currency,
+		PRDFileURL:              c.PRDFileURL,
+		SubmissionCriteria:      c.SubmissionCriteria,
+		ContractType:            contractType(c),
+		HourlyRate:              c.HourlyRate,
+		WeeklyHourCap:           c.WeeklyHourCap,
+		RetainerAmount:          c.RetainerAmount,
+		BillingCycle:            c.BillingCycle,
+		RetainerCycles:          c.RetainerCycles,
+		RetainerCyclesGenerated: c.RetainerCyclesGenerated,
+		NextCycleAt:             c.NextCycleAt,
+		ClientName:              c.ClientName,
+		ClientCompanyName:       c.ClientCompanyName,
+		ClientEmail:             c.ClientEmail,
+		ClientPhone:             c.ClientPhone,
+		TermsAndConditions:      c.TermsAndConditions,
+		Status:                  c.Status,
+		SentAt:                  c.SentAt,
+		ClientReviewComment:     c.ClientReviewComment,
+		ClientSignerVerified:    c.ClientSignerVerified,
+		ClientSignature:         signatureToResponse(c),
+		ProfileVisibility:       c.ProfileVisibility,
+		ClientPublicConsent:     c.ClientPublicConsent,
+		SigningOrder:            c.SigningOrder,
+		Signers:                 signerStatuses(c),
+		FreelancerSignedAt:      c.FreelancerSignedAt,
+		TermsVersion:            c.TermsVersion,
+		CancelledAt:             c.CancelledAt,
+		CompletedAt:             c.CompletedAt,
+		ClientRating:            c.ClientRating,
+		ClientTestimonial:       c.ClientTestimonial,
+		Milestones:              milestonesToResponse(c.Milestones),
+		Attachments:             attachmentsToResponse(c.Attachments),
+		CreatedAt:               c.CreatedAt,
+		UpdatedAt:               c.UpdatedAt,
 	}
 }
 
@@ -308,7 +505,7 @@ func (s *ContractService) buildShareableLinkForContract(c *domain.Contract) stri
 		return ""
 	}
 	if c.Status == domain.ContractStatusSent && c.ClientViewToken != "" {
-		return s.shareableLinkBaseURL + "/" + c.ClientViewToken
+		return s.links.URL(c)
 	}
 	return s.shareableLinkBaseURL + "/" + strconv.FormatUint(uint64(c.ID), 10)
 }
@@ -317,12 +514,12 @@ func milestonesFromInput(in []dto.MilestoneInput) []domain.ContractMilestone {
 	out := make([]domain.ContractMilestone, len(in))
 	for i := range in {
 		out[i] = domain.ContractMilestone{
-			Title:             in[i].Title,
-			Description:       in[i].Description,
-			Amount:            in[i].Amount,
-			DueDate:           in[i].DueDate,
-			IsInitialPayment:  in[i].IsInitialPayment,
-			Status:            "pending",
+			Title:            in[i].Title,
+			Description:      in[i].Description,
+			Amount:           in[i].Amount,
+			DueDate:          in[i].DueDate,
+			IsInitialPayment: in[i].IsInitialPayment,
+			Status:           domain.MilestoneStatusPending,
 		}
 	}
 	return out
@@ -360,6 +557,9 @@ func applyUpdate(c *domain.Contract, req *dto.UpdateContractRequest) {
 		c.ClientCompanyName = *req.ClientCompanyName
 	}
 	if req.ClientEmail != nil {
+		if !strings.EqualFold(*req.ClientEmail, c.ClientEmail) {
+			c.ClientUserID = nil // the claim was for the old address; the new client claims it again
+		}
 		c.ClientEmail = *req.ClientEmail
 	}
 	if req.ClientPhone != nil {
@@ -368,6 +568,9 @@ func applyUpdate(c *domain.Contract, req *dto.UpdateContractRequest) {
 	if req.TermsAndConditions != nil {
 		c.TermsAndConditions = *req.TermsAndConditions
 	}
+	if req.SigningOrder != nil {
+		c.SigningOrder = *req.SigningOrder
+	}
 }
 
 func (s *ContractService) toResponse(c *domain.Contract, ms []domain.ContractMilestone) *dto.ContractResponse {
@@ -377,27 +580,59 @@ func (s *ContractService) toResponse(c *domain.Contract, ms []domain.ContractMil
 
 func (s *ContractService) toResponseWithShareable(c *domain.Contract, ms []domain.ContractMilestone, shareableLink string) *dto.ContractResponse {
 	return &dto.ContractResponse{
-		ID:                 c.ID,
-		FreelancerUserID:   c.FreelancerUserID,
-		ProjectCategory:    c.ProjectCategory,
-		ProjectName:        c.ProjectName,
-		Description:        c.Description,
-		DueDate:            c.DueDate,
-		TotalAmount:        c.TotalAmount,
-		Currency:           c.Currency,
-		PRDFileURL:         c.PRDFileURL,
-		SubmissionCriteria: c.SubmissionCriteria,
-		ClientName:         c.ClientName,
-		ClientCompanyName:  c.ClientCompanyName,
-		ClientEmail:        c.ClientEmail,
-		ClientPhone:        c.ClientPhone,
-		TermsAndConditions: c.TermsAndConditions,
-		Status:             c.Status,
-		SentAt:             c.SentAt,
-		ShareableLink:      shareableLink,
-		Milestones:         milestonesToResponse(ms),
-		CreatedAt:          c.CreatedAt,
-		UpdatedAt:          c.UpdatedAt,
+		ID:                      c.ID,
+		FreelancerUserID:        c.FreelancerUserID,
+		ProjectCategory:         c.ProjectCategory,
+		ProjectName:             c.ProjectName,
+		Description:             c.Description,
+		DueDate:                 c.DueDate,
+		TotalAmount:             c.TotalAmount,
+		Currency:                c.Currency,
+		PRDFileURL:              c.PRDFileURL,
+		SubmissionCriteria:      c.SubmissionCriteria,
+		ContractType:            contractType(c),
+		HourlyRate:              c.HourlyRate,
+		WeeklyHourCap:           c.WeeklyHourCap,
+		RetainerAmount:          c.RetainerAmount,
+		BillingCycle:            c.BillingCycle,
+		RetainerCycles:          c.RetainerCycles,
+		RetainerCyclesGenerated: c.RetainerCyclesGenerated,
+		NextCycleAt:             c.NextCycleAt,
+		ClientName:              c.ClientName,
+		ClientCompanyName:       c.ClientCompanyName,
+		ClientEmail:             c.ClientEmail,
+		ClientPhone:             c.ClientPhone,
+		TermsAndConditions:      c.TermsAndConditions,
+		Status:                  c.Status,
+		SentAt:                  c.SentAt,
+		ShareableLink:           shareableLink,
+		ClientSignerVerified:    c.ClientSignerVerified,
+		ClientSignature:         signatureToResponse(c),
+		ProfileVisibility:       c.ProfileVisibility,
+		ClientPublicConsent:     c.ClientPublicConsent,
+		ClientPublicConsentAt:   c.ClientPublicConsentAt,
+		FirstViewedAt:           c.FirstViewedAt,
+		LastViewedAt:            c.LastViewedAt,
+		ViewCount:               c.ViewCount,
+		Tags:                    tagNames(c.Tags),
+		PrivateNotes:            c.PrivateNotes,
+		ArchivedAt:              c.ArchivedAt,
+		ClientVerification:      clientVerificationFromJSON(c.ClientVerification),
+		ClientSignedAt:          c.ClientSignedAt,
+		FreelancerSignedAt:      c.FreelancerSignedAt,
+		FreelancerSignedName:    c.FreelancerSignedName,
+		SigningOrder:            c.SigningOrder,
+		Signers:                 s.signersToResponse(c.Signers),
+		TermsVersion:            c.TermsVersion,
+		Version:                 c.Version,
+		CancelledAt:             c.CancelledAt,
+		CompletedAt:             c.CompletedAt,
+		ClientRating:            c.ClientRating,
+		ClientTestimonial:       c.ClientTestimonial,
+		Milestones:              milestonesToResponse(ms),
+		Attachments:             attachmentsToResponse(c.Attachments),
+		CreatedAt:               c.CreatedAt,
+		UpdatedAt:               c.UpdatedAt,
 	}
 }
 
@@ -417,6 +652,11 @@ func milestonesToResponse(ms []domain.ContractMilestone) []dto.MilestoneResponse
 			DueDate:          ms[i].DueDate,
 			IsInitialPayment: ms[i].IsInitialPayment,
 			Status:           ms[i].Status,
+			SubmittedAt:      ms[i].SubmittedAt,
+			SubmissionNote:   ms[i].SubmissionNote,
+			ApprovedAt:       ms[i].ApprovedAt,
+			RevisionCount:    ms[i].RevisionCount,
+			ChangesNote:      ms[i].ChangesNote,
 			CreatedAt:        ms[i].CreatedAt,
 			UpdatedAt:        ms[i].UpdatedAt,
 		}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"log"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+)
+
+// maxListedViews caps how many link opens ListViews returns.
+const maxListedViews = 100
+
+// botUserAgentMarkers mark link previewers, mail scanners and scripts; their opens are not counted as views.
+var botUserAgentMarkers = []string{"bot", "crawler", "spider", "preview", "facebookexternalhit", "headless", "curl/", "wget/", "python-requests", "go-http-client"}
+
+// recordView stores an open of the client link, notifies the freelancer on the first one (when enabled) and puts
+// it on the live event stream. Errors are logged: a failed count never fails the client view.
+func (s *ContractService) recordView(c *domain.Contract, ip, userAgent string) {
+	if isBotUserAgent(userAgent) {
+		return
+	}
+	ctx := context.Background()
+	v := &domain.ContractView{ContractID: c.ID, ViewedAt: time.Now(), UserAgent: coarseUserAgent(userAgent), IPPrefix: anonymizeIP(ip)}
+	n, err := s.repo.RecordView(ctx, v)
+	if err != nil {
+		log.Printf("[contract-views] record view of contract %d: %v", c.ID, err)
+		return
+	}
+	if n == 1 && s.notifyFirstView {
+		s.notifier.NotifyContractViewed(ctx, c.ID, c.FreelancerUserID)
+	}
+	s.events.Record(ctx, c, domain.ContractEventViewed, map[string]interface{}{"view_count": n, "first_view": n == 1})
+}
+
+// ListViews returns the contract's view stats and its most recent link opens (up to 100), newest first.
+func (s *ContractService) ListViews(ctx context.Context, id, freelancerUserID uint) (*dto.ContractViewsResponse, error) {
+	c, err := s.repo.GetByID(ctx, id, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.repo.ListViews(ctx, c.ID, maxListedViews)
+	if err != nil {
+		return nil, err
+	}
+	out := &dto.ContractViewsResponse{
+		FirstViewedAt: c.FirstViewedAt,
+		LastViewedAt:  c.LastViewedAt,
+		ViewCount:     c.ViewCount,
+		Views:         make([]dto.ContractViewResponse, len(list)),
+	}
+	for i, v := range list {
+		out.Views[i] = dto.ContractViewResponse{ViewedAt: v.ViewedAt, UserAgent: v.UserAgent, IPPrefix: v.IPPrefix}
+	}
+	return out, nil
+}
+
+func isBotUserAgent(ua string) bool {
+	ua = strings.ToLower(ua)
+	for _, m := range botUserAgentMarkers {
+		if strings.Contains(ua, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// coarseUserAgent reduces a User-Agent to browser family and OS, e.g. "Safari on iOS"; versions are dropped.
+func coarseUserAgent(ua string) string {
+	ua = strings.ToLower(ua)
+	if ua == "" {
+		return "Unknown"
+	}
+	browser := "Other"
+	switch {
+	case strings.Contains(ua, "edg/") || strings.Contains(ua, "edgios/") || strings.Contains(ua, "edga/"):
+		browser = "Edge"
+	case strings.Contains(ua, "opr/") || strings.Contains(ua, "opera"):
+		browser = "Opera"
+	case strings.Contains(ua, "samsungbrowser/"):
+		browser = "Samsung Internet"
+	case strings.Contains(ua, "firefox/") || strings.Contains(ua, "fxios/"):
+		browser = "Firefox"
+	case strings.Contains(ua, "chrome/") || strings.Contains(ua, "crios/"):
+		browser = "Chrome"
+	case strings.Contains(ua, "safari/"):
+		browser = "Safari"
+	}
+	os := ""
+	switch {
+	case strings.Contains(ua, "windows"):
+		os = "Windows"
+	case strings.Contains(ua, "iphone") || strings.Contains(ua, "ipad") || strings.Contains(ua, "ipod"):
+		os = "iOS"
+	case strings.Contains(ua, "android"):
+		os = "Android"
+	case strings.Contains(ua, "mac os x") || strings.Contains(ua, "macintosh"):
+		os = "macOS"
+	case strings.Contains(ua, "cros "):
+		os = "ChromeOS"
+	case strings.Contains(ua, "linux"):
+		os = "Linux"
+	}
+	if os == "" {
+		return browser
+	}
+	return browser + " on " + os
+}
+
+// anonymizeIP keeps the network part only: /24 for IPv4, /48 for IPv6. Empty when ip does not parse.
+func anonymizeIP(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+	addr = addr.Unmap()
+	bits := 48
+	if addr.Is4() {
+		bits = 24
+	}
+	p, err := addr.Prefix(bits)
+	if err != nil {
+		return ""
+	}
+	return p.String()
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+)
+
+var (
+	ErrNotSignedByClient    = errors.New("contract must be signed by the client (and all required signers) before countersigning")
+	ErrAlreadyCountersigned = errors.New("contract was already countersigned")
+)
+
+// Countersign records the freelancer's own signature on a signed contract and moves it to active.
+// Evidence: typed name, account email, IP and user agent.
+func (s *ContractService) Countersign(ctx context.Context, id uint, freelancerUserID uint, email string, req *dto.CountersignRequest, ip, userAgent string) (*dto.ContractResponse, error) {
+	c, err := s.repo.GetByID(ctx, id, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	if c.FreelancerSignedAt != nil {
+		return nil, ErrAlreadyCountersigned
+	}
+	if c.Status != domain.ContractStatusSigned {
+		return nil, ErrNotSignedByClient
+	}
+	now := time.Now()
+	c.FreelancerSignedAt = &now
+	c.FreelancerSignedName = strings.TrimSpace(req.FullName)
+	c.FreelancerSignerEmail = email
+	c.FreelancerSignerIP = ip
+	c.FreelancerSignerUserAgent = truncate(userAgent, 300)
+	if err := s.repo.Countersign(ctx, c); err != nil {
+		if errors.Is(err, repository.ErrContractNotFound) {
+			// status changed between read and write
+			return nil, ErrNotSignedByClient
+		}
+		return nil, err
+	}
+	c.Status = domain.ContractStatusActive
+	return s.contractToResponse(c), nil
+}
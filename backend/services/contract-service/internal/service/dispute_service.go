@@ -0,0 +1,516 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/notification"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+)
+
+var (
+	ErrDisputeNotAllowed        = errors.New("disputes can only be opened on signed, active or completed contracts")
+	ErrDisputeAlreadyOpen       = errors.New("this contract already has an unresolved dispute")
+	ErrDisputeResolved          = errors.New("dispute is already resolved")
+	ErrDisputeAlreadyEscalated  = errors.New("dispute is already escalated")
+	ErrNotDisputeOpener         = errors.New("only the party who opened the dispute can withdraw it")
+	ErrInvalidDisputeAttachment = errors.New("attachment_ids must reference attachments of this contract")
+	ErrMilestonesFrozen         = errors.New("milestones are frozen while a dispute is open")
+)
+
+// Notification events for NotifyDispute
+const (
+	disputeEventOpened    = "opened"
+	disputeEventMessage   = "message"
+	disputeEventEscalated = "escalated"
+	disputeEventWithdrawn = "withdrawn"
+	disputeEventResolved  = "resolved"
+)
+
+// disputeDeadlineActor is the "by" of escalations caused by a missed deadline
+const disputeDeadlineActor = "deadline"
+
+// disputableStatuses can have disputes opened on them
+var disputableStatuses = []string{domain.ContractStatusSigned, domain.ContractStatusActive, domain.ContractStatusDone}
+
+// activeDisputeStatuses are unresolved dispute states the parties can still act in
+var activeDisputeStatuses = []string{domain.DisputeStatusOpen, domain.DisputeStatusAwaitingResponse, domain.DisputeStatusEscalated}
+
+// DisputeSettings controls dispute deadlines. Zero values get defaults in NewDisputeService.
+type DisputeSettings struct {
+	ResponseWindow time.Duration // time the other party has to answer a new dispute (default 72 hours)
+	SettleWindow   time.Duration // time the parties have to settle once both engaged, before escalation (default 14 days)
+}
+
+func (o DisputeSettings) withDefaults() DisputeSettings {
+	if o.ResponseWindow <= 0 {
+		o.ResponseWindow = 72 * time.Hour
+	}
+	if o.SettleWindow <= 0 {
+		o.SettleWindow = 14 * 24 * time.Hour
+	}
+	return o
+}
+
+// DisputeService runs disputes: open, threaded messages, escalation on request or missed deadline, and admin resolution.
+type DisputeService struct {
+	contracts repository.ContractRepository
+	disputes  repository.DisputeRepository
+	notifier  notification.ContractNotifier
+	settings  DisputeSettings
+	events    EventRecorder
+}
+
+// NewDisputeService creates the service. events receives the client's thread messages for the freelancer's live stream.
+func NewDisputeService(contracts repository.ContractRepository, disputes repository.DisputeRepository, notifier notification.ContractNotifier, settings DisputeSettings, events EventRecorder) *DisputeService {
+	return &DisputeService{
+		contracts: contracts,
+		disputes:  disputes,
+		notifier:  notifier,
+		settings:  settings.withDefaults(),
+		events:    events,
+	}
+}
+
+// disputeActor is who acts in a dispute: a contract party or an admin.
+type disputeActor struct {
+	party  string // freelancer | client | admin
+	userID *uint  // nil for the client via the contract link
+}
+
+func freelancerActor(userID uint) disputeActor {
+	return disputeActor{party: domain.PartyFreelancer, userID: &userID}
+}
+
+var clientActor = disputeActor{party: domain.PartyClient}
+
+func (s *DisputeService) freelancerContract(ctx context.Context, contractID, freelancerUserID uint) (*domain.Contract, error) {
+	return s.contracts.GetByID(ctx, contractID, freelancerUserID)
+}
+
+func (s *DisputeService) OpenByFreelancer(ctx context.Context, contractID, freelancerUserID uint, req *dto.OpenDisputeRequest) (*dto.DisputeResponse, error) {
+	c, err := s.freelancerContract(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.open(ctx, c, freelancerActor(freelancerUserID), req)
+}
+
+func (s *DisputeService) OpenByClient(ctx context.Context, token string, req *dto.OpenDisputeRequest) (*dto.DisputeResponse, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.open(ctx, c, clientActor, req)
+}
+
+func (s *DisputeService) ListByFreelancer(ctx context.Context, contractID, freelancerUserID uint) ([]*dto.DisputeResponse, error) {
+	c, err := s.freelancerContract(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.list(ctx, c)
+}
+
+func (s *DisputeService) ListByClient(ctx context.Context, token string) ([]*dto.DisputeResponse, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.list(ctx, c)
+}
+
+func (s *DisputeService) GetByFreelancer(ctx context.Context, contractID, freelancerUserID, disputeID uint) (*dto.DisputeResponse, error) {
+	c, err := s.freelancerContract(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.get(ctx, c, disputeID)
+}
+
+func (s *DisputeService) GetByClient(ctx context.Context, token string, disputeID uint) (*dto.DisputeResponse, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.get(ctx, c, disputeID)
+}
+
+func (s *DisputeService) PostByFreelancer(ctx context.Context, contractID, freelancerUserID, disputeID uint, req *dto.DisputeMessageRequest) (*dto.DisputeResponse, error) {
+	c, err := s.freelancerContract(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.post(ctx, c, disputeID, freelancerActor(freelancerUserID), req)
+}
+
+func (s *DisputeService) PostByClient(ctx context.Context, token string, disputeID uint, req *dto.DisputeMessageRequest) (*dto.DisputeResponse, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.post(ctx, c, disputeID, clientActor, req)
+}
+
+func (s *DisputeService) EscalateByFreelancer(ctx context.Context, contractID, freelancerUserID, disputeID uint, req *dto.EscalateDisputeRequest) (*dto.DisputeResponse, error) {
+	c, err := s.freelancerContract(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.escalate(ctx, c, disputeID, freelancerActor(freelancerUserID), req.Comment)
+}
+
+func (s *DisputeService) EscalateByClient(ctx context.Context, token string, disputeID uint, req *dto.EscalateDisputeRequest) (*dto.DisputeResponse, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.escalate(ctx, c, disputeID, clientActor, req.Comment)
+}
+
+func (s *DisputeService) WithdrawByFreelancer(ctx context.Context, contractID, freelancerUserID, disputeID uint) (*dto.DisputeResponse, error) {
+	c, err := s.freelancerContract(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withdraw(ctx, c, disputeID, domain.PartyFreelancer)
+}
+
+func (s *DisputeService) WithdrawByClient(ctx context.Context, token string, disputeID uint) (*dto.DisputeResponse, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.withdraw(ctx, c, disputeID, domain.PartyClient)
+}
+
+// AdminList returns disputes across contracts, soonest deadline first. status filters when set.
+func (s *DisputeService) AdminList(ctx context.Context, status string, page, limit int) ([]*dto.DisputeResponse, int64, error) {
+	list, total, err := s.disputes.ListByStatus(ctx, status, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	out := make([]*dto.DisputeResponse, len(list))
+	for i, d := range list {
+		out[i] = disputeToResponse(d)
+	}
+	return out, total, nil
+}
+
+func (s *DisputeService) AdminGet(ctx context.Context, disputeID uint) (*dto.DisputeResponse, error) {
+	d, err := s.disputes.FindByID(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	return disputeToResponse(d), nil
+}
+
+// AdminPost adds an admin message (e.g. a request for more evidence) without changing the dispute state.
+func (s *DisputeService) AdminPost(ctx context.Context, disputeID, adminUserID uint, req *dto.DisputeMessageRequest) (*dto.DisputeResponse, error) {
+	d, err := s.disputes.FindByID(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.contracts.FindByID(ctx, d.ContractID)
+	if err != nil {
+		return nil, err
+	}
+	return s.post(ctx, c, disputeID, disputeActor{party: domain.PartyAdmin, userID: &adminUserID}, req)
+}
+
+// Resolve records the admin decision and closes the dispute, which lifts the milestone freeze.
+func (s *DisputeService) Resolve(ctx context.Context, disputeID, adminUserID uint, req *dto.ResolveDisputeRequest) (*dto.DisputeResponse, error) {
+	d, err := s.disputes.FindByID(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if d.Status == domain.DisputeStatusResolved {
+		return nil, ErrDisputeResolved
+	}
+	now := time.Now()
+	err = s.disputes.UpdateState(ctx, d.ID, activeDisputeStatuses, map[string]interface{}{
+		"status":              domain.DisputeStatusResolved,
+		"resolution":          req.Resolution,
+		"resolution_note":     strings.TrimSpace(req.Note),
+		"resolved_by_user_id": adminUserID,
+		"resolved_at":         now,
+		"awaiting_party":      "",
+		"due_at":              nil,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDisputeNotFound) {
+			return nil, ErrDisputeResolved
+		}
+		return nil, err
+	}
+	s.notify(ctx, d.ContractID, d.ID, disputeEventResolved, domain.PartyAdmin)
+	return s.AdminGet(ctx, d.ID)
+}
+
+// EscalateOverdue escalates disputes whose response or settlement deadline passed. Run periodically
+// (see job.DisputeDeadlineRunner); returns how many were escalated.
+func (s *DisputeService) EscalateOverdue(ctx context.Context) (int64, error) {
+	now := time.Now()
+	list, err := s.disputes.ListOverdue(ctx, now, 100)
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	for _, d := range list {
+		err := s.disputes.UpdateState(ctx, d.ID, []string{domain.DisputeStatusOpen, domain.DisputeStatusAwaitingResponse}, escalationUpdates(now))
+		if err != nil {
+			if !errors.Is(err, repository.ErrDisputeNotFound) {
+				log.Printf("[dispute-deadlines] dispute %d: %v", d.ID, err)
+			}
+			continue
+		}
+		s.notify(ctx, d.ContractID, d.ID, disputeEventEscalated, disputeDeadlineActor)
+		n++
+	}
+	return n, nil
+}
+
+// milestonesFrozen reports ErrMilestonesFrozen while the contract has an unresolved dispute.
+func milestonesFrozen(ctx context.Context, disputes repository.DisputeRepository, contractID uint) error {
+	if _, err := disputes.FindActive(ctx, contractID); err == nil {
+		return ErrMilestonesFrozen
+	} else if !errors.Is(err, repository.ErrDisputeNotFound) {
+		return err
+	}
+	return nil
+}
+
+// open raises a dispute; the other party must respond within the response window.
+func (s *DisputeService) open(ctx context.Context, c *domain.Contract, by disputeActor, req *dto.OpenDisputeRequest) (*dto.DisputeResponse, error) {
+	if !statusIn(c.Status, disputableStatuses) {
+		return nil, ErrDisputeNotAllowed
+	}
+	if req.MilestoneID != nil && !contractHasMilestone(c, *req.MilestoneID) {
+		return nil, ErrInvalidMilestone
+	}
+	attachmentIDs, err := disputeAttachmentIDs(c, req.AttachmentIDs)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.disputes.FindActive(ctx, c.ID); err == nil {
+		return nil, ErrDisputeAlreadyOpen
+	} else if !errors.Is(err, repository.ErrDisputeNotFound) {
+		return nil, err
+	}
+	due := time.Now().Add(s.settings.ResponseWindow)
+	reason := strings.TrimSpace(req.Reason)
+	d := &domain.ContractDispute{
+		ContractID:    c.ID,
+		MilestoneID:   req.MilestoneID,
+		OpenedBy:      by.party,
+		Category:      req.Category,
+		Reason:        reason,
+		Status:        domain.DisputeStatusAwaitingResponse,
+		AwaitingParty: otherParty(by.party),
+		DueAt:         &due,
+	}
+	first := &domain.ContractDisputeMessage{Author: by.party, AuthorUserID: by.userID, Body: reason, AttachmentIDs: attachmentIDs}
+	if err := s.disputes.Create(ctx, d, first); err != nil {
+		return nil, err
+	}
+	d.Messages = []domain.ContractDisputeMessage{*first}
+	go s.notifier.NotifyDispute(context.Background(), c.ID, c.FreelancerUserID, c.ClientEmail, d.ID, disputeEventOpened, by.party)
+	return disputeToResponse(d), nil
+}
+
+func (s *DisputeService) list(ctx context.Context, c *domain.Contract) ([]*dto.DisputeResponse, error) {
+	list, err := s.disputes.ListByContract(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*dto.DisputeResponse, len(list))
+	for i, d := range list {
+		out[i] = disputeToResponse(d)
+	}
+	return out, nil
+}
+
+func (s *DisputeService) get(ctx context.Context, c *domain.Contract, disputeID uint) (*dto.DisputeResponse, error) {
+	d, err := s.disputes.GetByID(ctx, c.ID, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	return disputeToResponse(d), nil
+}
+
+// post adds a message. When the awaited party replies the dispute becomes open with a settlement deadline.
+func (s *DisputeService) post(ctx context.Context, c *domain.Contract, disputeID uint, by disputeActor, req *dto.DisputeMessageRequest) (*dto.DisputeResponse, error) {
+	d, err := s.disputes.GetByID(ctx, c.ID, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if d.Status == domain.DisputeStatusResolved {
+		return nil, ErrDisputeResolved
+	}
+	attachmentIDs, err := disputeAttachmentIDs(c, req.AttachmentIDs)
+	if err != nil {
+		return nil, err
+	}
+	updates := map[string]interface{}{"updated_at": time.Now()}
+	if d.Status == domain.DisputeStatusAwaitingResponse && d.AwaitingParty == by.party {
+		updates["status"] = domain.DisputeStatusOpen
+		updates["awaiting_party"] = ""
+		updates["due_at"] = time.Now().Add(s.settings.SettleWindow)
+	}
+	msg := &domain.ContractDisputeMessage{DisputeID: d.ID, Author: by.party, AuthorUserID: by.userID, Body: strings.TrimSpace(req.Body), AttachmentIDs: attachmentIDs}
+	if err := s.disputes.AddMessage(ctx, msg, updates); err != nil {
+		if errors.Is(err, repository.ErrDisputeNotFound) {
+			return nil, ErrDisputeResolved
+		}
+		return nil, err
+	}
+	s.notify(ctx, c.ID, d.ID, disputeEventMessage, by.party)
+	if by.party == domain.PartyClient {
+		go s.events.Record(context.Background(), c, domain.ContractEventCommentAdded, map[string]interface{}{"dispute_id": d.ID, "message_id": msg.ID, "author": by.party})
+	}
+	return s.get(ctx, c, d.ID)
+}
+
+// escalate hands the dispute to an admin; an optional comment is added to the thread.
+func (s *DisputeService) escalate(ctx context.Context, c *domain.Contract, disputeID uint, by disputeActor, comment string) (*dto.DisputeResponse, error) {
+	d, err := s.disputes.GetByID(ctx, c.ID, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	switch d.Status {
+	case domain.DisputeStatusResolved:
+		return nil, ErrDisputeResolved
+	case domain.DisputeStatusEscalated:
+		return nil, ErrDisputeAlreadyEscalated
+	}
+	now := time.Now()
+	if comment = strings.TrimSpace(comment); comment != "" {
+		msg := &domain.ContractDisputeMessage{DisputeID: d.ID, Author: by.party, AuthorUserID: by.userID, Body: comment}
+		err = s.disputes.AddMessage(ctx, msg, escalationUpdates(now))
+	} else {
+		err = s.disputes.UpdateState(ctx, d.ID, []string{domain.DisputeStatusOpen, domain.DisputeStatusAwaitingResponse}, escalationUpdates(now))
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrDisputeNotFound) {
+			return nil, ErrDisputeResolved
+		}
+		return nil, err
+	}
+	s.notify(ctx, c.ID, d.ID, disputeEventEscalated, by.party)
+	return s.get(ctx, c, d.ID)
+}
+
+func (s *DisputeService) withdraw(ctx context.Context, c *domain.Contract, disputeID uint, by string) (*dto.DisputeResponse, error) {
+	d, err := s.disputes.GetByID(ctx, c.ID, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if d.Status == domain.DisputeStatusResolved {
+		return nil, ErrDisputeResolved
+	}
+	if d.OpenedBy != by {
+		return nil, ErrNotDisputeOpener
+	}
+	err = s.disputes.UpdateState(ctx, d.ID, activeDisputeStatuses, map[string]interface{}{
+		"status":         domain.DisputeStatusResolved,
+		"resolution":     domain.DisputeResolutionWithdrawn,
+		"resolved_at":    time.Now(),
+		"awaiting_party": "",
+		"due_at":         nil,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDisputeNotFound) {
+			return nil, ErrDisputeResolved
+		}
+		return nil, err
+	}
+	s.notify(ctx, c.ID, d.ID, disputeEventWithdrawn, by)
+	return s.get(ctx, c, d.ID)
+}
+
+// notify loads the parties of the contract and sends the dispute event in the background.
+func (s *DisputeService) notify(ctx context.Context, contractID, disputeID uint, event, by string) {
+	c, err := s.contracts.FindByID(ctx, contractID)
+	if err != nil {
+		log.Printf("[dispute] notify contract %d: %v", contractID, err)
+		return
+	}
+	go s.notifier.NotifyDispute(context.Background(), c.ID, c.FreelancerUserID, c.ClientEmail, disputeID, event, by)
+}
+
+func escalationUpdates(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"status":         domain.DisputeStatusEscalated,
+		"escalated_at":   now,
+		"awaiting_party": "",
+		"due_at":         nil,
+	}
+}
+
+func otherParty(party string) string {
+	if party == domain.PartyClient {
+		return domain.PartyFreelancer
+	}
+	return domain.PartyClient
+}
+
+// disputeAttachmentIDs checks that every ID is an attachment of the contract and returns them as JSON.
+func disputeAttachmentIDs(c *domain.Contract, ids []uint) (string, error) {
+	if len(ids) == 0 {
+		return "", nil
+	}
+	known := make(map[uint]bool, len(c.Attachments))
+	for _, a := range c.Attachments {
+		known[a.ID] = true
+	}
+	for _, id := range ids {
+		if !known[id] {
+			return "", ErrInvalidDisputeAttachment
+		}
+	}
+	b, err := json.Marshal(ids)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func disputeToResponse(d *domain.ContractDispute) *dto.DisputeResponse {
+	out := &dto.DisputeResponse{
+		ID:             d.ID,
+		ContractID:     d.ContractID,
+		MilestoneID:    d.MilestoneID,
+		OpenedBy:       d.OpenedBy,
+		Category:       d.Category,
+		Reason:         d.Reason,
+		Status:         d.Status,
+		AwaitingParty:  d.AwaitingParty,
+		DueAt:          d.DueAt,
+		EscalatedAt:    d.EscalatedAt,
+		Resolution:     d.Resolution,
+		ResolutionNote: d.ResolutionNote,
+		ResolvedAt:     d.ResolvedAt,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+	for _, m := range d.Messages {
+		var ids []uint
+		if m.AttachmentIDs != "" {
+			_ = json.Unmarshal([]byte(m.AttachmentIDs), &ids)
+		}
+		out.Messages = append(out.Messages, dto.DisputeMessageResponse{
+			ID:            m.ID,
+			Author:        m.Author,
+			Body:          m.Body,
+			AttachmentIDs: ids,
+			CreatedAt:     m.CreatedAt,
+		})
+	}
+	return out
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+)
+
+var (
+	ErrNoValidRows = errors.New("import has no valid rows")
+)
+
+// ImportService handles bulk import of contracts (CSV rows mapped to CreateContractRequest) as drafts.
+type ImportService struct {
+	repo repository.ImportJobRepository
+}
+
+func NewImportService(repo repository.ImportJobRepository) *ImportService {
+	return &ImportService{repo: repo}
+}
+
+// Import reports per-row errors for rows that failed mapping, validation or the contract terms check. With dryRun nothing is written.
+// Otherwise an import job is created and the valid rows are committed as drafts in one transaction off the
+// request path; the returned job_id can be polled with GetJob.
+func (s *ImportService) Import(ctx context.Context, freelancerUserID uint, fileName string, rows []dto.ImportRow, dryRun bool) (*dto.ImportContractsResponse, error) {
+	rowErrors := make([]dto.ImportRowError, 0)
+	valid := make([]*dto.CreateContractRequest, 0, len(rows))
+	for _, row := range rows {
+		if len(row.Errors) > 0 || row.Request == nil {
+			rowErrors = append(rowErrors, dto.ImportRowError{Row: row.Row, Errors: row.Errors})
+			continue
+		}
+		// same terms check as Create; Send does not re-check drafts
+		if err := checkPricing(contractFromCreateRequest(freelancerUserID, row.Request), len(row.Request.Milestones)); err != nil {
+			rowErrors = append(rowErrors, dto.ImportRowError{Row: row.Row, Errors: []string{err.Error()}})
+			continue
+		}
+		valid = append(valid, row.Request)
+	}
+	out := &dto.ImportContractsResponse{
+		DryRun:    dryRun,
+		TotalRows: len(rows),
+		ValidRows: len(valid),
+		Errors:    rowErrors,
+	}
+	if dryRun {
+		return out, nil
+	}
+	if len(valid) == 0 {
+		return nil, ErrNoValidRows
+	}
+
+	errorsJSON, _ := json.Marshal(rowErrors)
+	job := &domain.ContractImportJob{
+		FreelancerUserID: freelancerUserID,
+		FileName:         fileName,
+		Status:           domain.ImportJobStatusProcessing,
+		TotalRows:        len(rows),
+		ValidRows:        len(valid),
+		RowErrors:        string(errorsJSON),
+	}
+	if err := s.repo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	contracts := make([]*domain.Contract, len(valid))
+	milestones := make([][]domain.ContractMilestone, len(valid))
+	for i, req := range valid {
+		contracts[i] = contractFromCreateRequest(freelancerUserID, req)
+		milestones[i] = milestonesFromInput(req.Milestones)
+	}
+	go s.commit(context.Background(), job.ID, contracts, milestones)
+
+	out.JobID = job.ID
+	out.Status = job.Status
+	return out, nil
+}
+
+func (s *ImportService) commit(ctx context.Context, jobID uint, contracts []*domain.Contract, milestones [][]domain.ContractMilestone) {
+	if err := s.repo.CommitDrafts(ctx, jobID, contracts, milestones); err != nil {
+		log.Printf("[contract-import] job %d failed: %v", jobID, err)
+		if err := s.repo.MarkFailed(ctx, jobID, "Failed to save contracts; no rows were imported"); err != nil {
+			log.Printf("[contract-import] job %d: could not mark failed: %v", jobID, err)
+		}
+	}
+}
+
+// GetJob returns the import job status for polling. Only the freelancer who started the import can read it.
+func (s *ImportService) GetJob(ctx context.Context, id uint, freelancerUserID uint) (*dto.ImportJobResponse, error) {
+	job, err := s.repo.GetByID(ctx, id, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	rowErrors := make([]dto.ImportRowError, 0)
+	if job.RowErrors != "" {
+		_ = json.Unmarshal([]byte(job.RowErrors), &rowErrors)
+	}
+	contractIDs := make([]uint, 0)
+	if job.ContractIDs != "" {
+		_ = json.Unmarshal([]byte(job.ContractIDs), &contractIDs)
+	}
+	return &dto.ImportJobResponse{
+		ID:            job.ID,
+		FileName:      job.FileName,
+		Status:        job.Status,
+		TotalRows:     job.TotalRows,
+		ValidRows:     job.ValidRows,
+		ImportedCount: job.ImportedCount,
+		ContractIDs:   contractIDs,
+		Errors:        rowErrors,
+		ErrorMessage:  job.ErrorMessage,
+		CompletedAt:   job.CompletedAt,
+		CreatedAt:     job.CreatedAt,
+		UpdatedAt:     job.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/event"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/notification"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+)
+
+var (
+	ErrMilestoneNotActive    = errors.New("milestones can only be submitted or approved on a signed or active contract")
+	ErrMilestoneNotPending   = errors.New("milestone was already submitted")
+	ErrMilestoneNotSubmitted = errors.New("milestone must be submitted before it can be approved or sent back")
+	ErrCompletesOnApproval   = errors.New("fixed-price and fixed-length retainer contracts complete when the last milestone is approved")
+	ErrNotCompletable        = errors.New("only a signed or active contract without open milestones or timesheet entries awaiting the client can be completed")
+)
+
+// completionRetryWindow bounds how far back unpublished completions are retried.
+const completionRetryWindow = 30 * 24 * time.Hour
+
+// MilestoneService handles milestone submission (freelancer) and approval or change requests (client). Approving
+// the last open milestone completes a fixed-price contract, publishes a completion event, scores the contract's
+// reputation and invites the client to leave a testimonial; hourly and open-ended retainer contracts are completed
+// by the freelancer instead. Retainer period milestones are generated here too. Transitions are frozen during a dispute.
+type MilestoneService struct {
+	contracts  repository.ContractRepository
+	milestones repository.MilestoneRepository
+	disputes   repository.DisputeRepository
+	reputation *ReputationService
+	notifier   notification.ContractNotifier
+	publisher  event.Publisher
+	links      ClientLinks
+	events     EventRecorder
+}
+
+func NewMilestoneService(contracts repository.ContractRepository, milestones repository.MilestoneRepository, disputes repository.DisputeRepository, reputation *ReputationService, notifier notification.ContractNotifier, publisher event.Publisher, links ClientLinks, events EventRecorder) *MilestoneService {
+	return &MilestoneService{
+		contracts:  contracts,
+		milestones: milestones,
+		disputes:   disputes,
+		reputation: reputation,
+		notifier:   notifier,
+		publisher:  publisher,
+		links:      links.withDefaults(),
+		events:     events,
+	}
+}
+
+// Submit marks a pending milestone as delivered and asks the client to approve it.
+func (s *MilestoneService) Submit(ctx context.Context, contractID, freelancerUserID, milestoneID uint, req *dto.SubmitMilestoneRequest) (*dto.MilestoneResponse, error) {
+	c, err := s.contracts.GetByID(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !statusIn(c.Status, postSignStatuses) {
+		return nil, ErrMilestoneNotActive
+	}
+	m, err := findMilestone(c, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	if m.Status != domain.MilestoneStatusPending {
+		return nil, ErrMilestoneNotPending
+	}
+	if err := milestonesFrozen(ctx, s.disputes, c.ID); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	m.SubmittedAt = &now
+	m.SubmissionNote = strings.TrimSpace(req.Note)
+	if err := s.milestones.Submit(ctx, m, domain.MilestoneStatusPending); err != nil {
+		if errors.Is(err, repository.ErrMilestoneNotFound) {
+			return nil, ErrMilestoneNotPending
+		}
+		return nil, err
+	}
+	m.Status = domain.MilestoneStatusSubmitted
+	go s.notifier.NotifyMilestoneSubmitted(context.Background(), c.ID, c.ClientEmail, m.Title, s.clientLink(c))
+	go s.events.Record(context.Background(), c, domain.ContractEventMilestoneSubmitted, map[string]interface{}{"milestone_id": m.ID, "title": m.Title})
+	return &milestonesToResponse([]domain.ContractMilestone{*m})[0], nil
+}
+
+// ApproveByClientToken approves a submitted milestone via the contract link.
+func (s *MilestoneService) ApproveByClientToken(ctx context.Context, token string, milestoneID uint) (*dto.MilestoneApprovalResponse, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.approve(ctx, c, milestoneID)
+}
+
+// ApproveByClientAccount is the client dashboard equivalent of ApproveByClientToken.
+func (s *MilestoneService) ApproveByClientAccount(ctx context.Context, contractID, clientUserID, milestoneID uint) (*dto.MilestoneApprovalResponse, error) {
+	c, err := s.contracts.GetByIDForClient(ctx, contractID, clientUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.approve(ctx, c, milestoneID)
+}
+
+// RequestChangesByClientToken sends a submitted milestone back to the freelancer via the contract link. Each
+// request counts as a revision round in the contract's reputation score.
+func (s *MilestoneService) RequestChangesByClientToken(ctx context.Context, token string, milestoneID uint, req *dto.RequestMilestoneChangesRequest) (*dto.MilestoneResponse, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.requestChanges(ctx, c, milestoneID, req)
+}
+
+// RequestChangesByClientAccount is the client dashboard equivalent of RequestChangesByClientToken.
+func (s *MilestoneService) RequestChangesByClientAccount(ctx context.Context, contractID, clientUserID, milestoneID uint, req *dto.RequestMilestoneChangesRequest) (*dto.MilestoneResponse, error) {
+	c, err := s.contracts.GetByIDForClient(ctx, contractID, clientUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.requestChanges(ctx, c, milestoneID, req)
+}
+
+func (s *MilestoneService) requestChanges(ctx context.Context, c *domain.Contract, milestoneID uint, req *dto.RequestMilestoneChangesRequest) (*dto.MilestoneResponse, error) {
+	if !statusIn(c.Status, postSignStatuses) {
+		return nil, ErrMilestoneNotActive
+	}
+	m, err := findMilestone(c, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	if m.Status != domain.MilestoneStatusSubmitted {
+		return nil, ErrMilestoneNotSubmitted
+	}
+	if err := milestonesFrozen(ctx, s.disputes, c.ID); err != nil {
+		return nil, err
+	}
+	m.ChangesNote = strings.TrimSpace(req.Comment)
+	if err := s.milestones.RequestChanges(ctx, m); err != nil {
+		if errors.Is(err, repository.ErrMilestoneNotFound) {
+			return nil, ErrMilestoneNotSubmitted
+		}
+		return nil, err
+	}
+	m.Status = domain.MilestoneStatusPending
+	m.RevisionCount++
+	go s.notifier.NotifyMilestoneChangesRequested(context.Background(), c.ID, c.FreelancerUserID, m.Title, m.ChangesNote)
+	return &milestonesToResponse([]domain.ContractMilestone{*m})[0], nil
+}
+
+func (s *MilestoneService) approve(ctx context.Context, c *domain.Contract, milestoneID uint) (*dto.MilestoneApprovalResponse, error) {
+	if !statusIn(c.Status, postSignStatuses) {
+		return nil, ErrMilestoneNotActive
+	}
+	m, err := findMilestone(c, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	if m.Status != domain.MilestoneStatusSubmitted {
+		return nil, ErrMilestoneNotSubmitted
+	}
+	if err := milestonesFrozen(ctx, s.disputes, c.ID); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	m.ApprovedAt = &now
+	completed, err := s.milestones.Approve(ctx, m, postSignStatuses)
+	if err != nil {
+		if errors.Is(err, repository.ErrMilestoneNotFound) {
+			return nil, ErrMilestoneNotSubmitted
+		}
+		return nil, err
+	}
+	m.Status = domain.MilestoneStatusApproved
+	out := &dto.MilestoneApprovalResponse{
+		Milestone:      milestonesToResponse([]domain.ContractMilestone{*m})[0],
+		ContractStatus: c.Status,
+	}
+	if completed {
+		s.completed(c, now)
+		out.ContractStatus = c.Status
+		out.CompletedAt = c.CompletedAt
+	}
+	return out, nil
+}
+
+// Complete ends an hourly or open-ended retainer contract once nothing is left for the client to approve. Fixed-price
+// and fixed-length retainer contracts complete on their own with the last milestone.
+func (s *MilestoneService) Complete(ctx context.Context, contractID, freelancerUserID uint) (*dto.CompleteContractResponse, error) {
+	c, err := s.contracts.GetByID(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	switch contractType(c) {
+	case domain.ContractTypeFixed:
+		return nil, ErrCompletesOnApproval
+	case domain.ContractTypeRetainer:
+		if c.RetainerCycles > 0 {
+			return nil, ErrCompletesOnApproval
+		}
+	}
+	if !statusIn(c.Status, postSignStatuses) {
+		return nil, ErrNotCompletable
+	}
+	if err := milestonesFrozen(ctx, s.disputes, c.ID); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	completed, err := s.milestones.Complete(ctx, c.ID, now, postSignStatuses)
+	if err != nil {
+		return nil, err
+	}
+	if !completed {
+		return nil, ErrNotCompletable
+	}
+	s.completed(c, now)
+	return &dto.CompleteContractResponse{ContractStatus: c.Status, CompletedAt: c.CompletedAt}, nil
+}
+
+// completed runs the side effects of a completion: the completion event, the reputation score and the testimonial request.
+func (s *MilestoneService) completed(c *domain.Contract, at time.Time) {
+	c.Status = domain.ContractStatusDone
+	c.CompletedAt = &at
+	go s.publishCompletion(context.Background(), c.ID)
+	go s.reputation.scoreInBackground(c.ID)
+	go s.notifier.NotifyTestimonialRequested(context.Background(), c.ID, c.ClientEmail, s.clientLink(c))
+}
+
+// PublishPendingCompletions re-sends completion events that did not reach user-service. Run periodically
+// (see job.CompletionPublishRunner); returns how many were delivered.
+func (s *MilestoneService) PublishPendingCompletions(ctx context.Context) (int64, error) {
+	list, err := s.milestones.ListCompletionUnpublished(ctx, time.Now().Add(-completionRetryWindow), 100)
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	for _, c := range list {
+		if err := s.publish(ctx, c); err != nil {
+			log.Printf("[completion] contract %d: %v", c.ID, err)
+			continue
+		}
+		n++
+	}
+	return n, nil
+}
+
+// publishCompletion reloads the contract (milestones as approved) and publishes it; failures are retried by the job.
+func (s *MilestoneService) publishCompletion(ctx context.Context, contractID uint) {
+	c, err := s.contracts.FindByID(ctx, contractID)
+	if err == nil {
+		err = s.publish(ctx, c)
+	}
+	if err != nil {
+		log.Printf("[completion] contract %d: %v (will retry)", contractID, err)
+	}
+}
+
+func (s *MilestoneService) publish(ctx context.Context, c *domain.Contract) error {
+	if c.CompletedAt == nil {
+		return nil
+	}
+	if err := s.publisher.PublishContractCompleted(ctx, completionEvent(c)); err != nil {
+		return err
+	}
+	return s.milestones.MarkCompletionPublished(ctx, c.ID, time.Now())
+}
+
+func (s *MilestoneService) clientLink(c *domain.Contract) string {
+	return s.links.URL(c)
+}
+
+func findMilestone(c *domain.Contract, milestoneID uint) (*domain.ContractMilestone, error) {
+	for i := range c.Milestones {
+		if c.Milestones[i].ID == milestoneID {
+			return &c.Milestones[i], nil
+		}
+	}
+	return nil, repository.ErrMilestoneNotFound
+}
+
+func completionEvent(c *domain.Contract) event.ContractCompleted {
+	return event.ContractCompleted{
+		ContractID:        c.ID,
+		FreelancerUserID:  c.FreelancerUserID,
+		ProjectCategory:   c.ProjectCategory,
+		ProjectName:       c.ProjectName,
+		Description:       c.Description,
+		ClientName:        c.ClientName,
+		ClientCompanyName: c.ClientCompanyName,
+		ContractType:      contractType(c),
+		TotalAmount:       c.TotalAmount,
+		Currency:          c.Currency,
+		StartedAt:         c.ClientSignedAt,
+		DueDate:           c.DueDate,
+		CompletedAt:       *c.CompletedAt,
+		OnTime:            deliveredOnTime(c),
+	}
+}
+
+// deliveredOnTime is true when every milestone was submitted by its due date and the last submission
+// was by the contract due date. Approval time is the client's, so it does not count against the freelancer.
+func deliveredOnTime(c *domain.Contract) bool {
+	var last time.Time
+	for _, m := range c.Milestones {
+		if m.SubmittedAt == nil {
+			continue // approved without a recorded submission (e.g. initial payment)
+		}
+		if m.DueDate != nil && m.SubmittedAt.After(dueBy(*m.DueDate)) {
+			return false
+		}
+		if m.SubmittedAt.After(last) {
+			last = *m.SubmittedAt
+		}
+	}
+	return c.DueDate == nil || last.IsZero() || !last.After(dueBy(*c.DueDate))
+}
+
+// dueBy treats a date without a time of day as due by the end of that day.
+func dueBy(t time.Time) time.Time {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Add(24 * time.Hour)
+	}
+	return t
+}
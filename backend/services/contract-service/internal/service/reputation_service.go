@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/event"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/reputation"
+)
+
+var (
+	ErrContractNotCompleted = errors.New("contract is not completed")
+)
+
+// ReputationService scores completed contracts with the reputation formula, keeps the freelancer's aggregate and
+// publishes it to user-service. Scores from an older formula version are recomputed by Refresh.
+type ReputationService struct {
+	contracts  repository.ContractRepository
+	reputation repository.ReputationRepository
+	publisher  event.Publisher
+}
+
+func NewReputationService(contracts repository.ContractRepository, reputation repository.ReputationRepository, publisher event.Publisher) *ReputationService {
+	return &ReputationService{
+		contracts:  contracts,
+		reputation: reputation,
+		publisher:  publisher,
+	}
+}
+
+// ScoreContract computes and stores the score of a completed contract, then refreshes the freelancer's aggregate.
+func (s *ReputationService) ScoreContract(ctx context.Context, contractID uint) (*domain.ContractReputation, error) {
+	c, err := s.contracts.FindByID(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+	if c.Status != domain.ContractStatusDone || c.CompletedAt == nil {
+		return nil, ErrContractNotCompleted
+	}
+	in := reputationInputs(c)
+	res := reputation.Compute(in)
+	inputs, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	components, err := json.Marshal(res.Components)
+	if err != nil {
+		return nil, err
+	}
+	score := &domain.ContractReputation{
+		ContractID:       c.ID,
+		FreelancerUserID: c.FreelancerUserID,
+		FormulaVersion:   reputation.FormulaVersion,
+		Score:            res.Score,
+		Inputs:           string(inputs),
+		Components:       string(components),
+		ComputedAt:       time.Now(),
+	}
+	if err := s.reputation.SaveContractScore(ctx, score); err != nil {
+		return nil, err
+	}
+	if err := s.refreshAggregate(ctx, c.FreelancerUserID); err != nil {
+		return nil, err
+	}
+	return score, nil
+}
+
+// scoreInBackground is used after completion and the client's testimonial; Refresh picks the contract up if it fails.
+func (s *ReputationService) scoreInBackground(contractID uint) {
+	if _, err := s.ScoreContract(context.Background(), contractID); err != nil {
+		log.Printf("[reputation] contract %d: %v (will retry)", contractID, err)
+	}
+}
+
+// refreshAggregate recomputes the freelancer's score as the mean of their current-formula contract scores and
+// publishes it. A failed publish stays pending for Refresh.
+func (s *ReputationService) refreshAggregate(ctx context.Context, freelancerUserID uint) error {
+	scores, err := s.reputation.ListContractScores(ctx, freelancerUserID)
+	if err != nil {
+		return err
+	}
+	var sum float64
+	n := 0
+	for _, sc := range scores {
+		if sc.FormulaVersion != reputation.FormulaVersion {
+			continue // recomputed later; mixing versions would skew the mean
+		}
+		sum += sc.Score
+		n++
+	}
+	agg := &domain.FreelancerReputation{
+		FreelancerUserID: freelancerUserID,
+		FormulaVersion:   reputation.FormulaVersion,
+		ContractsScored:  n,
+		UpdatedAt:        time.Now(),
+	}
+	if n > 0 {
+		agg.Score = math.Round(sum/float64(n)*10) / 10
+	}
+	if err := s.reputation.SaveAggregate(ctx, agg); err != nil {
+		return err
+	}
+	if err := s.publishAggregate(ctx, agg); err != nil {
+		log.Printf("[reputation] freelancer %d: %v (will retry)", freelancerUserID, err)
+	}
+	return nil
+}
+
+func (s *ReputationService) publishAggregate(ctx context.Context, agg *domain.FreelancerReputation) error {
+	err := s.publisher.PublishReputationUpdated(ctx, event.ReputationUpdated{
+		FreelancerUserID: agg.FreelancerUserID,
+		Score:            agg.Score,
+		ContractsScored:  agg.ContractsScored,
+		FormulaVersion:   agg.FormulaVersion,
+		UpdatedAt:        agg.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	return s.reputation.MarkAggregatePublished(ctx, agg.FreelancerUserID, agg.UpdatedAt, time.Now())
+}
+
+// Refresh scores completed contracts that have no score from the current formula version (new completions that
+// failed to score, or every contract after a formula change) and re-sends unpublished aggregates. Run periodically
+// (see job.ReputationRunner); returns how many contracts were scored.
+func (s *ReputationService) Refresh(ctx context.Context) (int64, error) {
+	ids, err := s.reputation.ListUnscoredContracts(ctx, reputation.FormulaVersion, 100)
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	for _, id := range ids {
+		if _, err := s.ScoreContract(ctx, id); err != nil {
+			log.Printf("[reputation] contract %d: %v", id, err)
+			continue
+		}
+		n++
+	}
+	pending, err := s.reputation.ListUnpublishedAggregates(ctx, 100)
+	if err != nil {
+		return n, err
+	}
+	for _, agg := range pending {
+		if err := s.publishAggregate(ctx, agg); err != nil {
+			log.Printf("[reputation] freelancer %d: %v", agg.FreelancerUserID, err)
+		}
+	}
+	return n, nil
+}
+
+// GetContractScore explains the score of one of the freelancer's contracts.
+func (s *ReputationService) GetContractScore(ctx context.Context, contractID, freelancerUserID uint) (*dto.ContractReputationResponse, error) {
+	if _, err := s.contracts.GetByID(ctx, contractID, freelancerUserID); err != nil {
+		return nil, err
+	}
+	sc, err := s.reputation.GetContractScore(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+	out := &dto.ContractReputationResponse{
+		ContractID:     sc.ContractID,
+		Score:          sc.Score,
+		FormulaVersion: sc.FormulaVersion,
+		ComputedAt:     sc.ComputedAt,
+	}
+	if err := json.Unmarshal([]byte(sc.Components), &out.Components); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(sc.Inputs), &out.Inputs); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Summary returns the freelancer's aggregate score and the per-contract scores behind it.
+func (s *ReputationService) Summary(ctx context.Context, freelancerUserID uint) (*dto.ReputationSummaryResponse, error) {
+	out := &dto.ReputationSummaryResponse{FormulaVersion: reputation.FormulaVersion, Contracts: []dto.ContractScoreSummary{}}
+	agg, err := s.reputation.GetAggregate(ctx, freelancerUserID)
+	switch {
+	case err == nil:
+		out.Score = agg.Score
+		out.ContractsScored = agg.ContractsScored
+		out.FormulaVersion = agg.FormulaVersion
+		out.UpdatedAt = &agg.UpdatedAt
+	case !errors.Is(err, repository.ErrReputationNotFound):
+		return nil, err
+	}
+	scores, err := s.reputation.ListContractScores(ctx, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	for _, sc := range scores {
+		out.Contracts = append(out.Contracts, dto.ContractScoreSummary{
+			ContractID:     sc.ContractID,
+			Score:          sc.Score,
+			FormulaVersion: sc.FormulaVersion,
+			ComputedAt:     sc.ComputedAt,
+		})
+	}
+	return out, nil
+}
+
+func reputationInputs(c *domain.Contract) reputation.Inputs {
+	in := reputation.Inputs{
+		ContractDueDate:      c.DueDate,
+		CompletedAt:          *c.CompletedAt,
+		ClientRating:         c.ClientRating,
+		ClientSignerVerified: c.ClientSignerVerified,
+		ClientVerification:   clientVerificationFromJSON(c.ClientVerification),
+	}
+	for _, m := range c.Milestones {
+		in.Milestones = append(in.Milestones, reputation.Milestone{
+			ID:            m.ID,
+			DueDate:       m.DueDate,
+			ApprovedAt:    m.ApprovedAt,
+			RevisionCount: m.RevisionCount,
+		})
+	}
+	return in
+}
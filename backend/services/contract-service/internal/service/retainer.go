@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+)
+
+// retainerCatchUpRounds bounds how many periods one run generates per contract after downtime; later runs continue.
+const retainerCatchUpRounds = 12
+
+// GenerateRetainerCycles adds the period milestone of every signed or active retainer whose next billing period has
+// started. Run periodically (see job.RetainerCycleRunner); returns how many periods were generated.
+func (s *MilestoneService) GenerateRetainerCycles(ctx context.Context) (int64, error) {
+	now := time.Now()
+	var n int64
+	for round := 0; round < retainerCatchUpRounds; round++ {
+		list, err := s.milestones.ListRetainersDue(ctx, now, postSignStatuses, 100)
+		if err != nil {
+			return n, err
+		}
+		progressed := false
+		for _, c := range list {
+			added, err := s.addRetainerPeriod(ctx, c)
+			if err != nil {
+				log.Printf("[retainer-cycles] contract %d: %v", c.ID, err)
+				continue
+			}
+			if added {
+				n++
+				progressed = true
+			}
+		}
+		if !progressed {
+			break
+		}
+	}
+	return n, nil
+}
+
+// addRetainerPeriod generates c's next period: due at the period end, billed at the current retainer amount.
+func (s *MilestoneService) addRetainerPeriod(ctx context.Context, c *domain.Contract) (bool, error) {
+	if c.ClientSignedAt == nil {
+		return false, nil
+	}
+	start := addBillingCycles(*c.ClientSignedAt, c.BillingCycle, c.RetainerCyclesGenerated)
+	end := addBillingCycles(*c.ClientSignedAt, c.BillingCycle, c.RetainerCyclesGenerated+1)
+	m := &domain.ContractMilestone{
+		Title:   fmt.Sprintf("Retainer period %d: %s to %s", c.RetainerCyclesGenerated+1, start.UTC().Format("2 Jan 2006"), end.UTC().AddDate(0, 0, -1).Format("2 Jan 2006")),
+		Amount:  c.RetainerAmount,
+		DueDate: &end,
+		Status:  domain.MilestoneStatusPending,
+	}
+	return s.milestones.AddRetainerPeriod(ctx, c, m, end)
+}
+
+// addBillingCycles returns the start of period n counted from anchor (the client sign). Months keep the anchor's
+// day, clamped to the month's last day, so a retainer signed on the 31st bills on the 30th in 30-day months.
+func addBillingCycles(anchor time.Time, cycle string, n int) time.Time {
+	if cycle == domain.BillingCycleWeekly {
+		return anchor.AddDate(0, 0, 7*n)
+	}
+	y, m, d := anchor.Date()
+	first := time.Date(y, m+time.Month(n), 1, anchor.Hour(), anchor.Minute(), anchor.Second(), anchor.Nanosecond(), anchor.Location())
+	if last := first.AddDate(0, 1, -1).Day(); d > last {
+		d = last
+	}
+	return first.AddDate(0, 0, d-1)
+}
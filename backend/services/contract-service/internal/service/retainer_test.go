@@ -0,0 +1,37 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+)
+
+func TestAddBillingCycles(t *testing.T) {
+	at := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 14, 30, 0, 0, time.UTC) }
+	tests := []struct {
+		name   string
+		anchor time.Time
+		cycle  string
+		n      int
+		want   time.Time
+	}{
+		{"weekly, period 0 is the sign", at(2026, 3, 4), domain.BillingCycleWeekly, 0, at(2026, 3, 4)},
+		{"weekly", at(2026, 3, 4), domain.BillingCycleWeekly, 3, at(2026, 3, 25)},
+		{"weekly across a year", at(2026, 12, 28), domain.BillingCycleWeekly, 1, at(2027, 1, 4)},
+		{"monthly", at(2026, 1, 15), domain.BillingCycleMonthly, 1, at(2026, 2, 15)},
+		{"monthly across a year", at(2026, 11, 15), domain.BillingCycleMonthly, 3, at(2027, 2, 15)},
+		{"31st in a 30-day month", at(2026, 1, 31), domain.BillingCycleMonthly, 3, at(2026, 4, 30)},
+		{"31st in February", at(2026, 1, 31), domain.BillingCycleMonthly, 1, at(2026, 2, 28)},
+		{"31st in a leap February", at(2028, 1, 31), domain.BillingCycleMonthly, 1, at(2028, 2, 29)},
+		{"clamping does not drift", at(2026, 1, 31), domain.BillingCycleMonthly, 2, at(2026, 3, 31)},
+		{"29 Feb anchor", at(2028, 2, 29), domain.BillingCycleMonthly, 12, at(2029, 2, 28)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := addBillingCycles(tt.anchor, tt.cycle, tt.n); !got.Equal(tt.want) {
+				t.Fatalf("addBillingCycles = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"math"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+)
+
+// maxShowcased caps how many completed contracts a public profile lists.
+const maxShowcased = 50
+
+// valueBands are upper bounds (exclusive) for the value band shown instead of the contract amount.
+var valueBands = []struct {
+	upTo  float64
+	label string
+}{
+	{1000, "<1k"},
+	{5000, "1k-5k"},
+	{25000, "5k-25k"},
+	{100000, "25k-100k"},
+	{500000, "100k-500k"},
+}
+
+// ShowcaseService builds the redacted contract summaries user-service shows on public profiles, and lets the
+// freelancer choose per contract whether it is hidden, anonymized or shown in full.
+type ShowcaseService struct {
+	showcase repository.ShowcaseRepository
+}
+
+func NewShowcaseService(showcase repository.ShowcaseRepository) *ShowcaseService {
+	return &ShowcaseService{showcase: showcase}
+}
+
+// SetProfileVisibility sets how one of the freelancer's contracts appears on their public profile.
+func (s *ShowcaseService) SetProfileVisibility(ctx context.Context, contractID, freelancerUserID uint, visibility string) error {
+	return s.showcase.SetProfileVisibility(ctx, contractID, freelancerUserID, visibility)
+}
+
+// ListForProfile returns the freelancer's showcased completed contracts, newest first.
+func (s *ShowcaseService) ListForProfile(ctx context.Context, freelancerUserID uint) ([]dto.ContractSummary, error) {
+	list, err := s.showcase.ListShowcased(ctx, freelancerUserID, maxShowcased)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]dto.ContractSummary, 0, len(list))
+	for _, c := range list {
+		if c.CompletedAt == nil || c.ProfileVisibility == domain.ProfileVisibilityHidden {
+			continue
+		}
+		out = append(out, contractSummary(c))
+	}
+	return out, nil
+}
+
+func contractSummary(c *domain.Contract) dto.ContractSummary {
+	sum := dto.ContractSummary{
+		ContractID:      c.ID,
+		Visibility:      domain.ProfileVisibilityAnonymized,
+		ProjectCategory: c.ProjectCategory,
+		ContractType:    contractType(c),
+		CompletedAt:     *c.CompletedAt,
+		OnTime:          deliveredOnTime(c),
+		Rating:          c.ClientRating,
+		Testimonial:     c.ClientTestimonial,
+	}
+	start := c.ClientSignedAt
+	if start == nil {
+		start = &c.CreatedAt
+	}
+	if d := c.CompletedAt.Sub(*start); d > 0 {
+		sum.DurationDays = int(math.Ceil(d.Hours() / 24))
+	}
+	// anything other than full is treated as anonymized, so an unknown value never leaks the client
+	if c.ProfileVisibility != domain.ProfileVisibilityFull {
+		return sum
+	}
+	sum.Visibility = domain.ProfileVisibilityFull
+	sum.ValueBand = valueBand(c.TotalAmount)
+	sum.Currency = c.Currency
+	if c.ClientPublicConsent {
+		amount := c.TotalAmount
+		sum.Amount = &amount
+		sum.ClientName = c.ClientName
+		sum.ClientCompanyName = c.ClientCompanyName
+	}
+	return sum
+}
+
+func valueBand(amount float64) string {
+	for _, b := range valueBands {
+		if amount < b.upTo {
+			return b.label
+		}
+	}
+	return "500k+"
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrSignCodeRequired         = errors.New("otp_code is required to sign this contract; request a code first")
+	ErrInvalidSignCode          = errors.New("otp_code is invalid")
+	ErrSignCodeExpired          = errors.New("otp_code has expired; request a new code")
+	ErrSignCodeAttemptsExceeded = errors.New("too many wrong codes; request a new code")
+	ErrSignCodeTooSoon          = errors.New("a code was sent recently; wait before requesting another")
+)
+
+// SignOTPSettings controls email one-time codes for client sign. Zero values get defaults in NewContractService.
+type SignOTPSettings struct {
+	Required    bool          // when false, a code is optional but still marks the signer verified
+	TTL         time.Duration // default 10 minutes
+	MaxAttempts int           // wrong codes allowed per issued code (default 5)
+	Cooldown    time.Duration // minimum time between codes for a contract (default 60 seconds)
+}
+
+func (o SignOTPSettings) withDefaults() SignOTPSettings {
+	if o.TTL <= 0 {
+		o.TTL = 10 * time.Minute
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 5
+	}
+	if o.Cooldown <= 0 {
+		o.Cooldown = time.Minute
+	}
+	return o
+}
+
+// RequestSignCode emails a 6-digit code to the contract's client_email. Codes never go to an address the caller
+// chooses, so a link holder cannot verify as the client from their own inbox.
+// Allowed while the client has not signed yet (sent or partially_signed). Issuing a new code replaces older ones.
+func (s *ContractService) RequestSignCode(ctx context.Context, token string) (*dto.RequestSignCodeResponse, error) {
+	c, err := s.repo.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := clientCanSign(c); err != nil {
+		return nil, err
+	}
+	return s.issueSignCode(ctx, c.ID, 0, c.ClientEmail)
+}
+
+// issueSignCode creates and emails a code for the contract's client (signerID 0) or an additional signer, once per
+// cooldown for each of them.
+func (s *ContractService) issueSignCode(ctx context.Context, contractID, signerID uint, email string) (*dto.RequestSignCodeResponse, error) {
+	now := time.Now()
+	if last, err := s.otpRepo.FindLatest(ctx, contractID, signerID); err == nil {
+		if now.Sub(last.CreatedAt) < s.signOTP.Cooldown {
+			return nil, ErrSignCodeTooSoon
+		}
+	} else if !errors.Is(err, repository.ErrSignOTPNotFound) {
+		return nil, err
+	}
+
+	email = strings.ToLower(email)
+	code, err := generateSignCode()
+	if err != nil {
+		return nil, err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	otp := &domain.ContractSignOTP{
+		ContractID: contractID,
+		Email:      email,
+		CodeHash:   string(hash),
+		ExpiresAt:  now.Add(s.signOTP.TTL),
+	}
+	if signerID != 0 {
+		otp.SignerID = &signerID
+	}
+	if err := s.otpRepo.Create(ctx, otp); err != nil {
+		return nil, err
+	}
+	go s.notifier.NotifySignCode(context.Background(), contractID, email, code, otp.ExpiresAt)
+	return &dto.RequestSignCodeResponse{SentTo: maskEmail(email), ExpiresAt: otp.ExpiresAt}, nil
+}
+
+// verifySignCode checks code against the latest code issued to email for the contract's client (signerID 0) or an
+// additional signer. It does not consume the code:
+// pass the returned ID to the sign update, which consumes it only if the sign lands, so a failed sign does not burn
+// the code. A code sent to another address (e.g. before client_email was changed) does not count.
+// Wrong codes count against MaxAttempts.
+func (s *ContractService) verifySignCode(ctx context.Context, contractID, signerID uint, email, code string) (*domain.ContractSignOTP, error) {
+	if code == "" {
+		return nil, ErrSignCodeRequired
+	}
+	otp, err := s.otpRepo.FindLatest(ctx, contractID, signerID)
+	if err != nil {
+		if errors.Is(err, repository.ErrSignOTPNotFound) {
+			return nil, ErrSignCodeRequired
+		}
+		return nil, err
+	}
+	if !strings.EqualFold(otp.Email, email) {
+		return nil, ErrSignCodeRequired
+	}
+	now := time.Now()
+	if now.After(otp.ExpiresAt) {
+		return nil, ErrSignCodeExpired
+	}
+	if otp.Attempts >= s.signOTP.MaxAttempts {
+		return nil, ErrSignCodeAttemptsExceeded
+	}
+	if bcrypt.CompareHashAndPassword([]byte(otp.CodeHash), []byte(code)) != nil {
+		if err := s.otpRepo.IncrementAttempts(ctx, otp.ID); err != nil {
+			return nil, err
+		}
+		if otp.Attempts+1 >= s.signOTP.MaxAttempts {
+			return nil, ErrSignCodeAttemptsExceeded
+		}
+		return nil, ErrInvalidSignCode
+	}
+	return otp, nil
+}
+
+func generateSignCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// maskEmail keeps the first character of the local part and the domain: a***@example.com
+func maskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return "***"
+	}
+	return email[:1] + "***" + email[at:]
+}
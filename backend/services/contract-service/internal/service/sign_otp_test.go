@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/notification"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fakeSignOTPRepo keeps codes in memory, newest last
+type fakeSignOTPRepo struct {
+	otps []*domain.ContractSignOTP
+}
+
+func (r *fakeSignOTPRepo) Create(_ context.Context, otp *domain.ContractSignOTP) error {
+	otp.ID = uint(len(r.otps) + 1)
+	if otp.CreatedAt.IsZero() {
+		otp.CreatedAt = time.Now()
+	}
+	r.otps = append(r.otps, otp)
+	return nil
+}
+
+func (r *fakeSignOTPRepo) FindLatest(_ context.Context, contractID, signerID uint) (*domain.ContractSignOTP, error) {
+	for i := len(r.otps) - 1; i >= 0; i-- {
+		o := r.otps[i]
+		if o.ContractID != contractID || o.ConsumedAt != nil {
+			continue
+		}
+		if (signerID == 0 && o.SignerID == nil) || (o.SignerID != nil && *o.SignerID == signerID) {
+			cp := *o
+			return &cp, nil
+		}
+	}
+	return nil, repository.ErrSignOTPNotFound
+}
+
+func (r *fakeSignOTPRepo) IncrementAttempts(_ context.Context, id uint) error {
+	r.otps[id-1].Attempts++
+	return nil
+}
+
+func newSignOTPTestService(repo repository.SignOTPRepository) *ContractService {
+	return &ContractService{otpRepo: repo, notifier: notification.NoopNotifier{}, signOTP: SignOTPSettings{}.withDefaults()}
+}
+
+func testCodeHash(t *testing.T, code string) string {
+	t.Helper()
+	h, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(h)
+}
+
+func TestVerifySignCode(t *testing.T) {
+	const contractID, signerID = 1, 5
+	hash := testCodeHash(t, "123456")
+	sid := uint(signerID)
+	future, past := time.Now().Add(time.Hour), time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name     string
+		stored   *domain.ContractSignOTP // nil = no code issued
+		signerID uint
+		email    string
+		code     string
+		want     error
+		attempts int // stored attempts afterwards
+	}{
+		{"no code given", &domain.ContractSignOTP{Email: "client@example.com", CodeHash: hash, ExpiresAt: future}, 0, "client@example.com", "", ErrSignCodeRequired, 0},
+		{"none issued", nil, 0, "client@example.com", "123456", ErrSignCodeRequired, 0},
+		{"valid", &domain.ContractSignOTP{Email: "client@example.com", CodeHash: hash, ExpiresAt: future}, 0, "client@example.com", "123456", nil, 0},
+		{"email compared case-insensitively", &domain.ContractSignOTP{Email: "client@example.com", CodeHash: hash, ExpiresAt: future}, 0, "Client@Example.com", "123456", nil, 0},
+		{"sent to another address", &domain.ContractSignOTP{Email: "old@example.com", CodeHash: hash, ExpiresAt: future}, 0, "client@example.com", "123456", ErrSignCodeRequired, 0},
+		{"expired", &domain.ContractSignOTP{Email: "client@example.com", CodeHash: hash, ExpiresAt: past}, 0, "client@example.com", "123456", ErrSignCodeExpired, 0},
+		{"attempts used up", &domain.ContractSignOTP{Email: "client@example.com", CodeHash: hash, ExpiresAt: future, Attempts: 5}, 0, "client@example.com", "123456", ErrSignCodeAttemptsExceeded, 5},
+		{"wrong code", &domain.ContractSignOTP{Email: "client@example.com", CodeHash: hash, ExpiresAt: future}, 0, "client@example.com", "654321", ErrInvalidSignCode, 1},
+		{"last wrong code", &domain.ContractSignOTP{Email: "client@example.com", CodeHash: hash, ExpiresAt: future, Attempts: 4}, 0, "client@example.com", "654321", ErrSignCodeAttemptsExceeded, 5},
+		{"signer code", &domain.ContractSignOTP{SignerID: &sid, Email: "lead@example.com", CodeHash: hash, ExpiresAt: future}, signerID, "lead@example.com", "123456", nil, 0},
+		{"client code does not sign for a signer", &domain.ContractSignOTP{Email: "client@example.com", CodeHash: hash, ExpiresAt: future}, signerID, "client@example.com", "123456", ErrSignCodeRequired, 0},
+		{"signer code does not sign for the client", &domain.ContractSignOTP{SignerID: &sid, Email: "lead@example.com", CodeHash: hash, ExpiresAt: future}, 0, "lead@example.com", "123456", ErrSignCodeRequired, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeSignOTPRepo{}
+			if tt.stored != nil {
+				tt.stored.ContractID = contractID
+				_ = repo.Create(context.Background(), tt.stored)
+			}
+			s := newSignOTPTestService(repo)
+			otp, err := s.verifySignCode(context.Background(), contractID, tt.signerID, tt.email, tt.code)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if tt.want == nil && (otp == nil || otp.ID != tt.stored.ID) {
+				t.Fatalf("otp = %+v, want the stored code", otp)
+			}
+			if tt.stored != nil && tt.stored.Attempts != tt.attempts {
+				t.Fatalf("attempts = %d, want %d", tt.stored.Attempts, tt.attempts)
+			}
+			if tt.stored != nil && tt.stored.ConsumedAt != nil {
+				t.Fatal("verifySignCode must not consume the code; the sign transaction does")
+			}
+		})
+	}
+}
+
+func TestIssueSignCode(t *testing.T) {
+	repo := &fakeSignOTPRepo{}
+	s := newSignOTPTestService(repo)
+	ctx := context.Background()
+
+	out, err := s.issueSignCode(ctx, 1, 0, "Client@Example.com")
+	if err != nil {
+		t.Fatalf("client code: %v", err)
+	}
+	if out.SentTo != "c***@example.com" {
+		t.Fatalf("SentTo = %q", out.SentTo)
+	}
+	if got := repo.otps[0]; got.Email != "client@example.com" || got.SignerID != nil {
+		t.Fatalf("client code stored as %+v", got)
+	}
+	if _, err := s.issueSignCode(ctx, 1, 0, "client@example.com"); !errors.Is(err, ErrSignCodeTooSoon) {
+		t.Fatalf("second client code within cooldown: err = %v, want %v", err, ErrSignCodeTooSoon)
+	}
+	// each signer has their own cooldown
+	if _, err := s.issueSignCode(ctx, 1, 5, "lead@example.com"); err != nil {
+		t.Fatalf("signer code: %v", err)
+	}
+	if got := repo.otps[1]; got.SignerID == nil || *got.SignerID != 5 || got.Email != "lead@example.com" {
+		t.Fatalf("signer code stored as %+v", got)
+	}
+
+	repo.otps[0].CreatedAt = time.Now().Add(-2 * s.signOTP.Cooldown)
+	if _, err := s.issueSignCode(ctx, 1, 0, "client@example.com"); err != nil {
+		t.Fatalf("client code after cooldown: %v", err)
+	}
+}
+
+func TestMaskEmail(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"client@example.com", "c***@example.com"},
+		{"a@b.co", "a***@b.co"},
+		{"@example.com", "***"},
+		{"no-at-sign", "***"},
+	}
+	for _, tt := range tests {
+		if got := maskEmail(tt.in); got != tt.want {
+			t.Errorf("maskEmail(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
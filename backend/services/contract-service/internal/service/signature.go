@@ -0,0 +1,313 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/png" // registers the PNG decoder for image.DecodeConfig
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/storage"
+)
+
+var (
+	ErrSignatureRequired        = errors.New("a typed or drawn signature is required to sign")
+	ErrSignatureTooLarge        = errors.New("signature image exceeds the maximum size")
+	ErrInvalidSignature         = errors.New("signature must be a PNG or a plain SVG drawing")
+	ErrSignatureCaptureDisabled = errors.New("signature capture is not configured")
+)
+
+// Signature types and typed styles
+const (
+	SignatureTypeTyped = "typed"
+	SignatureTypeDrawn = "drawn"
+
+	signatureStyleScript  = "script"
+	signatureStyleCursive = "cursive"
+	signatureStyleFormal  = "formal"
+
+	maxSignaturePixels = 2000 // per side, for drawn PNGs
+)
+
+// signatureFonts are the font stacks for typed signatures, with generic fallbacks where the named font is missing
+var signatureFonts = map[string]string{
+	signatureStyleScript:  `'Dancing Script', 'Brush Script MT', cursive`,
+	signatureStyleCursive: `'Great Vibes', 'Lucida Handwriting', cursive`,
+	signatureStyleFormal:  `'Times New Roman', Times, serif`,
+}
+
+// SignatureCapture controls the visible mark on client sign. Store is where the mark is kept (the attachment blob store);
+// without it, requests carrying a signature are rejected. Zero values get defaults in NewContractService.
+type SignatureCapture struct {
+	Store    storage.BlobStore
+	MaxBytes int64 // decoded image size limit (default 256 KB)
+	Required bool  // when true, sign fails without a signature
+}
+
+func (c SignatureCapture) withDefaults() SignatureCapture {
+	if c.MaxBytes <= 0 {
+		c.MaxBytes = 256 << 10
+	}
+	return c
+}
+
+// capturedSignature is a validated mark ready to be stored
+type capturedSignature struct {
+	Type        string
+	Style       string
+	ContentType string
+	Content     []byte
+}
+
+// signingEvidence is what the client's sign is bound to. It is stored as JSON and hashed; field order is fixed by the
+// struct, and maps marshal with sorted keys, so the same sign always gives the same hash.
+type signingEvidence struct {
+	ContractID      uint              `json:"contract_id"`
+	ContractVersion int               `json:"contract_version"`
+	TermsVersion    int               `json:"terms_version"`
+	TermsSHA256     string            `json:"terms_sha256"` // of the terms snapshot (dates, amount, criteria, T&C, milestones)
+	SignedAt        string            `json:"signed_at"`    // RFC 3339, UTC
+	CompanyAddress  string            `json:"company_address"`
+	SignMetadata    map[string]string `json:"sign_metadata"`
+	SignerVerified  bool              `json:"signer_verified"`
+	Signature       *signatureDigest  `json:"signature,omitempty"`
+}
+
+// signatureDigest identifies the stored mark within the evidence
+type signatureDigest struct {
+	Type        string `json:"type"`
+	Style       string `json:"style,omitempty"`
+	ContentType string `json:"content_type"`
+	SHA256      string `json:"sha256"`
+}
+
+// captureSignature validates in and renders typed names to SVG. It returns nil when no signature was given and none is required.
+func (s *ContractService) captureSignature(in *dto.SignatureInput) (*capturedSignature, error) {
+	if in == nil {
+		if s.signatures.Required {
+			return nil, ErrSignatureRequired
+		}
+		return nil, nil
+	}
+	if s.signatures.Store == nil {
+		return nil, ErrSignatureCaptureDisabled
+	}
+	switch in.Type {
+	case SignatureTypeTyped:
+		name := strings.TrimSpace(in.TypedName)
+		if name == "" {
+			return nil, ErrSignatureRequired
+		}
+		style := in.Style
+		if signatureFonts[style] == "" {
+			style = signatureStyleScript
+		}
+		return &capturedSignature{Type: SignatureTypeTyped, Style: style, ContentType: "image/svg+xml", Content: renderTypedSignature(name, style)}, nil
+	case SignatureTypeDrawn:
+		content, err := decodeSignatureImage(in.Image, s.signatures.MaxBytes)
+		if err != nil {
+			return nil, err
+		}
+		contentType := strings.ToLower(strings.SplitN(mimetype.Detect(content).String(), ";", 2)[0])
+		switch contentType {
+		case "image/png":
+			cfg, _, err := image.DecodeConfig(bytes.NewReader(content))
+			if err != nil || cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width > maxSignaturePixels || cfg.Height > maxSignaturePixels {
+				return nil, ErrInvalidSignature
+			}
+		case "image/svg+xml":
+			if err := checkSignatureSVG(content); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, ErrInvalidSignature
+		}
+		return &capturedSignature{Type: SignatureTypeDrawn, ContentType: contentType, Content: content}, nil
+	}
+	return nil, ErrInvalidSignature
+}
+
+// storeSignature puts the mark in the blob store and returns the attachment row for the sign transaction to create.
+func (s *ContractService) storeSignature(ctx context.Context, contractID uint, sig *capturedSignature) (*domain.ContractAttachment, error) {
+	cid, digest := storage.ComputeCID(sig.Content)
+	if err := s.signatures.Store.Put(ctx, cid, sig.Content, sig.ContentType); err != nil {
+		return nil, err
+	}
+	ext := ".svg"
+	if sig.ContentType == "image/png" {
+		ext = ".png"
+	}
+	return &domain.ContractAttachment{
+		ContractID:       contractID,
+		UploadedByUserID: 0,
+		Kind:             domain.AttachmentKindSignature,
+		FileName:         "client-signature" + ext,
+		ContentType:      sig.ContentType,
+		Size:             int64(len(sig.Content)),
+		CID:              cid,
+		SHA256:           hex.EncodeToString(digest[:]),
+	}, nil
+}
+
+// buildSigningEvidence returns the evidence JSON for the client's sign of c and its sha256 (hex).
+func buildSigningEvidence(c *domain.Contract, signedAt time.Time, companyAddress string, meta map[string]string, signerVerified bool, sig *capturedSignature, a *domain.ContractAttachment) (string, string) {
+	terms, _ := json.Marshal(termsSnapshot(c))
+	termsSum := sha256.Sum256(terms)
+	ev := signingEvidence{
+		ContractID:      c.ID,
+		ContractVersion: c.Version,
+		TermsVersion:    termsVersion(c),
+		TermsSHA256:     hex.EncodeToString(termsSum[:]),
+		SignedAt:        signedAt.UTC().Format(time.RFC3339Nano),
+		CompanyAddress:  companyAddress,
+		SignMetadata:    meta,
+		SignerVerified:  signerVerified,
+	}
+	if sig != nil && a != nil {
+		ev.Signature = &signatureDigest{Type: sig.Type, Style: sig.Style, ContentType: a.ContentType, SHA256: a.SHA256}
+	}
+	b, _ := json.Marshal(ev)
+	sum := sha256.Sum256(b)
+	return string(b), hex.EncodeToString(sum[:])
+}
+
+// signatureToResponse describes the client's mark and evidence hash; nil until the client signed.
+func signatureToResponse(c *domain.Contract) *dto.SignatureResponse {
+	if c.ClientEvidenceHash == "" {
+		return nil
+	}
+	out := &dto.SignatureResponse{Type: c.ClientSignatureType, AttachmentID: c.ClientSignatureID, EvidenceHash: c.ClientEvidenceHash}
+	var ev signingEvidence
+	if json.Unmarshal([]byte(c.ClientSignEvidence), &ev) == nil && ev.Signature != nil {
+		out.Style = ev.Signature.Style
+		out.ContentType = ev.Signature.ContentType
+		out.SHA256 = ev.Signature.SHA256
+	}
+	return out
+}
+
+// renderTypedSignature draws name as an SVG text mark in the style's font, sized to the name.
+func renderTypedSignature(name, style string) []byte {
+	var esc bytes.Buffer
+	_ = xml.EscapeText(&esc, []byte(name))
+	width := 40 + 22*utf8.RuneCountInString(name)
+	fontStyle := "normal"
+	if style == signatureStyleFormal {
+		fontStyle = "italic"
+	}
+	return []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="80" viewBox="0 0 %d 80">`+
+		`<text x="20" y="55" font-family="%s" font-size="40" font-style="%s" fill="#111">%s</text></svg>`,
+		width, width, signatureFonts[style], fontStyle, esc.String()))
+}
+
+// decodeSignatureImage accepts a data URL, plain base64, or raw SVG markup and enforces maxBytes on the decoded size.
+func decodeSignatureImage(s string, maxBytes int64) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "<") {
+		if int64(len(s)) > maxBytes {
+			return nil, ErrSignatureTooLarge
+		}
+		return []byte(s), nil
+	}
+	if strings.HasPrefix(s, "data:") {
+		i := strings.Index(s, ",")
+		if i < 0 || !strings.HasSuffix(s[:i], ";base64") {
+			return nil, ErrInvalidSignature
+		}
+		s = s[i+1:]
+	}
+	if int64(base64.StdEncoding.DecodedLen(len(s))) > maxBytes+2 {
+		return nil, ErrSignatureTooLarge
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	if len(b) == 0 {
+		return nil, ErrInvalidSignature
+	}
+	if int64(len(b)) > maxBytes {
+		return nil, ErrSignatureTooLarge
+	}
+	return b, nil
+}
+
+// svgAllowedElements and svgAllowedAttrs are the plain drawing subset accepted for drawn signatures:
+// no scripts, links, embedded content, styles or external references.
+var svgAllowedElements = map[string]bool{
+	"svg": true, "g": true, "path": true, "polyline": true, "polygon": true, "line": true,
+	"circle": true, "ellipse": true, "rect": true, "title": true, "desc": true,
+}
+
+var svgAllowedAttrs = map[string]bool{
+	"xmlns": true, "version": true, "width": true, "height": true, "viewBox": true, "preserveAspectRatio": true,
+	"d": true, "points": true, "x": true, "y": true, "x1": true, "y1": true, "x2": true, "y2": true,
+	"cx": true, "cy": true, "r": true, "rx": true, "ry": true, "transform": true,
+	"fill": true, "fill-opacity": true, "fill-rule": true, "opacity": true,
+	"stroke": true, "stroke-width": true, "stroke-opacity": true, "stroke-linecap": true, "stroke-linejoin": true, "stroke-miterlimit": true,
+}
+
+// checkSignatureSVG accepts only a well-formed <svg> document built from the allowed elements and attributes.
+func checkSignatureSVG(content []byte) error {
+	d := xml.NewDecoder(bytes.NewReader(content))
+	depth, roots := 0, 0
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ErrInvalidSignature
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if !svgAllowedElements[t.Name.Local] || (t.Name.Space != "" && t.Name.Space != "http://www.w3.org/2000/svg") {
+				return ErrInvalidSignature
+			}
+			if depth == 0 {
+				if t.Name.Local != "svg" {
+					return ErrInvalidSignature
+				}
+				roots++
+			}
+			for _, a := range t.Attr {
+				if a.Name.Space == "xmlns" {
+					continue // namespace declarations; prefixed elements and attributes are rejected above and below
+				}
+				if a.Name.Space != "" || !svgAllowedAttrs[a.Name.Local] {
+					return ErrInvalidSignature
+				}
+				v := strings.ToLower(a.Value)
+				if strings.Contains(v, "url(") || strings.Contains(v, "javascript:") {
+					return ErrInvalidSignature
+				}
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.Directive:
+			return ErrInvalidSignature // DOCTYPE / ENTITY
+		case xml.ProcInst:
+			if t.Target != "xml" {
+				return ErrInvalidSignature
+			}
+		}
+	}
+	if roots != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/storage"
+)
+
+func TestCheckSignatureSVG(t *testing.T) {
+	tests := []struct {
+		name string
+		svg  string
+		ok   bool
+	}{
+		{"path", `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 100 40"><path d="M0 0 L10 10" stroke="#000" stroke-width="2" fill="none"/></svg>`, true},
+		{"xml declaration and groups", `<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"><g transform="scale(2)"><polyline points="0,0 5,5"/><title>Signature</title></g></svg>`, true},
+		{"unused namespace declaration", `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><line x1="0" y1="0" x2="1" y2="1"/></svg>`, true},
+		{"script", `<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`, false},
+		{"event handler", `<svg xmlns="http://www.w3.org/2000/svg" onload="alert(1)"><path d="M0 0"/></svg>`, false},
+		{"link", `<svg xmlns="http://www.w3.org/2000/svg"><a href="https://example.com"><path d="M0 0"/></a></svg>`, false},
+		{"external reference", `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><use xlink:href="https://example.com/x.svg#a"/></svg>`, false},
+		{"prefixed attribute", `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><path xlink:href="#a" d="M0 0"/></svg>`, false},
+		{"url in fill", `<svg xmlns="http://www.w3.org/2000/svg"><rect width="1" height="1" fill="url(https://example.com/p)"/></svg>`, false},
+		{"javascript in attribute", `<svg xmlns="http://www.w3.org/2000/svg"><path d="M0 0" transform="JavaScript:alert(1)"/></svg>`, false},
+		{"style element", `<svg xmlns="http://www.w3.org/2000/svg"><style>path{fill:red}</style></svg>`, false},
+		{"style attribute", `<svg xmlns="http://www.w3.org/2000/svg"><path d="M0 0" style="fill:red"/></svg>`, false},
+		{"text", `<svg xmlns="http://www.w3.org/2000/svg"><text>Jane</text></svg>`, false},
+		{"foreign namespace", `<svg xmlns="http://www.w3.org/2000/svg"><h:div xmlns:h="http://www.w3.org/1999/xhtml"/></svg>`, false},
+		{"doctype entity", `<!DOCTYPE svg [<!ENTITY x "y">]><svg xmlns="http://www.w3.org/2000/svg"/>`, false},
+		{"processing instruction", `<?xml-stylesheet href="x.css"?><svg xmlns="http://www.w3.org/2000/svg"/>`, false},
+		{"root is not svg", `<g><path d="M0 0"/></g>`, false},
+		{"two roots", `<svg xmlns="http://www.w3.org/2000/svg"/><svg xmlns="http://www.w3.org/2000/svg"/>`, false},
+		{"malformed", `<svg xmlns="http://www.w3.org/2000/svg"><path d="M0 0">`, false},
+		{"empty", ``, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSignatureSVG([]byte(tt.svg))
+			if tt.ok && err != nil {
+				t.Fatalf("rejected: %v", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrInvalidSignature) {
+				t.Fatalf("err = %v, want %v", err, ErrInvalidSignature)
+			}
+		})
+	}
+}
+
+func TestDecodeSignatureImage(t *testing.T) {
+	raw := []byte("signature bytes")
+	b64 := base64.StdEncoding.EncodeToString(raw)
+	tests := []struct {
+		name     string
+		in       string
+		maxBytes int64
+		want     []byte
+		wantErr  error
+	}{
+		{"data url", "data:image/png;base64," + b64, 100, raw, nil},
+		{"plain base64", "  " + b64 + "\n", 100, raw, nil},
+		{"raw svg", "<svg/>", 100, []byte("<svg/>"), nil},
+		{"raw svg too large", "<svg/>", 5, nil, ErrSignatureTooLarge},
+		{"decoded too large", b64, int64(len(raw) - 1), nil, ErrSignatureTooLarge},
+		{"data url without base64", "data:image/svg+xml,<svg/>", 100, nil, ErrInvalidSignature},
+		{"data url without comma", "data:image/png;base64", 100, nil, ErrInvalidSignature},
+		{"bad base64", "not base64!", 100, nil, ErrInvalidSignature},
+		{"empty", "", 100, nil, ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeSignatureImage(tt.in, tt.maxBytes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Fatalf("decoded %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// nopBlobStore only satisfies SignatureCapture.Store; captureSignature never writes to it.
+type nopBlobStore struct{ storage.BlobStore }
+
+func testPNG(t *testing.T, w, h int) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestCaptureSignature(t *testing.T) {
+	plainSVG := `<svg xmlns="http://www.w3.org/2000/svg"><path d="M0 0 L5 5"/></svg>`
+	tests := []struct {
+		name        string
+		required    bool
+		noStore     bool
+		in          *dto.SignatureInput
+		wantErr     error
+		wantType    string
+		wantContent string // content type
+	}{
+		{"none, optional", false, false, nil, nil, "", ""},
+		{"none, required", true, false, nil, ErrSignatureRequired, "", ""},
+		{"no store", false, true, &dto.SignatureInput{Type: SignatureTypeTyped, TypedName: "Jane"}, ErrSignatureCaptureDisabled, "", ""},
+		{"typed", false, false, &dto.SignatureInput{Type: SignatureTypeTyped, TypedName: "Jane Doe", Style: signatureStyleFormal}, nil, SignatureTypeTyped, "image/svg+xml"},
+		{"typed blank name", false, false, &dto.SignatureInput{Type: SignatureTypeTyped, TypedName: "  "}, ErrSignatureRequired, "", ""},
+		{"drawn png", false, false, &dto.SignatureInput{Type: SignatureTypeDrawn, Image: testPNG(t, 300, 100)}, nil, SignatureTypeDrawn, "image/png"},
+		{"drawn png too wide", false, false, &dto.SignatureInput{Type: SignatureTypeDrawn, Image: testPNG(t, maxSignaturePixels+1, 10)}, ErrInvalidSignature, "", ""},
+		{"drawn plain svg", false, false, &dto.SignatureInput{Type: SignatureTypeDrawn, Image: plainSVG}, nil, SignatureTypeDrawn, "image/svg+xml"},
+		{"drawn svg with script", false, false, &dto.SignatureInput{Type: SignatureTypeDrawn, Image: `<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`}, ErrInvalidSignature, "", ""},
+		{"drawn other format", false, false, &dto.SignatureInput{Type: SignatureTypeDrawn, Image: base64.StdEncoding.EncodeToString([]byte("GIF89a......"))}, ErrInvalidSignature, "", ""},
+		{"unknown type", false, false, &dto.SignatureInput{Type: "stamp"}, ErrInvalidSignature, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			capture := SignatureCapture{Required: tt.required}
+			if !tt.noStore {
+				capture.Store = nopBlobStore{}
+			}
+			s := &ContractService{signatures: capture.withDefaults()}
+			sig, err := s.captureSignature(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantType == "" {
+				if sig != nil {
+					t.Fatalf("signature = %+v, want none", sig)
+				}
+				return
+			}
+			if sig == nil || sig.Type != tt.wantType || sig.ContentType != tt.wantContent || len(sig.Content) == 0 {
+				t.Fatalf("signature = %+v, want type %s (%s)", sig, tt.wantType, tt.wantContent)
+			}
+		})
+	}
+}
+
+func TestRenderTypedSignatureEscapesName(t *testing.T) {
+	out := string(renderTypedSignature(`<script>&"`, signatureStyleScript))
+	if strings.Contains(out, "<script>") {
+		t.Fatalf("name not escaped: %s", out)
+	}
+	if !strings.Contains(out, "&lt;script&gt;&amp;") {
+		t.Fatalf("escaped name missing: %s", out)
+	}
+}
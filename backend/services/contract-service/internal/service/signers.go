@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+)
+
+var (
+	ErrDuplicateSigner     = errors.New("each signer needs a distinct email, different from client_email")
+	ErrSignerAlreadySigned = errors.New("this signer has already signed")
+	ErrNotSignersTurn      = errors.New("signing is sequential and earlier parties have not signed yet")
+)
+
+const (
+	signerStatusPending = "pending"
+	signerStatusSigned  = "signed"
+)
+
+func signersFromInput(in []dto.SignerInput) []domain.ContractSigner {
+	out := make([]domain.ContractSigner, len(in))
+	for i := range in {
+		required := true
+		if in[i].Required != nil {
+			required = *in[i].Required
+		}
+		out[i] = domain.ContractSigner{
+			OrderIndex: i,
+			Name:       strings.TrimSpace(in[i].Name),
+			Email:      strings.TrimSpace(in[i].Email),
+			Role:       strings.TrimSpace(in[i].Role),
+			Required:   required,
+		}
+	}
+	return out
+}
+
+// validateSigners rejects repeated emails, including the primary client's.
+func validateSigners(clientEmail string, signers []domain.ContractSigner) error {
+	seen := map[string]bool{strings.ToLower(strings.TrimSpace(clientEmail)): true}
+	for _, sg := range signers {
+		e := strings.ToLower(sg.Email)
+		if seen[e] {
+			return ErrDuplicateSigner
+		}
+		seen[e] = true
+	}
+	return nil
+}
+
+// clientCanSign reports whether the primary client may sign now: the contract is open for signing and they have not signed.
+func clientCanSign(c *domain.Contract) error {
+	if c.ClientSignedAt != nil || c.Status == domain.ContractStatusSigned {
+		return ErrAlreadySigned
+	}
+	if c.Status != domain.ContractStatusSent && c.Status != domain.ContractStatusPartial {
+		return repository.ErrContractNotFound
+	}
+	return nil
+}
+
+// signerTurn is true when sg may sign under the contract's signing order. In sequential mode the primary client
+// signs first, then every earlier required signer must have signed.
+func signerTurn(c *domain.Contract, sg *domain.ContractSigner) bool {
+	if c.SigningOrder != domain.SigningOrderSequential {
+		return true
+	}
+	if c.ClientSignedAt == nil {
+		return false
+	}
+	for i := range c.Signers {
+		o := &c.Signers[i]
+		if o.OrderIndex < sg.OrderIndex && o.Required && o.SignedAt == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// issueSignerTokens gives every signer without a token a new one. Called when the contract is sent.
+func (s *ContractService) issueSignerTokens(ctx context.Context, c *domain.Contract) error {
+	for i := range c.Signers {
+		if c.Signers[i].Token != nil {
+			continue
+		}
+		token := uuid.New().String()
+		if err := s.signerRepo.SetToken(ctx, c.Signers[i].ID, token); err != nil {
+			return err
+		}
+		c.Signers[i].Token = &token
+	}
+	return nil
+}
+
+// notifyEligibleSigners emails the sign link to signers whose turn has come and who were not notified yet.
+// Parallel contracts notify everyone on send; sequential ones notify as earlier parties sign.
+func (s *ContractService) notifyEligibleSigners(ctx context.Context, c *domain.Contract) error {
+	if c.Status != domain.ContractStatusSent && c.Status != domain.ContractStatusPartial {
+		return nil
+	}
+	var ids []uint
+	for i := range c.Signers {
+		sg := &c.Signers[i]
+		if sg.Token == nil || sg.SignedAt != nil || sg.NotifiedAt != nil || !signerTurn(c, sg) {
+			continue
+		}
+		ids = append(ids, sg.ID)
+		go s.notifier.NotifyContractSent(context.Background(), c.ID, sg.Email, s.buildSignerLink(sg))
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.signerRepo.MarkNotified(ctx, ids, time.Now())
+}
+
+func (s *ContractService) buildSignerLink(sg *domain.ContractSigner) string {
+	return s.links.signerURL(sg.ContractID, *sg.Token)
+}
+
+// GetBySignerToken returns the contract as an additional signer sees it (no auth). Token from the signer's link.
+func (s *ContractService) GetBySignerToken(ctx context.Context, token string) (*dto.SignerViewResponse, error) {
+	sg, c, err := s.findSigner(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	out := signerView(c, sg)
+	out.SignRequiresCode = s.signOTP.Required
+	return out, nil
+}
+
+// RequestSignerSignCode emails a 6-digit code to the signer's own email, with the same TTL, attempts and cooldown
+// as the client's sign codes. Allowed once it is the signer's turn and until they sign.
+func (s *ContractService) RequestSignerSignCode(ctx context.Context, token string) (*dto.RequestSignCodeResponse, error) {
+	sg, c, err := s.findSigner(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := signerCanSign(c, sg); err != nil {
+		return nil, err
+	}
+	return s.issueSignCode(ctx, c.ID, sg.ID, sg.Email)
+}
+
+// SignAsSigner records an additional signer's signature with evidence (typed name, IP, user agent). req.OTPCode is
+// checked as for the client's sign: required with SignOTPSettings.Required, and a valid code marks the signer verified.
+// The contract becomes signed once the client and all required signers have signed; until then it is partially_signed.
+func (s *ContractService) SignAsSigner(ctx context.Context, token string, req *dto.SignerSignRequest, ip, userAgent string) (*dto.SignerViewResponse, error) {
+	sg, c, err := s.findSigner(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := signerCanSign(c, sg); err != nil {
+		return nil, err
+	}
+	var otpID uint
+	if s.signOTP.Required || req.OTPCode != "" {
+		otp, err := s.verifySignCode(ctx, c.ID, sg.ID, sg.Email, req.OTPCode)
+		if err != nil {
+			return nil, err
+		}
+		sg.Verified, otpID = true, otp.ID
+	}
+	now := time.Now()
+	sg.SignedAt = &now
+	sg.SignedName = strings.TrimSpace(req.FullName)
+	sg.Designation = strings.TrimSpace(req.Designation)
+	sg.SignerIP = ip
+	sg.SignerUserAgent = truncate(userAgent, 300)
+	if err := s.signerRepo.RecordSign(ctx, sg, otpID); err != nil {
+		if errors.Is(err, repository.ErrSignerNotFound) {
+			return nil, ErrSignerAlreadySigned
+		}
+		if errors.Is(err, repository.ErrSignOTPNotFound) {
+			return nil, ErrInvalidSignCode // used by a concurrent sign
+		}
+		return nil, err
+	}
+	sg, c, err = s.findSigner(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	go s.events.Record(context.Background(), c, domain.ContractEventSigned, map[string]interface{}{"signed_by": "signer", "signer_name": sg.Name, "status": c.Status})
+	if err := s.notifyEligibleSigners(ctx, c); err != nil {
+		return nil, err
+	}
+	out := signerView(c, sg)
+	out.SignRequiresCode = s.signOTP.Required
+	return out, nil
+}
+
+// signerCanSign reports whether sg may sign now: not signed yet, the contract open for signing and their turn.
+func signerCanSign(c *domain.Contract, sg *domain.ContractSigner) error {
+	if sg.SignedAt != nil {
+		return ErrSignerAlreadySigned
+	}
+	if c.Status != domain.ContractStatusSent && c.Status != domain.ContractStatusPartial {
+		return repository.ErrContractNotFound
+	}
+	if !signerTurn(c, sg) {
+		return ErrNotSignersTurn
+	}
+	return nil
+}
+
+// findSigner loads the signer by token and its contract; drafts are never visible to signers.
+func (s *ContractService) findSigner(ctx context.Context, token string) (*domain.ContractSigner, *domain.Contract, error) {
+	sg, err := s.signerRepo.FindByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrSignerNotFound) {
+			return nil, nil, repository.ErrContractNotFound
+		}
+		return nil, nil, err
+	}
+	c, err := s.repo.FindByID(ctx, sg.ContractID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.Status == domain.ContractStatusDraft {
+		return nil, nil, repository.ErrContractNotFound
+	}
+	for i := range c.Signers {
+		if c.Signers[i].ID == sg.ID {
+			return &c.Signers[i], c, nil
+		}
+	}
+	return sg, c, nil
+}
+
+func signerView(c *domain.Contract, sg *domain.ContractSigner) *dto.SignerViewResponse {
+	open := c.Status == domain.ContractStatusSent || c.Status == domain.ContractStatusPartial
+	return &dto.SignerViewResponse{
+		Signer:   signerStatus(sg),
+		CanSign:  open && sg.SignedAt == nil && signerTurn(c, sg),
+		Contract: toPublicViewResponse(c),
+	}
+}
+
+func signerStatus(sg *domain.ContractSigner) dto.SignerStatus {
+	status := signerStatusPending
+	if sg.SignedAt != nil {
+		status = signerStatusSigned
+	}
+	return dto.SignerStatus{Name: sg.Name, Role: sg.Role, Required: sg.Required, Status: status, SignedAt: sg.SignedAt}
+}
+
+// signerStatuses lists every party for the public view: the primary client first, then additional signers.
+func signerStatuses(c *domain.Contract) []dto.SignerStatus {
+	client := dto.SignerStatus{Name: c.ClientName, Role: "client", Required: true, Status: signerStatusPending, SignedAt: c.ClientSignedAt}
+	if c.ClientSignedAt != nil {
+		client.Status = signerStatusSigned
+	}
+	out := append(make([]dto.SignerStatus, 0, len(c.Signers)+1), client)
+	for i := range c.Signers {
+		out = append(out, signerStatus(&c.Signers[i]))
+	}
+	return out
+}
+
+func (s *ContractService) signersToResponse(signers []domain.ContractSigner) []dto.SignerResponse {
+	if len(signers) == 0 {
+		return nil
+	}
+	out := make([]dto.SignerResponse, len(signers))
+	for i := range signers {
+		sg := &signers[i]
+		out[i] = dto.SignerResponse{
+			ID:              sg.ID,
+			OrderIndex:      sg.OrderIndex,
+			Name:            sg.Name,
+			Email:           sg.Email,
+			Role:            sg.Role,
+			Required:        sg.Required,
+			Status:          signerStatus(sg).Status,
+			NotifiedAt:      sg.NotifiedAt,
+			SignedAt:        sg.SignedAt,
+			SignedName:      sg.SignedName,
+			Designation:     sg.Designation,
+			SignerIP:        sg.SignerIP,
+			SignerUserAgent: sg.SignerUserAgent,
+			Verified:        sg.Verified,
+		}
+		if sg.Token != nil {
+			out[i].SignLink = s.buildSignerLink(sg)
+		}
+	}
+	return out
+}
+
+// truncate keeps the first n characters of s (not bytes, so a multi-byte character is never split). Invalid UTF-8,
+// e.g. in a User-Agent header, is replaced so Postgres accepts the value.
+func truncate(s string, n int) string {
+	r := []rune(strings.ToValidUTF8(s, "\uFFFD"))
+	if len(r) <= n {
+		return string(r)
+	}
+	return string(r[:n])
+}
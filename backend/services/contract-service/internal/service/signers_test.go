@@ -0,0 +1,119 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+)
+
+func TestSignerTurn(t *testing.T) {
+	now := time.Now()
+	signed := &now
+	// lead (required), reviewer (optional), finance (required)
+	signers := func(leadSigned, reviewerSigned *time.Time) []domain.ContractSigner {
+		return []domain.ContractSigner{
+			{ID: 1, OrderIndex: 0, Required: true, SignedAt: leadSigned},
+			{ID: 2, OrderIndex: 1, Required: false, SignedAt: reviewerSigned},
+			{ID: 3, OrderIndex: 2, Required: true},
+		}
+	}
+	tests := []struct {
+		name         string
+		order        string
+		clientSigned *time.Time
+		signers      []domain.ContractSigner
+		signer       int // index into signers
+		want         bool
+	}{
+		{"parallel: anyone before the client", domain.SigningOrderParallel, nil, signers(nil, nil), 2, true},
+		{"empty order is parallel", "", nil, signers(nil, nil), 0, true},
+		{"sequential: client signs first", domain.SigningOrderSequential, nil, signers(nil, nil), 0, false},
+		{"sequential: first signer after the client", domain.SigningOrderSequential, signed, signers(nil, nil), 0, true},
+		{"sequential: waits for earlier required signer", domain.SigningOrderSequential, signed, signers(nil, nil), 2, false},
+		{"sequential: optional signer does not block", domain.SigningOrderSequential, signed, signers(signed, nil), 2, true},
+		{"sequential: optional signer waits for earlier required", domain.SigningOrderSequential, signed, signers(nil, nil), 1, false},
+		{"sequential: later signers do not block", domain.SigningOrderSequential, signed, signers(nil, nil), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &domain.Contract{SigningOrder: tt.order, ClientSignedAt: tt.clientSigned, Signers: tt.signers}
+			if got := signerTurn(c, &c.Signers[tt.signer]); got != tt.want {
+				t.Fatalf("signerTurn = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignerCanSign(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		status string
+		order  string
+		signer domain.ContractSigner
+		want   error
+	}{
+		{"open", domain.ContractStatusSent, domain.SigningOrderParallel, domain.ContractSigner{Required: true}, nil},
+		{"partially signed", domain.ContractStatusPartial, domain.SigningOrderParallel, domain.ContractSigner{Required: true}, nil},
+		{"already signed", domain.ContractStatusPartial, domain.SigningOrderParallel, domain.ContractSigner{SignedAt: &now}, ErrSignerAlreadySigned},
+		{"contract not open", domain.ContractStatusDraft, domain.SigningOrderParallel, domain.ContractSigner{}, repository.ErrContractNotFound},
+		{"not their turn", domain.ContractStatusSent, domain.SigningOrderSequential, domain.ContractSigner{}, ErrNotSignersTurn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &domain.Contract{Status: tt.status, SigningOrder: tt.order, Signers: []domain.ContractSigner{tt.signer}}
+			if err := signerCanSign(c, &c.Signers[0]); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSigners(t *testing.T) {
+	tests := []struct {
+		name    string
+		emails  []string
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"distinct", []string{"lead@example.com", "finance@example.com"}, false},
+		{"repeated", []string{"lead@example.com", "Lead@Example.com"}, true},
+		{"client's email", []string{"client@example.com"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var signers []domain.ContractSigner
+			for _, e := range tt.emails {
+				signers = append(signers, domain.ContractSigner{Email: e})
+			}
+			err := validateSigners(" Client@example.com ", signers)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		n    int
+		want string
+	}{
+		{"short", "curl/8.0", 300, "curl/8.0"},
+		{"ascii", "abcdef", 3, "abc"},
+		{"multi-byte kept whole", "héllo", 2, "hé"},
+		{"invalid utf-8 replaced", "a\xffb", 3, "a�b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncate(tt.in, tt.n); got != tt.want {
+				t.Fatalf("truncate(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/event"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+)
+
+var (
+	ErrTestimonialExists = errors.New("a testimonial was already left for this contract")
+)
+
+// TestimonialService records the client's rating and comment on a completed contract (invited by email on
+// completion) and publishes it to user-service as a verified testimonial. The rating also feeds the reputation score.
+type TestimonialService struct {
+	contracts    repository.ContractRepository
+	testimonials repository.TestimonialRepository
+	reputation   *ReputationService
+	publisher    event.Publisher
+}
+
+func NewTestimonialService(contracts repository.ContractRepository, testimonials repository.TestimonialRepository, reputation *ReputationService, publisher event.Publisher) *TestimonialService {
+	return &TestimonialService{
+		contracts:    contracts,
+		testimonials: testimonials,
+		reputation:   reputation,
+		publisher:    publisher,
+	}
+}
+
+// SubmitByClientToken stores the testimonial via the contract link. One per contract; it cannot be changed afterwards.
+func (s *TestimonialService) SubmitByClientToken(ctx context.Context, token string, req *dto.SubmitTestimonialRequest) (*dto.TestimonialResponse, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if c.Status != domain.ContractStatusDone {
+		return nil, ErrContractNotCompleted
+	}
+	if c.ClientRating != nil {
+		return nil, ErrTestimonialExists
+	}
+	now := time.Now()
+	comment := strings.TrimSpace(req.Comment)
+	if err := s.testimonials.Save(ctx, c.ID, req.Rating, comment, now); err != nil {
+		if errors.Is(err, repository.ErrContractNotFound) {
+			return nil, ErrTestimonialExists
+		}
+		return nil, err
+	}
+	c.ClientRating = &req.Rating
+	c.ClientTestimonial = comment
+	c.ClientRatedAt = &now
+	go s.reputation.scoreInBackground(c.ID)
+	go func() {
+		if err := s.publish(context.Background(), c); err != nil {
+			log.Printf("[testimonial] contract %d: %v (will retry)", c.ID, err)
+		}
+	}()
+	return &dto.TestimonialResponse{ContractID: c.ID, Rating: req.Rating, Comment: comment, SubmittedAt: now}, nil
+}
+
+// PublishPending re-sends testimonials that did not reach user-service. Run periodically
+// (see job.TestimonialPublishRunner); returns how many were delivered.
+func (s *TestimonialService) PublishPending(ctx context.Context) (int64, error) {
+	list, err := s.testimonials.ListUnpublished(ctx, time.Now().Add(-completionRetryWindow), 100)
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	for _, c := range list {
+		if err := s.publish(ctx, c); err != nil {
+			log.Printf("[testimonial] contract %d: %v", c.ID, err)
+			continue
+		}
+		n++
+	}
+	return n, nil
+}
+
+func (s *TestimonialService) publish(ctx context.Context, c *domain.Contract) error {
+	if c.ClientRating == nil || c.ClientRatedAt == nil {
+		return nil
+	}
+	err := s.publisher.PublishTestimonialSubmitted(ctx, event.TestimonialSubmitted{
+		ContractID:        c.ID,
+		FreelancerUserID:  c.FreelancerUserID,
+		ClientName:        c.ClientName,
+		ClientCompanyName: c.ClientCompanyName,
+		ClientEmail:       c.ClientEmail,
+		ProjectName:       c.ProjectName,
+		Rating:            *c.ClientRating,
+		Comment:           c.ClientTestimonial,
+		SubmittedAt:       *c.ClientRatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	return s.testimonials.MarkPublished(ctx, c.ID, time.Now())
+}
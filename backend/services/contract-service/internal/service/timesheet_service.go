@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/contract-service/internal/domain"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/notification"
+	"github.com/saiyam0211/defellix/services/contract-service/internal/repository"
+)
+
+var (
+	ErrNotHourly            = errors.New("timesheets are only kept on hourly contracts")
+	ErrTimesheetNotActive   = errors.New("time can only be logged or reviewed on a signed or active contract")
+	ErrFutureWorkDate       = errors.New("work_date cannot be in the future")
+	ErrTimesheetEntryClosed = errors.New("timesheet entry was already approved or rejected")
+)
+
+const workDateLayout = "2006-01-02"
+
+// TimesheetService handles time on hourly contracts: the freelancer logs entries (within the weekly cap) and the
+// client approves or rejects them via the contract link. Approved amounts are added to the contract total.
+// Like milestones, timesheets are frozen during a dispute.
+type TimesheetService struct {
+	contracts  repository.ContractRepository
+	timesheets repository.TimesheetRepository
+	disputes   repository.DisputeRepository
+	notifier   notification.ContractNotifier
+	links      ClientLinks
+	events     EventRecorder
+}
+
+func NewTimesheetService(contracts repository.ContractRepository, timesheets repository.TimesheetRepository, disputes repository.DisputeRepository, notifier notification.ContractNotifier, links ClientLinks, events EventRecorder) *TimesheetService {
+	return &TimesheetService{
+		contracts:  contracts,
+		timesheets: timesheets,
+		disputes:   disputes,
+		notifier:   notifier,
+		links:      links.withDefaults(),
+		events:     events,
+	}
+}
+
+// Log adds a pending entry at the contract's current hourly rate and asks the client to review it.
+func (s *TimesheetService) Log(ctx context.Context, contractID, freelancerUserID uint, req *dto.LogTimesheetRequest) (*dto.TimesheetEntryResponse, error) {
+	c, err := s.contracts.GetByID(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOpen(ctx, c); err != nil {
+		return nil, err
+	}
+	workDate, err := time.Parse(workDateLayout, req.WorkDate)
+	if err != nil {
+		return nil, err
+	}
+	if workDate.After(time.Now().UTC()) {
+		return nil, ErrFutureWorkDate
+	}
+	e := &domain.TimesheetEntry{
+		ContractID:  c.ID,
+		WorkDate:    workDate,
+		Hours:       req.Hours,
+		Description: strings.TrimSpace(req.Description),
+		Amount:      roundAmount(req.Hours * c.HourlyRate),
+		Status:      domain.TimesheetStatusPending,
+	}
+	if err := s.timesheets.Create(ctx, e, weekStart(workDate), c.WeeklyHourCap); err != nil {
+		return nil, err
+	}
+	go s.notifier.NotifyTimesheetLogged(context.Background(), c.ID, c.ClientEmail, e.Hours, s.links.URL(c))
+	out := timesheetEntryToResponse(e)
+	return &out, nil
+}
+
+// ListForFreelancer returns the contract's timesheet with totals.
+func (s *TimesheetService) ListForFreelancer(ctx context.Context, contractID, freelancerUserID uint) (*dto.TimesheetResponse, error) {
+	c, err := s.contracts.GetByID(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.list(ctx, c)
+}
+
+// Delete removes an entry the client has not reviewed yet.
+func (s *TimesheetService) Delete(ctx context.Context, contractID, freelancerUserID, entryID uint) error {
+	c, err := s.contracts.GetByID(ctx, contractID, freelancerUserID)
+	if err != nil {
+		return err
+	}
+	e, err := s.timesheets.GetByID(ctx, entryID, c.ID)
+	if err != nil {
+		return err
+	}
+	if e.Status != domain.TimesheetStatusPending {
+		return ErrTimesheetEntryClosed
+	}
+	if err := s.timesheets.Delete(ctx, e.ID, c.ID); err != nil {
+		if errors.Is(err, repository.ErrTimesheetEntryNotFound) {
+			return ErrTimesheetEntryClosed
+		}
+		return err
+	}
+	return nil
+}
+
+// ListByClientToken returns the timesheet for the client view (no auth).
+func (s *TimesheetService) ListByClientToken(ctx context.Context, token string) (*dto.TimesheetResponse, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.list(ctx, c)
+}
+
+// ApproveByClientToken approves a pending entry via the contract link; its amount is added to the contract total.
+func (s *TimesheetService) ApproveByClientToken(ctx context.Context, token string, entryID uint) (*dto.TimesheetEntryResponse, error) {
+	c, e, err := s.pendingByToken(ctx, token, entryID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	e.ReviewedAt = &now
+	if err := s.timesheets.Approve(ctx, e); err != nil {
+		if errors.Is(err, repository.ErrTimesheetEntryNotFound) {
+			return nil, ErrTimesheetEntryClosed
+		}
+		return nil, err
+	}
+	e.Status = domain.TimesheetStatusApproved
+	go s.events.Record(context.Background(), c, domain.ContractEventTimesheetReviewed, map[string]interface{}{"entry_id": e.ID, "status": e.Status, "hours": e.Hours, "amount": e.Amount})
+	out := timesheetEntryToResponse(e)
+	return &out, nil
+}
+
+// RejectByClientToken rejects a pending entry via the contract link with the client's reason; it is not billed.
+func (s *TimesheetService) RejectByClientToken(ctx context.Context, token string, entryID uint, req *dto.RejectTimesheetEntryRequest) (*dto.TimesheetEntryResponse, error) {
+	c, e, err := s.pendingByToken(ctx, token, entryID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	e.ReviewedAt = &now
+	e.RejectionNote = strings.TrimSpace(req.Comment)
+	if err := s.timesheets.Reject(ctx, e); err != nil {
+		if errors.Is(err, repository.ErrTimesheetEntryNotFound) {
+			return nil, ErrTimesheetEntryClosed
+		}
+		return nil, err
+	}
+	e.Status = domain.TimesheetStatusRejected
+	go s.events.Record(context.Background(), c, domain.ContractEventTimesheetReviewed, map[string]interface{}{"entry_id": e.ID, "status": e.Status, "hours": e.Hours, "comment": e.RejectionNote})
+	out := timesheetEntryToResponse(e)
+	return &out, nil
+}
+
+func (s *TimesheetService) pendingByToken(ctx context.Context, token string, entryID uint) (*domain.Contract, *domain.TimesheetEntry, error) {
+	c, err := s.contracts.FindByClientViewToken(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.checkOpen(ctx, c); err != nil {
+		return nil, nil, err
+	}
+	e, err := s.timesheets.GetByID(ctx, entryID, c.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if e.Status != domain.TimesheetStatusPending {
+		return nil, nil, ErrTimesheetEntryClosed
+	}
+	return c, e, nil
+}
+
+// checkOpen allows timesheet changes on signed or active hourly contracts without an open dispute.
+func (s *TimesheetService) checkOpen(ctx context.Context, c *domain.Contract) error {
+	if contractType(c) != domain.ContractTypeHourly {
+		return ErrNotHourly
+	}
+	if !statusIn(c.Status, postSignStatuses) {
+		return ErrTimesheetNotActive
+	}
+	return milestonesFrozen(ctx, s.disputes, c.ID)
+}
+
+func (s *TimesheetService) list(ctx context.Context, c *domain.Contract) (*dto.TimesheetResponse, error) {
+	if contractType(c) != domain.ContractTypeHourly {
+		return nil, ErrNotHourly
+	}
+	entries, err := s.timesheets.ListByContract(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	out := &dto.TimesheetResponse{
+		Summary: dto.TimesheetSummary{HourlyRate: c.HourlyRate, Currency: c.Currency, WeeklyHourCap: c.WeeklyHourCap},
+		Entries: make([]dto.TimesheetEntryResponse, len(entries)),
+	}
+	thisWeek := weekStart(time.Now().UTC())
+	for i := range entries {
+		e := &entries[i]
+		out.Entries[i] = timesheetEntryToResponse(e)
+		switch e.Status {
+		case domain.TimesheetStatusApproved:
+			out.Summary.ApprovedHours += e.Hours
+			out.Summary.ApprovedAmount += e.Amount
+		case domain.TimesheetStatusPending:
+			out.Summary.PendingHours += e.Hours
+			out.Summary.PendingAmount += e.Amount
+		}
+		if e.Status != domain.TimesheetStatusRejected && !e.WorkDate.Before(thisWeek) && e.WorkDate.Before(thisWeek.AddDate(0, 0, 7)) {
+			out.Summary.CurrentWeekHours += e.Hours
+		}
+	}
+	out.Summary.ApprovedAmount = roundAmount(out.Summary.ApprovedAmount)
+	out.Summary.PendingAmount = roundAmount(out.Summary.PendingAmount)
+	return out, nil
+}
+
+// weekStart returns the Monday (UTC midnight) of d's week; the weekly hour cap counts Monday to Sunday.
+func weekStart(d time.Time) time.Time {
+	d = time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+	return d.AddDate(0, 0, -((int(d.Weekday()) + 6) % 7))
+}
+
+func timesheetEntryToResponse(e *domain.TimesheetEntry) dto.TimesheetEntryResponse {
+	return dto.TimesheetEntryResponse{
+		ID:            e.ID,
+		WorkDate:      e.WorkDate.Format(workDateLayout),
+		Hours:         e.Hours,
+		Description:   e.Description,
+		Amount:        e.Amount,
+		Status:        e.Status,
+		ReviewedAt:    e.ReviewedAt,
+		RejectionNote: e.RejectionNote,
+		CreatedAt:     e.CreatedAt,
+	}
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"io"
+	"strings"
+)
+
+var (
+	ErrBlobNotFound = errors.New("blob not found")
+)
+
+// BlobStore stores immutable file content by content identifier (CID). Implementations must treat Put of an
+// existing CID as a no-op success, since the same bytes always map to the same key.
+type BlobStore interface {
+	Put(ctx context.Context, cid string, content []byte, contentType string) error
+	Get(ctx context.Context, cid string) (io.ReadCloser, error)
+	Exists(ctx context.Context, cid string) (bool, error)
+}
+
+// cidBase32 is the RFC 4648 lowercase alphabet without padding used by multibase "b".
+var cidBase32 = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// ComputeCID returns a CIDv1 (raw codec, sha2-256 multihash, base32 multibase) for content, i.e. the same
+// "bafkrei..." identifier IPFS assigns to a raw block, so files can be pinned to IPFS later without re-keying.
+func ComputeCID(content []byte) (cid string, digest [32]byte) {
+	digest = sha256.Sum256(content)
+	// version 1, raw (0x55), sha2-256 (0x12), 32-byte digest
+	b := append([]byte{0x01, 0x55, 0x12, 0x20}, digest[:]...)
+	return "b" + cidBase32.EncodeToString(b), digest
+}
+
+// ValidCID reports whether s looks like a CID produced by ComputeCID. Used to keep keys path-safe.
+func ValidCID(s string) bool {
+	if len(s) != 59 || s[0] != 'b' {
+		return false
+	}
+	return strings.Trim(s[1:], "abcdefghijklmnopqrstuvwxyz234567") == ""
+}
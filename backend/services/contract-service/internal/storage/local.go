@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// LocalBlobStore keeps blobs on the local filesystem under dir, sharded by CID prefix.
+type LocalBlobStore struct {
+	dir string
+}
+
+// NewLocalBlobStore creates dir if needed. Use for development or single-node deployments.
+func NewLocalBlobStore(dir string) (*LocalBlobStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create blob dir: %w", err)
+	}
+	return &LocalBlobStore{dir: dir}, nil
+}
+
+func (s *LocalBlobStore) path(cid string) (string, error) {
+	if !ValidCID(cid) {
+		return "", ErrBlobNotFound
+	}
+	return filepath.Join(s.dir, cid[len(cid)-2:], cid), nil
+}
+
+func (s *LocalBlobStore) Put(ctx context.Context, cid string, content []byte, contentType string) error {
+	p, err := s.path(cid)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(p); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return err
+	}
+	// write to a temp file then rename so readers never see a partial blob
+	tmp, err := os.CreateTemp(filepath.Dir(p), cid+".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), p)
+}
+
+func (s *LocalBlobStore) Get(ctx context.Context, cid string) (io.ReadCloser, error) {
+	p, err := s.path(cid)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrBlobNotFound
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *LocalBlobStore) Exists(ctx context.Context, cid string) (bool, error) {
+	p, err := s.path(cid)
+	if err != nil {
+		return false, nil
+	}
+	if _, err := os.Stat(p); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// emptyPayloadHash is hex(sha256("")), used to sign GET/HEAD requests.
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3Options configures an S3-compatible store (AWS S3, MinIO, Cloudflare R2, etc.). Path-style addressing is used.
+type S3Options struct {
+	Endpoint        string // e.g. https://s3.ap-south-1.amazonaws.com or http://localhost:9000
+	Region          string
+	Bucket          string
+	Prefix          string // optional key prefix, e.g. "attachments/"
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3BlobStore talks to an S3-compatible API with AWS Signature Version 4. Objects are keyed by CID.
+type S3BlobStore struct {
+	opts   S3Options
+	base   *url.URL
+	client *http.Client
+}
+
+func NewS3BlobStore(opts S3Options) (*S3BlobStore, error) {
+	if opts.Endpoint == "" || opts.Bucket == "" || opts.AccessKeyID == "" || opts.SecretAccessKey == "" {
+		return nil, errors.New("s3 blob store: endpoint, bucket and credentials are required")
+	}
+	base, err := url.Parse(strings.TrimSuffix(opts.Endpoint, "/"))
+	if err != nil || base.Host == "" {
+		return nil, fmt.Errorf("s3 blob store: invalid endpoint %q", opts.Endpoint)
+	}
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+	return &S3BlobStore{opts: opts, base: base, client: &http.Client{Timeout: 60 * time.Second}}, nil
+}
+
+func (s *S3BlobStore) Put(ctx context.Context, cid string, content []byte, contentType string) error {
+	if exists, err := s.Exists(ctx, cid); err != nil {
+		return err
+	} else if exists {
+		return nil
+	}
+	sum := sha256.Sum256(content)
+	req, err := s.newRequest(ctx, http.MethodPut, cid, bytes.NewReader(content), hex.EncodeToString(sum[:]))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = int64(len(content))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("s3 put: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *S3BlobStore) Get(ctx context.Context, cid string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, cid, nil, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrBlobNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("s3 get: unexpected status %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+func (s *S3BlobStore) Exists(ctx context.Context, cid string) (bool, error) {
+	req, err := s.newRequest(ctx, http.MethodHead, cid, nil, emptyPayloadHash)
+	if err != nil {
+		return false, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return false, nil
+	case resp.StatusCode/100 == 2:
+		return true, nil
+	default:
+		return false, fmt.Errorf("s3 head: unexpected status %d", resp.StatusCode)
+	}
+}
+
+// newRequest builds a path-style request for the object and signs it (SigV4, single chunk).
+func (s *S3BlobStore) newRequest(ctx context.Context, method, cid string, body io.Reader, payloadHash string) (*http.Request, error) {
+	if !ValidCID(cid) {
+		return nil, ErrBlobNotFound
+	}
+	u := *s.base
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.opts.Bucket + "/" + s.opts.Prefix + cid
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req, payloadHash, time.Now().UTC())
+	return req, nil
+}
+
+func (s *S3BlobStore) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		"", // no query string
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.opts.Region + "/s3/aws4_request"
+	crHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(crHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.opts.SecretAccessKey), date)
+	key = hmacSHA256(key, s.opts.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.opts.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
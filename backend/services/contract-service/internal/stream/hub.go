@@ -0,0 +1,139 @@
+package stream
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+const maxReconnectDelay = 30 * time.Second
+
+// Hub listens on a Postgres NOTIFY channel whose payload is a freelancer user ID and wakes that user's subscribers
+// on this instance. Any replica can write the event; every replica holding a stream for the user hears about it.
+// Subscribers are only woken: they read what is new from the database themselves, so a wake-up never carries data
+// and a missed one is recovered by the next.
+type Hub struct {
+	db      *sql.DB
+	channel string
+
+	mu   sync.Mutex
+	subs map[uint]map[chan struct{}]struct{}
+}
+
+// NewHub builds a hub over db, which must use the pgx driver (as gorm's postgres driver does). Run holds one
+// connection from db's pool while it listens.
+func NewHub(db *sql.DB, channel string) *Hub {
+	return &Hub{db: db, channel: channel, subs: make(map[uint]map[chan struct{}]struct{})}
+}
+
+// Subscribe returns a channel that receives a value whenever userID may have new events, and a func to unsubscribe.
+func (h *Hub) Subscribe(userID uint) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	h.mu.Lock()
+	if h.subs[userID] == nil {
+		h.subs[userID] = make(map[chan struct{}]struct{})
+	}
+	h.subs[userID][ch] = struct{}{}
+	h.mu.Unlock()
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.subs[userID], ch)
+		if len(h.subs[userID]) == 0 {
+			delete(h.subs, userID)
+		}
+		h.mu.Unlock()
+	}
+}
+
+// Run blocks and listens until ctx is cancelled, reconnecting with backoff when the connection drops. Call in a goroutine.
+func (h *Hub) Run(ctx context.Context) {
+	delay := time.Second
+	for {
+		err := h.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("[event-stream] listen on %q: %v; reconnecting in %s", h.channel, err, delay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+func (h *Hub) listen(ctx context.Context) error {
+	conn, err := h.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	var listenErr error
+	// the connection is always discarded afterwards rather than returned to the pool still listening
+	_ = conn.Raw(func(dc interface{}) error {
+		sc, ok := dc.(*stdlib.Conn)
+		if !ok {
+			listenErr = errors.New("event stream needs the pgx database driver")
+			return driver.ErrBadConn
+		}
+		listenErr = h.wait(ctx, sc.Conn())
+		return driver.ErrBadConn
+	})
+	return listenErr
+}
+
+func (h *Hub) wait(ctx context.Context, c *pgx.Conn) error {
+	if _, err := c.Exec(ctx, "LISTEN "+pgx.Identifier{h.channel}.Sanitize()); err != nil {
+		return err
+	}
+	// notifications sent while not listening are lost; let every subscriber catch up from the database
+	h.wakeAll()
+	for {
+		n, err := c.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		userID, err := strconv.ParseUint(n.Payload, 10, 64)
+		if err != nil {
+			continue
+		}
+		h.wake(uint(userID))
+	}
+}
+
+func (h *Hub) wake(userID uint) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[userID] {
+		notify(ch)
+	}
+}
+
+func (h *Hub) wakeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, subs := range h.subs {
+		for ch := range subs {
+			notify(ch)
+		}
+	}
+}
+
+// notify does not block: a subscriber with a wake-up already pending reads everything new in one go.
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,119 @@
+// Package gstin validates Indian GST identification numbers (GSTIN).
+//
+// A GSTIN has 15 characters:
+//
+//	2  state code (01–38, 97 other territory, 99 centre jurisdiction)
+//	10 PAN of the holder (AAAAA9999A; 4th letter is the holder type)
+//	1  entity number for the same PAN in the state (1–9, A–Z)
+//	1  "Z" by default
+//	1  mod-36 check character
+package gstin
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrLength    = errors.New("GSTIN must be 15 characters")
+	ErrFormat    = errors.New("GSTIN format is invalid")
+	ErrStateCode = errors.New("GSTIN state code is invalid")
+	ErrPAN       = errors.New("GSTIN does not contain a valid PAN")
+	ErrChecksum  = errors.New("GSTIN check character does not match")
+)
+
+const charset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// panHolderTypes are valid 4th characters of a PAN (P individual, C company, H HUF, F firm, A AOP,
+// T trust, B BOI, L local authority, J artificial juridical person, G government).
+const panHolderTypes = "PCHFATBLJG"
+
+// GSTIN is a parsed, validated GST number
+type GSTIN struct {
+	Number    string // normalised (upper case, trimmed)
+	StateCode string
+	PAN       string
+}
+
+// Normalise trims spaces and upper-cases s.
+func Normalise(s string) string {
+	return strings.ToUpper(strings.TrimSpace(s))
+}
+
+// Parse validates s (after Normalise) and returns its parts. The error says which check failed.
+func Parse(s string) (*GSTIN, error) {
+	s = Normalise(s)
+	if len(s) != 15 {
+		return nil, ErrLength
+	}
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(charset, s[i]) < 0 {
+			return nil, ErrFormat
+		}
+	}
+	if !isDigits(s[0:2]) {
+		return nil, ErrFormat
+	}
+	if !validStateCode(s[0:2]) {
+		return nil, ErrStateCode
+	}
+	if !validPAN(s[2:12]) {
+		return nil, ErrPAN
+	}
+	if s[12] == '0' || s[13] != 'Z' {
+		return nil, ErrFormat
+	}
+	if CheckChar(s[:14]) != s[14] {
+		return nil, ErrChecksum
+	}
+	return &GSTIN{Number: s, StateCode: s[0:2], PAN: s[2:12]}, nil
+}
+
+// Valid reports whether s is a valid GSTIN.
+func Valid(s string) bool {
+	_, err := Parse(s)
+	return err == nil
+}
+
+// CheckChar computes the mod-36 check character for the first 14 characters of a GSTIN.
+// Each character's value is multiplied by 1 or 2 alternately; quotient and remainder by 36 are summed.
+func CheckChar(first14 string) byte {
+	sum := 0
+	for i := 0; i < len(first14); i++ {
+		factor := 1
+		if i%2 == 1 {
+			factor = 2
+		}
+		product := strings.IndexByte(charset, first14[i]) * factor
+		sum += product/36 + product%36
+	}
+	return charset[(36-sum%36)%36]
+}
+
+func validStateCode(code string) bool {
+	n := int(code[0]-'0')*10 + int(code[1]-'0')
+	return (n >= 1 && n <= 38) || n == 97 || n == 99
+}
+
+func validPAN(pan string) bool {
+	return isLetters(pan[0:5]) && isDigits(pan[5:9]) && isLetters(pan[9:10]) &&
+		strings.IndexByte(panHolderTypes, pan[3]) >= 0
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isLetters(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'A' || s[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -11,6 +11,7 @@ type Config struct {
 	App      AppConfig
 	Database DatabaseConfig
 	Auth     AuthConfig
+	Internal InternalConfig
 }
 
 // ServerConfig holds server-related configuration
@@ -24,8 +25,9 @@ type ServerConfig struct {
 
 // AppConfig holds application-level configuration
 type AppConfig struct {
-	Environment string
-	LogLevel    string
+	Environment    string
+	LogLevel       string
+	RequireIfMatch bool // PUT /users/me without If-Match → 428; false keeps unconditional writes for older clients
 }
 
 // DatabaseConfig holds PostgreSQL configuration
@@ -44,6 +46,12 @@ type AuthConfig struct {
 	Port string
 }
 
+// InternalConfig holds settings for service-to-service endpoints (/internal/v1/*)
+type InternalConfig struct {
+	APIToken           string // shared secret expected in X-Internal-Token; empty disables internal endpoints
+	ContractServiceURL string // e.g. http://localhost:8082; empty = no contracts on public profiles
+}
+
 // Load reads configuration from environment variables with defaults
 func Load() *Config {
 	return &Config{
@@ -55,8 +63,9 @@ func Load() *Config {
 			IdleTimeout:  getEnvAsInt("SERVER_IDLE_TIMEOUT", 60),
 		},
 		App: AppConfig{
-			Environment: getEnv("APP_ENV", "development"),
-			LogLevel:    getEnv("LOG_LEVEL", "info"),
+			Environment:    getEnv("APP_ENV", "development"),
+			LogLevel:       getEnv("LOG_LEVEL", "info"),
+			RequireIfMatch: getEnvAsBool("REQUIRE_IF_MATCH", false),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -70,6 +79,10 @@ func Load() *Config {
 			Host: getEnv("AUTH_SERVICE_HOST", "localhost"),
 			Port: getEnv("AUTH_SERVICE_PORT", "50051"),
 		},
+		Internal: InternalConfig{
+			APIToken:           getEnv("INTERNAL_API_TOKEN", ""),
+			ContractServiceURL: getEnv("CONTRACT_SERVICE_URL", ""),
+		},
 	}
 }
 
@@ -81,6 +94,16 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvAsBool retrieves an environment variable as bool or returns a default value
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
 // getEnvAsInt retrieves an environment variable as integer or returns a default value
 func getEnvAsInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
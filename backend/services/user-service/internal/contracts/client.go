@@ -0,0 +1,73 @@
+// Package contracts reads data from contract-service for profiles.
+package contracts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/saiyam0211/defellix/services/user-service/internal/dto"
+)
+
+// InternalTokenHeader carries the shared secret on service-to-service calls
+const InternalTokenHeader = "X-Internal-Token"
+
+// SummaryClient returns the redacted completed-contract summaries shown on a freelancer's public profile.
+type SummaryClient interface {
+	ListSummaries(ctx context.Context, freelancerUserID uint) ([]dto.ContractSummary, error)
+}
+
+// NoopClient returns no contracts. Use when contract-service is not configured.
+type NoopClient struct{}
+
+func (NoopClient) ListSummaries(context.Context, uint) ([]dto.ContractSummary, error) {
+	return nil, nil
+}
+
+// HTTPClient calls contract-service's internal API.
+type HTTPClient struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewHTTPClient builds a client for baseURL (e.g. http://contract-service:8082) authenticated with token.
+// The timeout is short because public profile requests wait on it.
+func NewHTTPClient(baseURL, token string) *HTTPClient {
+	return &HTTPClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: 3 * time.Second},
+	}
+}
+
+func (c *HTTPClient) ListSummaries(ctx context.Context, freelancerUserID uint) ([]dto.ContractSummary, error) {
+	path := fmt.Sprintf("/internal/v1/freelancers/%d/contract-summaries", freelancerUserID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(InternalTokenHeader, c.token)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%s: %s: %s", path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	var body struct {
+		Data struct {
+			Contracts []dto.ContractSummary `json:"contracts"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return nil, err
+	}
+	return body.Data.Contracts, nil
+}
@@ -66,6 +66,7 @@ type UserProfile struct {
 	IsActive          bool           `gorm:"default:true" json:"is_active"`
 	IsVerified        bool           `gorm:"default:false" json:"is_verified"`         // Email/Phone verification
 	IsProfileComplete bool           `gorm:"default:false" json:"is_profile_complete"` // All required fields filled
+	Version           int            `gorm:"not null;default:1" json:"version"`         // Bumped by every write; the ETag, checked against If-Match
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
 	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
@@ -93,6 +94,12 @@ type Project struct {
 	Technologies  []string `json:"technologies,omitempty"`
 	ClientName    string   `json:"client_name,omitempty"`
 	CompletedDate string   `json:"completed_date,omitempty"` // ISO 8601 string
+
+	// Verified projects come from a completed contract in contract-service (one per contract)
+	ContractID *uint `json:"contract_id,omitempty"`
+	IsVerified bool  `json:"is_verified,omitempty"`
+	OnTime     *bool `json:"on_time,omitempty"` // delivered by the contract/milestone due dates
+
 	CreatedAt     string   `json:"created_at"`               // ISO 8601 string
 	UpdatedAt     string   `json:"updated_at"`               // ISO 8601 string
 }
@@ -113,6 +120,10 @@ type Testimonial struct {
 	ProjectName string `json:"project_name,omitempty"`
 	IsVerified  bool   `json:"is_verified"` // From verified client
 	CreatedAt   string `json:"created_at"`  // ISO 8601 string
+
+	// Verified testimonials are left by the client through the contract link (one per contract)
+	ContractID *uint `json:"contract_id,omitempty"`
+	IsHidden   bool  `json:"is_hidden,omitempty"` // hidden from the public profile by the freelancer
 }
 
 // PortfolioItem represents a portfolio entry (legacy - stored as JSONB)
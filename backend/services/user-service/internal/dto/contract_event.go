@@ -0,0 +1,66 @@
+package dto
+
+import "time"
+
+// ContractCompletedEvent is posted by contract-service to /internal/v1/events/contract-completed
+// when every milestone of a contract is approved. Delivery can repeat; handling is idempotent per contract_id.
+type ContractCompletedEvent struct {
+	ContractID        uint       `json:"contract_id" validate:"required"`
+	FreelancerUserID  uint       `json:"freelancer_user_id" validate:"required"`
+	ProjectCategory   string     `json:"project_category"`
+	ProjectName       string     `json:"project_name" validate:"required"`
+	Description       string     `json:"description,omitempty"`
+	ClientName        string     `json:"client_name"`
+	ClientCompanyName string     `json:"client_company_name,omitempty"`
+	ContractType      string     `json:"contract_type,omitempty"` // fixed | hourly | retainer; hourly and retainer totals are what was billed
+	TotalAmount       float64    `json:"total_amount"`
+	Currency          string     `json:"currency"`
+	StartedAt         *time.Time `json:"started_at,omitempty"`
+	DueDate           *time.Time `json:"due_date,omitempty"`
+	CompletedAt       time.Time  `json:"completed_at" validate:"required"`
+	OnTime            bool       `json:"on_time"`
+}
+
+// ContractSummary is a completed contract as contract-service exposes it for public profiles, already redacted
+// for the contract's visibility: anonymized ones carry no client or amount; full ones a value band, and the client
+// and exact amount only with the client's consent. Hidden contracts are not listed.
+type ContractSummary struct {
+	ContractID        uint      `json:"contract_id"`
+	Visibility        string    `json:"visibility"` // anonymized | full
+	ProjectCategory   string    `json:"project_category"`
+	ContractType      string    `json:"contract_type,omitempty"` // fixed | hourly | retainer
+	ValueBand         string    `json:"value_band,omitempty"`
+	Amount            *float64  `json:"amount,omitempty"`
+	Currency          string    `json:"currency,omitempty"`
+	DurationDays      int       `json:"duration_days"`
+	CompletedAt       time.Time `json:"completed_at"`
+	OnTime            bool      `json:"on_time"`
+	ClientName        string    `json:"client_name,omitempty"`
+	ClientCompanyName string    `json:"client_company_name,omitempty"`
+	Rating            *int      `json:"rating,omitempty"`
+	Testimonial       string    `json:"testimonial,omitempty"`
+}
+
+// ReputationUpdatedEvent is posted by contract-service to /internal/v1/events/reputation-updated whenever the
+// freelancer's aggregate reputation changes. Events can arrive out of order; older updated_at values are ignored.
+type ReputationUpdatedEvent struct {
+	FreelancerUserID uint      `json:"freelancer_user_id" validate:"required"`
+	Score            float64   `json:"score" validate:"min=0,max=100"`
+	ContractsScored  int       `json:"contracts_scored" validate:"min=0"`
+	FormulaVersion   int       `json:"formula_version" validate:"required"`
+	UpdatedAt        time.Time `json:"updated_at" validate:"required"`
+}
+
+// TestimonialSubmittedEvent is posted by contract-service to /internal/v1/events/testimonial-submitted when the
+// client rates a completed contract. Delivery can repeat; handling is idempotent per contract_id.
+type TestimonialSubmittedEvent struct {
+	ContractID        uint      `json:"contract_id" validate:"required"`
+	FreelancerUserID  uint      `json:"freelancer_user_id" validate:"required"`
+	ClientName        string    `json:"client_name"`
+	ClientCompanyName string    `json:"client_company_name,omitempty"`
+	ClientEmail       string    `json:"client_email"`
+	ProjectName       string    `json:"project_name"`
+	Rating            int       `json:"rating" validate:"required,min=1,max=10"`
+	Comment           string    `json:"comment" validate:"max=2000"`
+	SubmittedAt       time.Time `json:"submitted_at" validate:"required"`
+}
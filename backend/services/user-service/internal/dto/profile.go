@@ -76,6 +76,11 @@ type ProjectLink struct {
 	URL   string `json:"url" validate:"required,url"`
 }
 
+// SetTestimonialVisibilityRequest hides or shows a testimonial on the public profile. Testimonials cannot be edited.
+type SetTestimonialVisibilityRequest struct {
+	Hidden *bool `json:"hidden" validate:"required"`
+}
+
 // UpdateProjectRequest represents the request to update a project
 type UpdateProjectRequest struct {
 	ProjectName  string        `json:"project_name,omitempty" validate:"omitempty,min=2,max=100"`
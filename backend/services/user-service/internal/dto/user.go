@@ -95,6 +95,7 @@ type UserProfileResponse struct {
 	IsActive          bool   `json:"is_active"`
 	IsVerified        bool   `json:"is_verified"`
 	IsProfileComplete bool   `json:"is_profile_complete"`
+	Version           int    `json:"version"` // also the ETag; send it as If-Match on PUT /me
 	CreatedAt         string `json:"created_at"`
 	UpdatedAt         string `json:"updated_at"`
 }
@@ -118,7 +119,8 @@ type PublicProfileResponse struct {
 	HourlyRate    *float64             `json:"hourly_rate,omitempty"`
 	Availability  string               `json:"availability,omitempty"`
 	Projects      []ProjectResponse    `json:"projects,omitempty"` // only if show_projects
-	// Contracts     []ContractSummary   `json:"contracts,omitempty"` // when integrated
+	Testimonials  []TestimonialResponse `json:"testimonials,omitempty"` // only if show_profile; hidden ones are left out
+	Contracts     []ContractSummary    `json:"contracts,omitempty"` // only if show_contracts; from contract-service
 }
 
 // ProjectResponse represents a project in API response
@@ -135,6 +137,9 @@ type ProjectResponse struct {
 	Technologies  []string        `json:"technologies,omitempty"`
 	ClientName    string          `json:"client_name,omitempty"`
 	CompletedDate string          `json:"completed_date,omitempty"`
+	ContractID    *uint           `json:"contract_id,omitempty"` // set on verified projects from a completed contract
+	IsVerified    bool            `json:"is_verified"`
+	OnTime        *bool           `json:"on_time,omitempty"`
 	CreatedAt     string          `json:"created_at"`
 	UpdatedAt     string          `json:"updated_at"`
 }
@@ -148,6 +153,8 @@ type TestimonialResponse struct {
 	ProjectName string `json:"project_name,omitempty"`
 	IsVerified  bool   `json:"is_verified"`
 	CreatedAt   string `json:"created_at"`
+	ContractID  *uint  `json:"contract_id,omitempty"`
+	IsHidden    bool   `json:"is_hidden,omitempty"`
 }
 
 // PortfolioItem represents a portfolio entry in response
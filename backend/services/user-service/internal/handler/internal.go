@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/saiyam0211/defellix/services/user-service/internal/dto"
+	"github.com/saiyam0211/defellix/services/user-service/internal/middleware"
+	"github.com/saiyam0211/defellix/services/user-service/internal/repository"
+	"github.com/saiyam0211/defellix/services/user-service/internal/service"
+)
+
+// InternalHandler receives events from other services (contract-service). Not exposed to end users.
+type InternalHandler struct {
+	validator      *middleware.Validator
+	profileService *service.ProfileService
+	apiToken       string
+}
+
+// NewInternalHandler creates the internal events handler; apiToken is the shared X-Internal-Token secret
+func NewInternalHandler(profileService *service.ProfileService, apiToken string) *InternalHandler {
+	return &InternalHandler{
+		validator:      middleware.NewValidator(),
+		profileService: profileService,
+		apiToken:       apiToken,
+	}
+}
+
+// RegisterRoutes registers internal routes under /internal/v1
+func (h *InternalHandler) RegisterRoutes(r chi.Router) {
+	r.With(middleware.RequireInternalToken(h.apiToken)).Route("/internal/v1", func(r chi.Router) {
+		r.Post("/events/contract-completed", h.ContractCompleted)
+		r.Post("/events/reputation-updated", h.ReputationUpdated)
+		r.Post("/events/testimonial-submitted", h.TestimonialSubmitted)
+	})
+}
+
+// ContractCompleted adds a verified project for a completed contract. Repeated events are acknowledged with 200.
+func (h *InternalHandler) ContractCompleted(w http.ResponseWriter, r *http.Request) {
+	var evt dto.ContractCompletedEvent
+	if err := h.validator.ValidateJSON(r, &evt); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+
+	project, created, err := h.profileService.RecordContractCompletion(r.Context(), &evt)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			// contract-service retries until the freelancer has a profile
+			respondError(w, http.StatusNotFound, "Profile not found", "PROFILE_NOT_FOUND")
+			return
+		}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			// still conflicting after re-reading; the caller can retry
+			respondError(w, http.StatusConflict, err.Error(), "VERSION_CONFLICT")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to record completed contract", "INTERNAL_ERROR")
+		return
+	}
+
+	if !created {
+		respondSuccess(w, http.StatusOK, project, "Already recorded")
+		return
+	}
+	respondSuccess(w, http.StatusCreated, project, "Verified project added")
+}
+
+// ReputationUpdated stores the freelancer's aggregate reputation score. Stale (older) events are acknowledged with 200.
+func (h *InternalHandler) ReputationUpdated(w http.ResponseWriter, r *http.Request) {
+	var evt dto.ReputationUpdatedEvent
+	if err := h.validator.ValidateJSON(r, &evt); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+
+	applied, err := h.profileService.RecordReputation(r.Context(), &evt)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, "Profile not found", "PROFILE_NOT_FOUND")
+			return
+		}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			// still conflicting after re-reading; the caller can retry
+			respondError(w, http.StatusConflict, err.Error(), "VERSION_CONFLICT")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to record reputation", "INTERNAL_ERROR")
+		return
+	}
+
+	if !applied {
+		respondSuccess(w, http.StatusOK, nil, "Newer reputation already recorded")
+		return
+	}
+	respondSuccess(w, http.StatusOK, nil, "Reputation updated")
+}
+
+// TestimonialSubmitted adds the client's verified testimonial for a contract. Repeated events are acknowledged with 200.
+func (h *InternalHandler) TestimonialSubmitted(w http.ResponseWriter, r *http.Request) {
+	var evt dto.TestimonialSubmittedEvent
+	if err := h.validator.ValidateJSON(r, &evt); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+
+	testimonial, created, err := h.profileService.RecordTestimonial(r.Context(), &evt)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			respondError(w, http.StatusNotFound, "Profile not found", "PROFILE_NOT_FOUND")
+			return
+		}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			// still conflicting after re-reading; the caller can retry
+			respondError(w, http.StatusConflict, err.Error(), "VERSION_CONFLICT")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to record testimonial", "INTERNAL_ERROR")
+		return
+	}
+
+	if !created {
+		respondSuccess(w, http.StatusOK, testimonial, "Already recorded")
+		return
+	}
+	respondSuccess(w, http.StatusCreated, testimonial, "Verified testimonial added")
+}
@@ -12,6 +12,14 @@ type ErrorResponse struct {
 	Code    string `json:"code,omitempty"`
 }
 
+// PreconditionFailedResponse represents a 412 error carrying the current representation
+type PreconditionFailedResponse struct {
+	Error   string      `json:"error"`
+	Message string      `json:"message,omitempty"`
+	Code    string      `json:"code,omitempty"`
+	Data    interface{} `json:"data"`
+}
+
 // SuccessResponse represents a success response with data
 type SuccessResponse struct {
 	Data    interface{} `json:"data"`
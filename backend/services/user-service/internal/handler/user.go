@@ -17,14 +17,16 @@ type UserHandler struct {
 	validator     *middleware.Validator
 	userService   *service.UserService
 	profileService *service.ProfileService
+	requireIfMatch bool
 }
 
-// NewUserHandler creates a new user handler
-func NewUserHandler(userService *service.UserService, profileService *service.ProfileService) *UserHandler {
+// NewUserHandler creates a new user handler. With requireIfMatch, PUT /me without If-Match is refused with 428.
+func NewUserHandler(userService *service.UserService, profileService *service.ProfileService, requireIfMatch bool) *UserHandler {
 	return &UserHandler{
 		validator:      middleware.NewValidator(),
 		userService:   userService,
 		profileService: profileService,
+		requireIfMatch: requireIfMatch,
 	}
 }
 
@@ -50,6 +52,7 @@ func (h *UserHandler) RegisterRoutes(r chi.Router) {
 			r.Post("/me/projects", h.AddProject)
 			r.Put("/me/projects/{projectId}", h.UpdateProject)
 			r.Delete("/me/projects/{projectId}", h.DeleteProject)
+			r.Put("/me/testimonials/{testimonialId}/visibility", h.SetTestimonialVisibility)
 			r.Post("/me/portfolio", h.AddPortfolioItem)
 			r.Put("/me/portfolio/{itemId}", h.UpdatePortfolioItem)
 			r.Delete("/me/portfolio/{itemId}", h.DeletePortfolioItem)
@@ -92,6 +95,7 @@ func (h *UserHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", versionETag(profile.Version))
 	respondSuccess(w, http.StatusOK, profile, "Profile retrieved successfully")
 }
 
@@ -109,6 +113,7 @@ func (h *UserHandler) GetMyProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", versionETag(profile.Version))
 	respondSuccess(w, http.StatusOK, profile, "Profile retrieved successfully")
 }
 
@@ -116,14 +121,34 @@ func (h *UserHandler) GetMyProfile(w http.ResponseWriter, r *http.Request) {
 func (h *UserHandler) UpdateMyProfile(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("user_id").(uint)
 
+	expectedVersion, err := ifMatchVersion(r, h.requireIfMatch)
+	if err != nil {
+		respondIfMatchError(w, err)
+		return
+	}
+
 	var req dto.UpdateProfileRequest
 	if err := h.validator.ValidateJSON(r, &req); err != nil {
 		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
 		return
 	}
 
-	profile, err := h.userService.UpdateProfile(r.Context(), userID, &req)
+	profile, err := h.userService.UpdateProfile(r.Context(), userID, expectedVersion, &req)
 	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			current, getErr := h.userService.GetProfileByUserID(r.Context(), userID)
+			if errors.Is(getErr, repository.ErrUserNotFound) {
+				// no profile yet: nothing can match
+				respondPreconditionFailed(w, "", nil)
+				return
+			}
+			if getErr != nil {
+				respondError(w, http.StatusInternalServerError, "Failed to retrieve profile", "INTERNAL_ERROR")
+				return
+			}
+			respondPreconditionFailed(w, versionETag(current.Version), current)
+			return
+		}
 		if errors.Is(err, repository.ErrUserNameTaken) {
 			respondError(w, http.StatusConflict, "user_name already taken", "USER_NAME_TAKEN")
 			return
@@ -136,6 +161,7 @@ func (h *UserHandler) UpdateMyProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", versionETag(profile.Version))
 	respondSuccess(w, http.StatusOK, profile, "Profile updated successfully")
 }
 
@@ -231,6 +257,10 @@ func (h *UserHandler) AddPortfolioItem(w http.ResponseWriter, r *http.Request) {
 
 	item, err := h.userService.AddPortfolioItem(r.Context(), userID, &req)
 	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			respondError(w, http.StatusConflict, err.Error(), "VERSION_CONFLICT")
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to add portfolio item", "INTERNAL_ERROR")
 		return
 	}
@@ -323,6 +353,10 @@ func (h *UserHandler) AddProject(w http.ResponseWriter, r *http.Request) {
 
 	project, err := h.profileService.AddProject(r.Context(), userID, &req)
 	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			respondError(w, http.StatusConflict, err.Error(), "VERSION_CONFLICT")
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to add project", "INTERNAL_ERROR")
 		return
 	}
@@ -347,6 +381,10 @@ func (h *UserHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
 			respondError(w, http.StatusNotFound, "Project not found", "PROJECT_NOT_FOUND")
 			return
 		}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			respondError(w, http.StatusConflict, err.Error(), "VERSION_CONFLICT")
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to update project", "INTERNAL_ERROR")
 		return
 	}
@@ -364,6 +402,14 @@ func (h *UserHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
 			respondError(w, http.StatusNotFound, "Project not found", "PROJECT_NOT_FOUND")
 			return
 		}
+		if errors.Is(err, service.ErrVerifiedProject) {
+			respondError(w, http.StatusConflict, err.Error(), "VERIFIED_PROJECT")
+			return
+		}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			respondError(w, http.StatusConflict, err.Error(), "VERSION_CONFLICT")
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to delete project", "INTERNAL_ERROR")
 		return
 	}
@@ -371,3 +417,32 @@ func (h *UserHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
 	respondSuccess(w, http.StatusOK, map[string]string{"message": "Project deleted successfully"}, "Project deleted")
 }
 
+
+// SetTestimonialVisibility hides or shows a testimonial on the public profile. Body: { "hidden": true }.
+// Testimonials come from clients and cannot be edited.
+func (h *UserHandler) SetTestimonialVisibility(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(uint)
+	testimonialID := chi.URLParam(r, "testimonialId")
+
+	var req dto.SetTestimonialVisibilityRequest
+	if err := h.validator.ValidateJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), "VALIDATION_ERROR")
+		return
+	}
+
+	testimonial, err := h.profileService.SetTestimonialHidden(r.Context(), userID, testimonialID, *req.Hidden)
+	if err != nil {
+		if errors.Is(err, service.ErrTestimonialNotFound) {
+			respondError(w, http.StatusNotFound, "Testimonial not found", "TESTIMONIAL_NOT_FOUND")
+			return
+		}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			respondError(w, http.StatusConflict, err.Error(), "VERSION_CONFLICT")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to update testimonial", "INTERNAL_ERROR")
+		return
+	}
+
+	respondSuccess(w, http.StatusOK, testimonial, "Testimonial updated")
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// InternalTokenHeader carries the shared secret on service-to-service calls
+const InternalTokenHeader = "X-Internal-Token"
+
+// RequireInternalToken only lets through requests carrying the shared internal API token.
+// With an empty token every request is rejected, so internal endpoints stay closed until configured.
+func RequireInternalToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got := r.Header.Get(InternalTokenHeader)
+			if token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				respondError(w, http.StatusUnauthorized, "Invalid internal token", "UNAUTHORIZED")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -54,7 +55,7 @@ func (v *Validator) formatValidationError(err error) error {
 		return err
 	}
 
-	return fmt.Errorf(strings.Join(messages, "; "))
+	return errors.New(strings.Join(messages, "; "))
 }
 
 // getValidationMessage returns a human-readable validation message
@@ -18,8 +18,13 @@ var (
 	ErrUserExists = errors.New("user already exists")
 	// ErrUserNameTaken indicates user_name is already in use by another profile
 	ErrUserNameTaken = errors.New("user_name already taken")
+	// ErrVersionConflict indicates the profile was changed since it was read
+	ErrVersionConflict = errors.New("profile was changed by another request")
 )
 
+// nextVersion bumps user_profiles.version; every write to a profile sets it so the ETag changes
+var nextVersion = gorm.Expr("version + 1")
+
 // UserRepository defines the interface for user profile data access
 type UserRepository interface {
 	Create(ctx context.Context, profile *domain.UserProfile) error
@@ -98,14 +103,29 @@ func (r *userRepository) FindByUserName(ctx context.Context, userName string) (*
 	return &profile, nil
 }
 
-// Update updates an existing user profile
+// Update updates an existing user profile. It only succeeds if the stored version is still profile.Version
+// (ErrVersionConflict otherwise), so concurrent read-modify-write cycles cannot overwrite each other.
+// Server-side writers re-read and retry on ErrVersionConflict (see service.modifyProfile).
 func (r *userRepository) Update(ctx context.Context, profile *domain.UserProfile) error {
 	profile.UpdatedAt = time.Now()
 
-	if err := r.db.WithContext(ctx).Save(profile).Error; err != nil {
-		return err
-	}
-	return nil
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the bump locks the row: a concurrent update waits, then fails the version check
+		res := tx.Model(&domain.UserProfile{}).Where("id = ? AND version = ?", profile.ID, profile.Version).
+			Update("version", nextVersion)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+		profile.Version++
+		if err := tx.Save(profile).Error; err != nil {
+			profile.Version--
+			return err
+		}
+		return nil
+	})
 }
 
 // Search searches for user profiles with filters
@@ -204,7 +224,7 @@ func (r *userRepository) AddSkill(ctx context.Context, userID uint, skill string
 	profile.Skills = skillsJSON
 	profile.UpdatedAt = time.Now()
 
-	return r.db.WithContext(ctx).Model(profile).Update("skills", skillsJSON).Error
+	return r.db.WithContext(ctx).Model(profile).Updates(map[string]interface{}{"skills": skillsJSON, "version": nextVersion}).Error
 }
 
 // RemoveSkill removes a skill from user profile
@@ -238,7 +258,7 @@ func (r *userRepository) RemoveSkill(ctx context.Context, userID uint, skill str
 	profile.Skills = skillsJSON
 	profile.UpdatedAt = time.Now()
 
-	return r.db.WithContext(ctx).Model(profile).Update("skills", skillsJSON).Error
+	return r.db.WithContext(ctx).Model(profile).Updates(map[string]interface{}{"skills": skillsJSON, "version": nextVersion}).Error
 }
 
 // AddPortfolioItem adds a portfolio item to user profile (legacy)
@@ -266,7 +286,7 @@ func (r *userRepository) AddPortfolioItem(ctx context.Context, userID uint, item
 	profile.Portfolio = portfolioJSON
 	profile.UpdatedAt = time.Now()
 
-	return r.db.WithContext(ctx).Model(profile).Update("portfolio", portfolioJSON).Error
+	return r.db.WithContext(ctx).Model(profile).Updates(map[string]interface{}{"portfolio": portfolioJSON, "version": nextVersion}).Error
 }
 
 // UpdatePortfolioItem updates a portfolio item (legacy)
@@ -310,7 +330,7 @@ func (r *userRepository) UpdatePortfolioItem(ctx context.Context, userID uint, i
 			}
 			profile.Portfolio = portfolioJSON
 			profile.UpdatedAt = time.Now()
-			if err := r.db.WithContext(ctx).Model(profile).Update("portfolio", portfolioJSON).Error; err != nil {
+			if err := r.db.WithContext(ctx).Model(profile).Updates(map[string]interface{}{"portfolio": portfolioJSON, "version": nextVersion}).Error; err != nil {
 				return nil, err
 			}
 			return &portfolio[i], nil
@@ -351,5 +371,5 @@ func (r *userRepository) DeletePortfolioItem(ctx context.Context, userID uint, i
 	profile.Portfolio = portfolioJSON
 	profile.UpdatedAt = time.Now()
 
-	return r.db.WithContext(ctx).Model(profile).Update("portfolio", portfolioJSON).Error
+	return r.db.WithContext(ctx).Model(profile).Updates(map[string]interface{}{"portfolio": portfolioJSON, "version": nextVersion}).Error
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,6 +16,10 @@ import (
 var (
 	// ErrProfileExists indicates profile already exists
 	ErrProfileExists = errors.New("profile already exists")
+	// ErrVerifiedProject indicates a project from a completed contract, which cannot be deleted
+	ErrVerifiedProject = errors.New("verified projects cannot be deleted; hide them with show_contracts")
+	// ErrTestimonialNotFound indicates the testimonial is not on the profile
+	ErrTestimonialNotFound = errors.New("testimonial not found")
 )
 
 // ProfileService handles profile creation and management
@@ -98,20 +103,6 @@ func (s *ProfileService) checkProfileComplete(req *dto.CreateProfileRequest) boo
 
 // AddProject adds a project to user profile
 func (s *ProfileService) AddProject(ctx context.Context, userID uint, req *dto.AddProjectRequest) (*domain.Project, error) {
-	// Get existing profile
-	profile, err := s.userRepo.FindByUserID(ctx, userID)
-	if err != nil {
-		return nil, err
-	}
-
-	// Parse existing projects
-	var projects []domain.Project
-	if len(profile.Projects) > 0 {
-		if err := json.Unmarshal(profile.Projects, &projects); err != nil {
-			return nil, err
-		}
-	}
-
 	// Create project
 	project := &domain.Project{
 		ID:            uuid.New().String(),
@@ -140,166 +131,390 @@ func (s *ProfileService) AddProject(ctx context.Context, userID uint, req *dto.A
 		}
 	}
 
-	// Add to projects array
-	projects = append(projects, *project)
-
-	// Update stats
-	var stats map[string]interface{}
-	if len(profile.Stats) > 0 {
-		if err := json.Unmarshal(profile.Stats, &stats); err != nil {
-			stats = make(map[string]interface{})
+	err := modifyProfile(ctx, s.userRepo, userID, func(profile *domain.UserProfile) error {
+		// Parse existing projects
+		var projects []domain.Project
+		if len(profile.Projects) > 0 {
+			if err := json.Unmarshal(profile.Projects, &projects); err != nil {
+				return err
+			}
 		}
-	} else {
-		stats = make(map[string]interface{})
-	}
-	stats["no_of_projects_done"] = len(projects)
-	statsJSON, _ := json.Marshal(stats)
 
-	// Marshal projects to JSONB
-	projectsJSON, err := json.Marshal(projects)
+		// Add to projects array and marshal projects and stats to JSONB
+		projects = append(projects, *project)
+		return setProjects(profile, projects)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Update profile
-	profile.Projects = projectsJSON
-	profile.Stats = statsJSON
-	profile.UpdatedAt = time.Now()
-
-	if err := s.userRepo.Update(ctx, profile); err != nil {
-		return nil, err
-	}
-
 	return project, nil
 }
 
 // UpdateProject updates a project
 func (s *ProfileService) UpdateProject(ctx context.Context, userID uint, projectID string, req *dto.UpdateProjectRequest) (*domain.Project, error) {
-	profile, err := s.userRepo.FindByUserID(ctx, userID)
+	var updated *domain.Project
+	err := modifyProfile(ctx, s.userRepo, userID, func(profile *domain.UserProfile) error {
+		// Parse existing projects
+		var projects []domain.Project
+		if len(profile.Projects) > 0 {
+			if err := json.Unmarshal(profile.Projects, &projects); err != nil {
+				return err
+			}
+		}
+
+		// Find and update project
+		for i := range projects {
+			if projects[i].ID == projectID {
+				// Name and client of a verified project come from the contract
+				if req.ProjectName != "" && !projects[i].IsVerified {
+					projects[i].ProjectName = req.ProjectName
+				}
+				if req.Description != "" {
+					projects[i].Description = req.Description
+				}
+				if req.Screenshots != nil {
+					projects[i].Screenshots = req.Screenshots
+				}
+				if req.GitHubLink != "" {
+					projects[i].GitHubLink = req.GitHubLink
+				}
+				if req.LiveLink != "" {
+					projects[i].LiveLink = req.LiveLink
+				}
+				if req.DriveLink != "" {
+					projects[i].DriveLink = req.DriveLink
+				}
+				if req.VideoLink != "" {
+					projects[i].VideoLink = req.VideoLink
+				}
+				if req.Technologies != nil {
+					projects[i].Technologies = req.Technologies
+				}
+				if req.ClientName != "" && !projects[i].IsVerified {
+					projects[i].ClientName = req.ClientName
+				}
+				if req.OtherLinks != nil {
+					projects[i].OtherLinks = make([]domain.ProjectLink, len(req.OtherLinks))
+					for j, link := range req.OtherLinks {
+						projects[i].OtherLinks[j] = domain.ProjectLink{
+							Label: link.Label,
+							URL:   link.URL,
+						}
+					}
+				}
+				projects[i].UpdatedAt = time.Now().Format(time.RFC3339)
+
+				// Marshal back to JSONB
+				projectsJSON, err := json.Marshal(projects)
+				if err != nil {
+					return err
+				}
+				profile.Projects = projectsJSON
+				updated = &projects[i]
+				return nil
+			}
+		}
+		return errors.New("project not found")
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Parse existing projects
-	var projects []domain.Project
-	if len(profile.Projects) > 0 {
-		if err := json.Unmarshal(profile.Projects, &projects); err != nil {
-			return nil, err
-		}
-	}
+	return updated, nil
+}
 
-	// Find and update project
-	for i := range projects {
-		if projects[i].ID == projectID {
-			if req.ProjectName != "" {
-				projects[i].ProjectName = req.ProjectName
-			}
-			if req.Description != "" {
-				projects[i].Description = req.Description
-			}
-			if req.Screenshots != nil {
-				projects[i].Screenshots = req.Screenshots
-			}
-			if req.GitHubLink != "" {
-				projects[i].GitHubLink = req.GitHubLink
-			}
-			if req.LiveLink != "" {
-				projects[i].LiveLink = req.LiveLink
-			}
-			if req.DriveLink != "" {
-				projects[i].DriveLink = req.DriveLink
-			}
-			if req.VideoLink != "" {
-				projects[i].VideoLink = req.VideoLink
-			}
-			if req.Technologies != nil {
-				projects[i].Technologies = req.Technologies
-			}
-			if req.ClientName != "" {
-				projects[i].ClientName = req.ClientName
+// DeleteProject deletes a project
+func (s *ProfileService) DeleteProject(ctx context.Context, userID uint, projectID string) error {
+	return modifyProfile(ctx, s.userRepo, userID, func(profile *domain.UserProfile) error {
+		// Parse existing projects
+		var projects []domain.Project
+		if len(profile.Projects) > 0 {
+			if err := json.Unmarshal(profile.Projects, &projects); err != nil {
+				return err
 			}
-			if req.OtherLinks != nil {
-				projects[i].OtherLinks = make([]domain.ProjectLink, len(req.OtherLinks))
-				for j, link := range req.OtherLinks {
-					projects[i].OtherLinks[j] = domain.ProjectLink{
-						Label: link.Label,
-						URL:   link.URL,
-					}
+		}
+
+		// Remove project
+		newProjects := make([]domain.Project, 0, len(projects))
+		found := false
+		for _, project := range projects {
+			if project.ID != projectID {
+				newProjects = append(newProjects, project)
+			} else {
+				if project.IsVerified {
+					return ErrVerifiedProject
 				}
+				found = true
 			}
-			projects[i].UpdatedAt = time.Now().Format(time.RFC3339)
+		}
 
-			// Marshal back to JSONB
-			projectsJSON, err := json.Marshal(projects)
-			if err != nil {
-				return nil, err
-			}
+		if !found {
+			return errors.New("project not found")
+		}
 
-			profile.Projects = projectsJSON
-			profile.UpdatedAt = time.Now()
+		// Marshal back to JSONB
+		return setProjects(profile, newProjects)
+	})
+}
 
-			if err := s.userRepo.Update(ctx, profile); err != nil {
-				return nil, err
+// RecordContractCompletion adds a verified project for a completed contract to the freelancer's profile.
+// It is idempotent per contract: a repeated event returns the existing project with created=false.
+func (s *ProfileService) RecordContractCompletion(ctx context.Context, evt *dto.ContractCompletedEvent) (*domain.Project, bool, error) {
+	var recorded *domain.Project
+	created := false
+	err := modifyProfile(ctx, s.userRepo, evt.FreelancerUserID, func(profile *domain.UserProfile) error {
+		var projects []domain.Project
+		if len(profile.Projects) > 0 {
+			if err := json.Unmarshal(profile.Projects, &projects); err != nil {
+				return err
+			}
+		}
+		for i := range projects {
+			if projects[i].ContractID != nil && *projects[i].ContractID == evt.ContractID {
+				recorded, created = &projects[i], false
+				return errProfileUnchanged
 			}
+		}
 
-			return &projects[i], nil
+		clientName := evt.ClientCompanyName
+		if clientName == "" {
+			clientName = evt.ClientName
 		}
+		contractID := evt.ContractID
+		onTime := evt.OnTime
+		now := time.Now().Format(time.RFC3339)
+		project := domain.Project{
+			ID:            uuid.New().String(),
+			ProjectName:   evt.ProjectName,
+			Description:   truncateRunes(evt.Description, 1000),
+			ClientName:    clientName,
+			CompletedDate: evt.CompletedAt.Format(time.RFC3339),
+			ContractID:    &contractID,
+			IsVerified:    true,
+			OnTime:        &onTime,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		projects = append(projects, project)
+		recorded, created = &project, true
+		return setProjects(profile, projects)
+	})
+	if err != nil {
+		return nil, false, err
 	}
+	return recorded, created, nil
+}
+
+// RecordTestimonial adds the client's testimonial for a completed contract as verified. It is idempotent per
+// contract: a repeated event returns the existing testimonial with created=false.
+func (s *ProfileService) RecordTestimonial(ctx context.Context, evt *dto.TestimonialSubmittedEvent) (*domain.Testimonial, bool, error) {
+	var recorded *domain.Testimonial
+	created := false
+	err := modifyProfile(ctx, s.userRepo, evt.FreelancerUserID, func(profile *domain.UserProfile) error {
+		testimonials, err := profileTestimonials(profile)
+		if err != nil {
+			return err
+		}
+		for i := range testimonials {
+			if testimonials[i].ContractID != nil && *testimonials[i].ContractID == evt.ContractID {
+				recorded, created = &testimonials[i], false
+				return errProfileUnchanged
+			}
+		}
 
-	return nil, errors.New("project not found")
+		clientName := evt.ClientName
+		if evt.ClientCompanyName != "" {
+			clientName = evt.ClientName + ", " + evt.ClientCompanyName
+		}
+		contractID := evt.ContractID
+		testimonial := domain.Testimonial{
+			ID:          uuid.New().String(),
+			ClientName:  truncateRunes(clientName, 200),
+			ClientEmail: evt.ClientEmail,
+			Rating:      evt.Rating,
+			Comment:     truncateRunes(evt.Comment, 2000),
+			ProjectName: evt.ProjectName,
+			IsVerified:  true,
+			CreatedAt:   evt.SubmittedAt.UTC().Format(time.RFC3339),
+			ContractID:  &contractID,
+		}
+		testimonials = append(testimonials, testimonial)
+
+		testimonialsJSON, err := json.Marshal(testimonials)
+		if err != nil {
+			return err
+		}
+		profile.Testimonials = testimonialsJSON
+		recorded, created = &testimonial, true
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return recorded, created, nil
 }
 
-// DeleteProject deletes a project
-func (s *ProfileService) DeleteProject(ctx context.Context, userID uint, projectID string) error {
-	profile, err := s.userRepo.FindByUserID(ctx, userID)
+// SetTestimonialHidden hides or shows one of the user's testimonials on the public profile. Content stays as the
+// client wrote it.
+func (s *ProfileService) SetTestimonialHidden(ctx context.Context, userID uint, testimonialID string, hidden bool) (*domain.Testimonial, error) {
+	var found *domain.Testimonial
+	err := modifyProfile(ctx, s.userRepo, userID, func(profile *domain.UserProfile) error {
+		testimonials, err := profileTestimonials(profile)
+		if err != nil {
+			return err
+		}
+		found = nil
+		for i := range testimonials {
+			if testimonials[i].ID == testimonialID {
+				found = &testimonials[i]
+				break
+			}
+		}
+		if found == nil {
+			return ErrTestimonialNotFound
+		}
+		if found.IsHidden == hidden {
+			return errProfileUnchanged
+		}
+		found.IsHidden = hidden
+
+		testimonialsJSON, err := json.Marshal(testimonials)
+		if err != nil {
+			return err
+		}
+		profile.Testimonials = testimonialsJSON
+		return nil
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return found, nil
+}
 
-	// Parse existing projects
-	var projects []domain.Project
-	if len(profile.Projects) > 0 {
-		if err := json.Unmarshal(profile.Projects, &projects); err != nil {
+func profileTestimonials(profile *domain.UserProfile) ([]domain.Testimonial, error) {
+	var testimonials []domain.Testimonial
+	if len(profile.Testimonials) > 0 {
+		if err := json.Unmarshal(profile.Testimonials, &testimonials); err != nil {
+			return nil, err
+		}
+	}
+	return testimonials, nil
+}
+
+// maxProfileWriteAttempts bounds how often modifyProfile re-reads a profile that another write changed meanwhile.
+const maxProfileWriteAttempts = 5
+
+// errProfileUnchanged is returned by a modifyProfile callback that has nothing to save.
+var errProfileUnchanged = errors.New("profile unchanged")
+
+// modifyProfile reads the profile of userID, applies fn and saves it. Update checks the version, so when another
+// write lands in between, the profile is read again and fn re-applied instead of failing or overwriting that write.
+// Only PUT /users/me surfaces the conflict (as 412 with If-Match); server-side read-modify-write paths use this.
+// After maxProfileWriteAttempts the conflict is returned.
+func modifyProfile(ctx context.Context, repo repository.UserRepository, userID uint, fn func(profile *domain.UserProfile) error) error {
+	var err error
+	for attempt := 0; attempt < maxProfileWriteAttempts; attempt++ {
+		var profile *domain.UserProfile
+		if profile, err = repo.FindByUserID(ctx, userID); err != nil {
+			return err
+		}
+		if err = fn(profile); err != nil {
+			if errors.Is(err, errProfileUnchanged) {
+				return nil
+			}
+			return err
+		}
+		profile.UpdatedAt = time.Now()
+		if err = repo.Update(ctx, profile); !errors.Is(err, repository.ErrVersionConflict) {
 			return err
 		}
 	}
+	return err
+}
 
-	// Remove project
-	newProjects := make([]domain.Project, 0, len(projects))
-	found := false
-	for _, project := range projects {
-		if project.ID != projectID {
-			newProjects = append(newProjects, project)
-		} else {
-			found = true
+// RecordReputation stores the freelancer's aggregate reputation from contract-service in the profile stats
+// (reputation_score, reputation_contracts, reputation_formula_version). An event older than the stored one is
+// ignored and reported with applied=false.
+func (s *ProfileService) RecordReputation(ctx context.Context, evt *dto.ReputationUpdatedEvent) (bool, error) {
+	applied := false
+	err := modifyProfile(ctx, s.userRepo, evt.FreelancerUserID, func(profile *domain.UserProfile) error {
+		stats := make(map[string]interface{})
+		if len(profile.Stats) > 0 {
+			if err := json.Unmarshal(profile.Stats, &stats); err != nil || stats == nil {
+				stats = make(map[string]interface{})
+			}
+		}
+		if prev, ok := stats["reputation_updated_at"].(string); ok {
+			if t, err := time.Parse(time.RFC3339Nano, prev); err == nil && t.After(evt.UpdatedAt) {
+				applied = false
+				return errProfileUnchanged
+			}
+		}
+		stats["reputation_score"] = evt.Score
+		stats["reputation_contracts"] = evt.ContractsScored
+		stats["reputation_formula_version"] = evt.FormulaVersion
+		stats["reputation_updated_at"] = evt.UpdatedAt.UTC().Format(time.RFC3339Nano)
+		statsJSON, err := json.Marshal(stats)
+		if err != nil {
+			return err
 		}
+
+		profile.Stats = statsJSON
+		applied = true
+		return nil
+	})
+	if err != nil {
+		return false, err
 	}
+	return applied, nil
+}
 
-	if !found {
-		return errors.New("project not found")
+// setProjects stores projects on the profile and refreshes the stats derived from them:
+// no_of_projects_done (all projects) and on_time_completion (% of verified projects delivered on time).
+// Other stats keys are kept.
+func setProjects(profile *domain.UserProfile, projects []domain.Project) error {
+	projectsJSON, err := json.Marshal(projects)
+	if err != nil {
+		return err
 	}
 
-	// Update stats
-	var stats map[string]interface{}
+	stats := make(map[string]interface{})
 	if len(profile.Stats) > 0 {
-		if err := json.Unmarshal(profile.Stats, &stats); err != nil {
+		if err := json.Unmarshal(profile.Stats, &stats); err != nil || stats == nil {
 			stats = make(map[string]interface{})
 		}
+	}
+	stats["no_of_projects_done"] = len(projects)
+	verified, onTime := 0, 0
+	for _, p := range projects {
+		if !p.IsVerified || p.OnTime == nil {
+			continue
+		}
+		verified++
+		if *p.OnTime {
+			onTime++
+		}
+	}
+	if verified > 0 {
+		stats["on_time_completion"] = math.Round(float64(onTime)*1000/float64(verified)) / 10
 	} else {
-		stats = make(map[string]interface{})
+		delete(stats, "on_time_completion")
 	}
-	stats["no_of_projects_done"] = len(newProjects)
-	statsJSON, _ := json.Marshal(stats)
-
-	// Marshal back to JSONB
-	projectsJSON, err := json.Marshal(newProjects)
+	statsJSON, err := json.Marshal(stats)
 	if err != nil {
 		return err
 	}
 
 	profile.Projects = projectsJSON
 	profile.Stats = statsJSON
-	profile.UpdatedAt = time.Now()
+	return nil
+}
 
-	return s.userRepo.Update(ctx, profile)
+func truncateRunes(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max])
 }
@@ -8,10 +8,12 @@ import (
 
 	"encoding/json"
 	"fmt"
+	"log"
 	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/saiyam0211/defellix/services/user-service/internal/contracts"
 	"github.com/saiyam0211/defellix/services/user-service/internal/domain"
 	"github.com/saiyam0211/defellix/services/user-service/internal/dto"
 	"github.com/saiyam0211/defellix/services/user-service/internal/repository"
@@ -47,13 +49,15 @@ func normaliseUserName(s string) (string, error) {
 
 // UserService handles user profile business logic
 type UserService struct {
-	userRepo repository.UserRepository
+	userRepo  repository.UserRepository
+	contracts contracts.SummaryClient
 }
 
-// NewUserService creates a new user service
-func NewUserService(userRepo repository.UserRepository) *UserService {
+// NewUserService creates a new user service; contractClient supplies completed contracts for public profiles
+func NewUserService(userRepo repository.UserRepository, contractClient contracts.SummaryClient) *UserService {
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:  userRepo,
+		contracts: contractClient,
 	}
 }
 
@@ -93,11 +97,58 @@ func (s *UserService) GetPublicProfileByUserName(ctx context.Context, userName s
 	if !profile.IsActive {
 		return nil, ErrProfileNotFound
 	}
-	return s.toPublicProfileResponse(profile), nil
+	out := s.toPublicProfileResponse(profile)
+	if profile.ShowContracts {
+		// contract-service being down should not take public profiles with it
+		list, err := s.contracts.ListSummaries(ctx, profile.UserID)
+		if err != nil {
+			log.Printf("public profile %s: contract summaries: %v", profile.UserName, err)
+		}
+		out.Contracts = list
+		applyContractVisibility(out, list)
+	}
+	return out, nil
+}
+
+// applyContractVisibility redacts contract-linked projects and testimonials the same way contract-service redacted
+// the summaries: a contract missing from them (hidden, or contract-service unreachable) is not linked at all, and
+// the client is named only when its summary names them.
+func applyContractVisibility(out *dto.PublicProfileResponse, summaries []dto.ContractSummary) {
+	byID := make(map[uint]dto.ContractSummary, len(summaries))
+	for _, c := range summaries {
+		byID[c.ContractID] = c
+	}
+	projects := out.Projects[:0]
+	for _, p := range out.Projects {
+		if p.ContractID != nil {
+			c, ok := byID[*p.ContractID]
+			if !ok {
+				continue
+			}
+			p.ClientName = c.ClientName
+		}
+		projects = append(projects, p)
+	}
+	out.Projects = projects
+	for i := range out.Testimonials {
+		t := &out.Testimonials[i]
+		if t.ContractID == nil {
+			continue
+		}
+		c, ok := byID[*t.ContractID]
+		if !ok {
+			t.ContractID = nil
+			t.ProjectName = ""
+		}
+		if c.ClientName == "" {
+			t.ClientName = ""
+		}
+	}
 }
 
-// UpdateProfile updates a user profile
-func (s *UserService) UpdateProfile(ctx context.Context, userID uint, req *dto.UpdateProfileRequest) (*dto.UserProfileResponse, error) {
+// UpdateProfile updates a user profile. expectedVersion is the If-Match version (0 = no precondition);
+// repository.ErrVersionConflict when it is stale or another write lands first.
+func (s *UserService) UpdateProfile(ctx context.Context, userID uint, expectedVersion int, req *dto.UpdateProfileRequest) (*dto.UserProfileResponse, error) {
 	// Get existing profile
 	profile, err := s.userRepo.FindByUserID(ctx, userID)
 	if err != nil {
@@ -111,6 +162,9 @@ func (s *UserService) UpdateProfile(ctx context.Context, userID uint, req *dto.U
 			return nil, err
 		}
 	}
+	if expectedVersion > 0 && profile.Version != expectedVersion {
+		return nil, repository.ErrVersionConflict
+	}
 
 	// Update fields
 	if req.FullName != "" {
@@ -285,19 +339,6 @@ func (s *UserService) RemoveSkill(ctx context.Context, userID uint, skill string
 
 // AddPortfolioItem adds a portfolio item to user profile (legacy - use projects instead)
 func (s *UserService) AddPortfolioItem(ctx context.Context, userID uint, req *dto.AddPortfolioRequest) (*dto.PortfolioItem, error) {
-	profile, err := s.userRepo.FindByUserID(ctx, userID)
-	if err != nil {
-		return nil, err
-	}
-
-	// Parse existing portfolio
-	var portfolio []domain.PortfolioItem
-	if len(profile.Portfolio) > 0 {
-		if err := json.Unmarshal(profile.Portfolio, &portfolio); err != nil {
-			return nil, err
-		}
-	}
-
 	// Create new item
 	item := &domain.PortfolioItem{
 		ID:           uuid.New().String(),
@@ -309,17 +350,25 @@ func (s *UserService) AddPortfolioItem(ctx context.Context, userID uint, req *dt
 		CreatedAt:    time.Now().Format(time.RFC3339),
 	}
 
-	// Add to portfolio
-	portfolio = append(portfolio, *item)
-	portfolioJSON, err := json.Marshal(portfolio)
-	if err != nil {
-		return nil, err
-	}
-
-	profile.Portfolio = portfolioJSON
-	profile.UpdatedAt = time.Now()
+	err := modifyProfile(ctx, s.userRepo, userID, func(profile *domain.UserProfile) error {
+		// Parse existing portfolio
+		var portfolio []domain.PortfolioItem
+		if len(profile.Portfolio) > 0 {
+			if err := json.Unmarshal(profile.Portfolio, &portfolio); err != nil {
+				return err
+			}
+		}
 
-	if err := s.userRepo.Update(ctx, profile); err != nil {
+		// Add to portfolio
+		portfolio = append(portfolio, *item)
+		portfolioJSON, err := json.Marshal(portfolio)
+		if err != nil {
+			return err
+		}
+		profile.Portfolio = portfolioJSON
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -444,6 +493,7 @@ func (s *UserService) toProfileResponse(profile *domain.UserProfile) *dto.UserPr
 		IsActive:          profile.IsActive,
 		IsVerified:        profile.IsVerified,
 		IsProfileComplete: profile.IsProfileComplete,
+		Version:           profile.Version,
 		CreatedAt:         profile.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:         profile.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
@@ -476,9 +526,33 @@ func (s *UserService) toPublicProfileResponse(profile *domain.UserProfile) *dto.
 	if profile.ShowProjects && len(profile.Projects) > 0 {
 		var projs []domain.Project
 		if err := json.Unmarshal(profile.Projects, &projs); err == nil {
-			out.Projects = make([]dto.ProjectResponse, len(projs))
+			out.Projects = make([]dto.ProjectResponse, 0, len(projs))
 			for i := range projs {
-				out.Projects[i] = s.toProjectResponse(&projs[i])
+				// Projects from contracts are only public when the owner shows contracts
+				if projs[i].ContractID != nil && !profile.ShowContracts {
+					continue
+				}
+				out.Projects = append(out.Projects, s.toProjectResponse(&projs[i]))
+			}
+		}
+	}
+	if profile.ShowProfile && len(profile.Testimonials) > 0 {
+		var testis []domain.Testimonial
+		if err := json.Unmarshal(profile.Testimonials, &testis); err == nil {
+			out.Testimonials = make([]dto.TestimonialResponse, 0, len(testis))
+			for i := range testis {
+				if testis[i].IsHidden {
+					continue
+				}
+				t := s.toTestimonialResponse(&testis[i])
+				// Which contract a testimonial came from is only public when the owner shows contracts; without the
+				// contract summaries there is no record of the client's consent to be named either
+				if t.ContractID != nil && !profile.ShowContracts {
+					t.ContractID = nil
+					t.ProjectName = ""
+					t.ClientName = ""
+				}
+				out.Testimonials = append(out.Testimonials, t)
 			}
 		}
 	}
@@ -507,6 +581,9 @@ func (s *UserService) toProjectResponse(project *domain.Project) dto.ProjectResp
 		Technologies:  project.Technologies,
 		ClientName:    project.ClientName,
 		CompletedDate: project.CompletedDate,
+		ContractID:    project.ContractID,
+		IsVerified:    project.IsVerified,
+		OnTime:        project.OnTime,
 		CreatedAt:     project.CreatedAt,
 		UpdatedAt:     project.UpdatedAt,
 	}
@@ -521,6 +598,8 @@ func (s *UserService) toTestimonialResponse(testimonial *domain.Testimonial) dto
 		ProjectName: testimonial.ProjectName,
 		IsVerified:  testimonial.IsVerified,
 		CreatedAt:   testimonial.CreatedAt,
+		ContractID:  testimonial.ContractID,
+		IsHidden:    testimonial.IsHidden,
 	}
 }
 